@@ -6,6 +6,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/marcelorc13/timesheet-pro/internal/events"
+	"github.com/marcelorc13/timesheet-pro/internal/export"
 	"github.com/marcelorc13/timesheet-pro/internal/repository"
 	"github.com/marcelorc13/timesheet-pro/internal/server"
 	"github.com/marcelorc13/timesheet-pro/internal/server/api"
@@ -16,6 +18,20 @@ import (
 func main() {
 	_ = godotenv.Load()
 	connString := os.Getenv("POSTGRES_URL")
+	// Kept apart from JWT_SECRET: rotating the session key must not invalidate
+	// every kiosk PIN, nor leaking one expose the other
+	kioskPINKey := []byte(os.Getenv("KIOSK_PIN_SECRET"))
+	if len(kioskPINKey) == 0 {
+		panic("KIOSK_PIN_SECRET não definida")
+	}
+	software := export.Software{
+		Document:       os.Getenv("AFD_DEVELOPER_CNPJ"),
+		Registration:   os.Getenv("AFD_INPI_REGISTRATION"),
+		Model:          "TIMESHEET PRO",
+		Version:        "1.0",
+		DeveloperName:  os.Getenv("AFD_DEVELOPER_NAME"),
+		DeveloperEmail: os.Getenv("AFD_DEVELOPER_EMAIL"),
+	}
 
 	ctx := context.Background()
 
@@ -26,6 +42,9 @@ func main() {
 	}
 
 	r := gin.Default()
+	if err := server.TrustProxies(r, os.Getenv("TRUSTED_PROXIES"), os.Getenv("TRUSTED_PLATFORM")); err != nil {
+		panic(err)
+	}
 
 	router := server.NewRouter(r)
 
@@ -33,22 +52,109 @@ func main() {
 	us := service.NewUserService(*ur)
 	uh := api.NewUserHandler(*us)
 
+	txm := repository.NewTxManager(db)
+	ar := repository.NewAuditRepository(db)
+	wr := repository.NewWebhookRepository(db)
+
 	or := repository.NewOrganizationRepository(db)
-	os := service.NewOrganizationService(*or, *ur)
+	os := service.NewOrganizationService(*or, *ur, ar, wr, txm)
 	oh := api.NewOrganizationHandler(*os)
 
+	as := service.NewAuditService(ar, or)
+	ah := api.NewAuditHandler(as)
+
+	// Team setup
+	tmr := repository.NewTeamRepository(db)
+	tms := service.NewTeamService(tmr, or)
+	tmh := api.NewTeamHandler(tms)
+
 	// Timesheet setup
+	bus := events.NewBus()
 	tr := repository.NewTimesheetRepository(db)
-	ts := service.NewTimesheetService(tr, or)
+	rr := repository.NewReceiptRepository(db)
+	ppr := repository.NewPunchPolicyRepository(db)
+	ts := service.NewTimesheetService(tr, or, tmr, ar, rr, wr, ppr, txm, bus)
 	th := api.NewTimesheetHandler(ts)
+	prh := api.NewPresenceHandler(ts)
+
+	rs := service.NewReceiptService(rr, or)
+	rh := api.NewReceiptHandler(rs)
+
+	scr := repository.NewScheduleRepository(db)
+	scs := service.NewScheduleService(scr, or)
+	sch := api.NewScheduleHandler(scs)
+
+	es := service.NewExportService(tr, or, scr, software)
+	eh := api.NewExportHandler(es)
+
+	// Espelho de ponto setup
+	sgr := repository.NewSignatureRepository(db)
+	ess := service.NewEspelhoService(ts, or, ur, sgr)
+	esh := api.NewEspelhoHandler(ess)
+
+	// Payroll setup
+	pr := repository.NewPayrollRepository(db)
+	ps := service.NewPayrollService(tr, or, scr, pr, ar, txm)
+	ph := api.NewPayrollHandler(ps)
+
+	// Punch chain setup
+	skr := repository.NewSigningKeyRepository(db)
+	pcs := service.NewPunchChainService(tr, skr, or)
+	pch := api.NewPunchChainHandler(pcs)
+
+	// Webhook setup
+	ws := service.NewWebhookService(wr, or, ar, txm)
+	wh := api.NewWebhookHandler(ws)
+	go ws.Run(ctx)
+
+	// Kiosk setup
+	kr := repository.NewKioskRepository(db)
+	ks := service.NewKioskService(kr, or, ar, txm, ts, kioskPINKey)
+	kh := api.NewKioskHandler(ks)
+
+	// Punch policy setup
+	pps := service.NewPunchPolicyService(ppr, or, ar, txm)
+	pph := api.NewPunchPolicyHandler(pps)
+
+	// Compliance setup
+	cs := service.NewComplianceService(tr, or)
+	ch := api.NewComplianceHandler(cs)
+
+	// Project setup
+	prjr := repository.NewProjectRepository(db)
+	prjs := service.NewProjectService(prjr, tr, or, ar, txm)
+	prjh := api.NewProjectHandler(prjs)
+
+	// Billing setup
+	br := repository.NewBillingRepository(db)
+	bs := service.NewBillingService(br, or, ar, txm)
+	bh := api.NewBillingHandler(bs)
+
+	// Weekly submission setup
+	twr := repository.NewTimesheetWeekRepository(db)
+	tws := service.NewTimesheetWeekService(twr, tr, or, ar, wr, txm, ts)
+	twh := api.NewTimesheetWeekHandler(tws)
+	go tws.RunReminders(ctx)
 
 	// View handlers
 	ovh := views.NewOrganizationViewHandler(*os, *us)
-	tvh := views.NewTimesheetViewHandler(ts, os)
+	tvh := views.NewTimesheetViewHandler(ts, os, prjs, tws)
 	pvh := views.NewProfileViewHandler(us)
+	tmvh := views.NewTeamViewHandler(tms, os)
+	avh := views.NewAuditViewHandler(as, os)
+	evh := views.NewExportViewHandler(os, ps)
+	esvh := views.NewEspelhoViewHandler(ess, os)
+	prvh := views.NewPresenceViewHandler(ts, os)
+	whvh := views.NewWebhookViewHandler(ws, os)
+	kvh := views.NewKioskViewHandler(ks, os)
+	ppvh := views.NewPunchPolicyViewHandler(pps, os)
+	cvh := views.NewComplianceViewHandler(cs, os)
+	prjvh := views.NewProjectViewHandler(prjs, bs, os)
+	bvh := views.NewBillingViewHandler(bs, os)
+	twvh := views.NewTimesheetWeekViewHandler(tws, os)
 
-	router.APIRoutes(*uh, *oh, *th)
-	router.ViewsRoutes(*ovh, *tvh, *pvh, or)
+	router.APIRoutes(*uh, *oh, *th, *tmh, *ah, *pch, *rh, *eh, *sch, *esh, *ph, *prh, *wh, *kh, *pph, *ch, *prjh, *bh, *twh)
+	router.ViewsRoutes(*ovh, *tvh, *pvh, *tmvh, *avh, *evh, *esvh, *prvh, *whvh, *kvh, *ppvh, *cvh, *prjvh, *bvh, *twvh, or)
 
 	router.Start()
 }
@@ -0,0 +1,117 @@
+// Command chaincheck verifies the tamper-evident punch chain.
+//
+// With -org it walks the organization chain stored in the database (POSTGRES_URL)
+// and checks it ends at the stored chain head, so deleted trailing punches are
+// caught. With -file it checks a signed export offline, optionally against a
+// public key obtained out of band with -pubkey (base64). It prints "cadeia
+// íntegra" and exits with status 0 only when every check passes; it exits with
+// status 1 on any break and 2 when the check cannot run.
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	"github.com/marcelorc13/timesheet-pro/internal/repository"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+)
+
+func main() {
+	orgFlag := flag.String("org", "", "ID da organização a verificar no banco")
+	fileFlag := flag.String("file", "", "arquivo de exportação assinado a verificar offline")
+	pubkeyFlag := flag.String("pubkey", "", "chave pública Ed25519 da organização (base64)")
+	flag.Parse()
+
+	var breaks []domain.ChainBreak
+	var err error
+
+	switch {
+	case *fileFlag != "":
+		breaks, err = checkFile(*fileFlag, *pubkeyFlag)
+	case *orgFlag != "":
+		breaks, err = checkDatabase(*orgFlag)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "erro:", err)
+		os.Exit(2)
+	}
+
+	if len(breaks) > 0 {
+		for _, b := range breaks {
+			if b.EntryID == uuid.Nil {
+				fmt.Printf("quebra na sequência %d: %s\n", b.Sequence, b.Reason)
+				continue
+			}
+			fmt.Printf("quebra na sequência %d (registro %s): %s\n", b.Sequence, b.EntryID, b.Reason)
+		}
+		os.Exit(1)
+	}
+
+	fmt.Println("cadeia íntegra")
+}
+
+func checkDatabase(org string) ([]domain.ChainBreak, error) {
+	orgID, err := uuid.Parse(org)
+	if err != nil {
+		return nil, fmt.Errorf("ID da organização inválido")
+	}
+
+	_ = godotenv.Load()
+	ctx := context.Background()
+
+	db := repository.NewPool(ctx, os.Getenv("POSTGRES_URL"))
+	defer db.Close()
+
+	pcs := service.NewPunchChainService(repository.NewTimesheetRepository(db), repository.NewSigningKeyRepository(db), repository.NewOrganizationRepository(db))
+	report, err := pcs.VerifyOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("%d registros verificados, %d anteriores à cadeia, hash final %s\n", report.Records, report.Unchained, report.HeadHash)
+	fmt.Printf("topo gravado: sequência %d, hash %s\n", report.StoredHead.Sequence, report.StoredHead.Hash)
+	return report.Breaks, nil
+}
+
+func checkFile(path, pubkey string) ([]domain.ChainBreak, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var signed domain.SignedPunchChainExport
+	if err := json.Unmarshal(raw, &signed); err != nil {
+		return nil, fmt.Errorf("arquivo de exportação inválido: %w", err)
+	}
+
+	var publicKey ed25519.PublicKey
+	if pubkey != "" {
+		decoded, err := base64.StdEncoding.DecodeString(pubkey)
+		if err != nil || len(decoded) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("chave pública inválida")
+		}
+		publicKey = decoded
+	} else {
+		fmt.Fprintln(os.Stderr, "aviso: sem -pubkey a assinatura é conferida com a chave embutida no arquivo")
+	}
+
+	export, breaks, err := domain.VerifyPunchChainExport(signed, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("%s: %d registros, assinatura válida, hash final %s\n", export.OrganizationName, len(export.Records), export.HeadHash)
+	return breaks, nil
+}
@@ -0,0 +1,245 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditAction identifies what an actor did inside an organization
+type AuditAction string
+
+const (
+	AuditOrganizationUpdate AuditAction = "organization.update"
+	AuditOrganizationDelete AuditAction = "organization.delete"
+	AuditMemberAdd          AuditAction = "member.add"
+	AuditMemberRemove       AuditAction = "member.remove"
+	AuditTimesheetApprove   AuditAction = "timesheet.approve"
+	AuditTimesheetReprove   AuditAction = "timesheet.reprove"
+	AuditPayrollUpdate      AuditAction = "payroll.update"
+	AuditWebhookCreate      AuditAction = "webhook.create"
+	AuditWebhookUpdate      AuditAction = "webhook.update"
+	AuditWebhookDelete      AuditAction = "webhook.delete"
+	AuditKioskRegister      AuditAction = "kiosk.register"
+	AuditKioskRevoke        AuditAction = "kiosk.revoke"
+	AuditKioskCredentials   AuditAction = "kiosk.credentials"
+	AuditPunchPolicyUpdate  AuditAction = "punch_policy.update"
+	AuditGeofenceCreate     AuditAction = "geofence.create"
+	AuditGeofenceDelete     AuditAction = "geofence.delete"
+	AuditPunchNetworkCreate AuditAction = "punch_network.create"
+	AuditPunchNetworkDelete AuditAction = "punch_network.delete"
+	AuditProjectCreate      AuditAction = "project.create"
+	AuditProjectUpdate      AuditAction = "project.update"
+	AuditProjectTaskCreate  AuditAction = "project_task.create"
+	AuditProjectTaskDelete  AuditAction = "project_task.delete"
+	AuditClientCreate       AuditAction = "client.create"
+	AuditClientUpdate       AuditAction = "client.update"
+	AuditMemberRateUpdate   AuditAction = "member_rate.update"
+	AuditInvoiceCreate      AuditAction = "invoice.create"
+	AuditInvoiceIssue       AuditAction = "invoice.issue"
+	AuditInvoiceDelete      AuditAction = "invoice.delete"
+	AuditWeekApprove        AuditAction = "timesheet_week.approve"
+	AuditWeekReject         AuditAction = "timesheet_week.reject"
+	AuditWeekSettingsUpdate AuditAction = "week_settings.update"
+	AuditPayPeriodClose     AuditAction = "pay_period.close"
+	AuditPayPeriodReopen    AuditAction = "pay_period.reopen"
+)
+
+// AuditActions lists every known action, used to build filters
+var AuditActions = []AuditAction{
+	AuditOrganizationUpdate,
+	AuditOrganizationDelete,
+	AuditMemberAdd,
+	AuditMemberRemove,
+	AuditTimesheetApprove,
+	AuditTimesheetReprove,
+	AuditPayrollUpdate,
+	AuditWebhookCreate,
+	AuditWebhookUpdate,
+	AuditWebhookDelete,
+	AuditKioskRegister,
+	AuditKioskRevoke,
+	AuditKioskCredentials,
+	AuditPunchPolicyUpdate,
+	AuditGeofenceCreate,
+	AuditGeofenceDelete,
+	AuditPunchNetworkCreate,
+	AuditPunchNetworkDelete,
+	AuditProjectCreate,
+	AuditProjectUpdate,
+	AuditProjectTaskCreate,
+	AuditProjectTaskDelete,
+	AuditClientCreate,
+	AuditClientUpdate,
+	AuditMemberRateUpdate,
+	AuditInvoiceCreate,
+	AuditInvoiceIssue,
+	AuditInvoiceDelete,
+	AuditWeekApprove,
+	AuditWeekReject,
+	AuditWeekSettingsUpdate,
+	AuditPayPeriodClose,
+	AuditPayPeriodReopen,
+}
+
+// Label returns the action description shown to users
+func (a AuditAction) Label() string {
+	switch a {
+	case AuditOrganizationUpdate:
+		return "Organização atualizada"
+	case AuditOrganizationDelete:
+		return "Organização removida"
+	case AuditMemberAdd:
+		return "Membro adicionado"
+	case AuditMemberRemove:
+		return "Membro removido"
+	case AuditTimesheetApprove:
+		return "Ponto aprovado"
+	case AuditTimesheetReprove:
+		return "Ponto reprovado"
+	case AuditPayrollUpdate:
+		return "Folha de pagamento configurada"
+	case AuditWebhookCreate:
+		return "Webhook criado"
+	case AuditWebhookUpdate:
+		return "Webhook alterado"
+	case AuditWebhookDelete:
+		return "Webhook removido"
+	case AuditKioskRegister:
+		return "Terminal de ponto cadastrado"
+	case AuditKioskRevoke:
+		return "Terminal de ponto revogado"
+	case AuditKioskCredentials:
+		return "Credenciais de terminal alteradas"
+	case AuditPunchPolicyUpdate:
+		return "Política de registro de ponto alterada"
+	case AuditGeofenceCreate:
+		return "Área de registro de ponto criada"
+	case AuditGeofenceDelete:
+		return "Área de registro de ponto removida"
+	case AuditPunchNetworkCreate:
+		return "Rede de registro de ponto adicionada"
+	case AuditPunchNetworkDelete:
+		return "Rede de registro de ponto removida"
+	case AuditProjectCreate:
+		return "Projeto criado"
+	case AuditProjectUpdate:
+		return "Projeto alterado"
+	case AuditProjectTaskCreate:
+		return "Tarefa de projeto criada"
+	case AuditProjectTaskDelete:
+		return "Tarefa de projeto removida"
+	case AuditClientCreate:
+		return "Cliente criado"
+	case AuditClientUpdate:
+		return "Cliente alterado"
+	case AuditMemberRateUpdate:
+		return "Valor/hora de membro alterado"
+	case AuditInvoiceCreate:
+		return "Rascunho de fatura gerado"
+	case AuditInvoiceIssue:
+		return "Fatura emitida"
+	case AuditInvoiceDelete:
+		return "Rascunho de fatura descartado"
+	case AuditWeekApprove:
+		return "Semana aprovada"
+	case AuditWeekReject:
+		return "Semana rejeitada"
+	case AuditWeekSettingsUpdate:
+		return "Envio semanal alterado"
+	case AuditPayPeriodClose:
+		return "Período de folha fechado"
+	case AuditPayPeriodReopen:
+		return "Período de folha reaberto"
+	default:
+		return string(a)
+	}
+}
+
+// AuditLog is an append-only record of an administrative change. The actor
+// name is stored with the entry; ActorID is nil once the user is deleted.
+type AuditLog struct {
+	ID             int64           `json:"id"`
+	OrganizationID uuid.UUID       `json:"organization_id"`
+	ActorID        *uuid.UUID      `json:"actor_id"`
+	ActorName      string          `json:"actor_name"`
+	Action         AuditAction     `json:"action"`
+	TargetType     string          `json:"target_type"`
+	TargetID       string          `json:"target_id"`
+	Before         json.RawMessage `json:"before,omitempty"`
+	After          json.RawMessage `json:"after,omitempty"`
+	IP             string          `json:"ip"`
+	UserAgent      string          `json:"user_agent"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// AuditFilter narrows the audit log listing. Zero values are ignored.
+type AuditFilter struct {
+	ActorID *uuid.UUID
+	Action  AuditAction
+	Start   *time.Time
+	End     *time.Time
+}
+
+// RequestMetadata describes the HTTP request that triggered a change
+type RequestMetadata struct {
+	IP        string
+	UserAgent string
+}
+
+type requestMetadataKey struct{}
+
+// WithRequestMetadata stores the request metadata in the context
+func WithRequestMetadata(ctx context.Context, meta RequestMetadata) context.Context {
+	return context.WithValue(ctx, requestMetadataKey{}, meta)
+}
+
+// RequestMetadataFrom returns the request metadata stored in the context, if any
+func RequestMetadataFrom(ctx context.Context) RequestMetadata {
+	meta, _ := ctx.Value(requestMetadataKey{}).(RequestMetadata)
+	return meta
+}
+
+// ParseAuditFilter builds a filter from query string values. Dates use the
+// YYYY-MM-DD layout and the end date is inclusive.
+func ParseAuditFilter(actor, action, start, end string) (AuditFilter, error) {
+	var filter AuditFilter
+
+	if actor != "" {
+		actorID, err := uuid.Parse(actor)
+		if err != nil {
+			return filter, fmt.Errorf("ID do usuário inválido")
+		}
+		filter.ActorID = &actorID
+	}
+
+	if action != "" {
+		if !slices.Contains(AuditActions, AuditAction(action)) {
+			return filter, fmt.Errorf("ação inválida: %q", action)
+		}
+		filter.Action = AuditAction(action)
+	}
+
+	if start != "" {
+		startDate, err := time.Parse("2006-01-02", start)
+		if err != nil {
+			return filter, fmt.Errorf("formato de data inicial inválido (use YYYY-MM-DD)")
+		}
+		filter.Start = &startDate
+	}
+
+	if end != "" {
+		endDate, err := time.Parse("2006-01-02", end)
+		if err != nil {
+			return filter, fmt.Errorf("formato de data final inválido (use YYYY-MM-DD)")
+		}
+		endDate = endDate.AddDate(0, 0, 1)
+		filter.End = &endDate
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,234 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RoundingMode is how the billed time of an invoice line is rounded to the
+// client increment
+type RoundingMode string
+
+const (
+	RoundUp      RoundingMode = "up"
+	RoundNearest RoundingMode = "nearest"
+	RoundDown    RoundingMode = "down"
+)
+
+// RoundingIncrements lists the increments a client may be billed in, in
+// minutes. Zero bills the exact minutes.
+var RoundingIncrements = []int{0, 5, 6, 10, 15, 30, 60}
+
+// Round rounds the minutes to a multiple of increment. A zero increment keeps
+// the minutes.
+func (m RoundingMode) Round(minutes int64, increment int) int64 {
+	if increment <= 0 {
+		return minutes
+	}
+
+	step := int64(increment)
+	switch m {
+	case RoundUp:
+		return (minutes + step - 1) / step * step
+	case RoundDown:
+		return minutes / step * step
+	default:
+		return (minutes + step/2) / step * step
+	}
+}
+
+// Label returns the mode name shown to users
+func (m RoundingMode) Label() string {
+	switch m {
+	case RoundUp:
+		return "Para cima"
+	case RoundNearest:
+		return "Mais próximo"
+	case RoundDown:
+		return "Para baixo"
+	default:
+		return string(m)
+	}
+}
+
+// RoundingLabel describes how the time of a client is billed
+func RoundingLabel(increment int, mode RoundingMode) string {
+	if increment == 0 {
+		return "Minutos exatos"
+	}
+	return fmt.Sprintf("%s, a cada %d min", mode.Label(), increment)
+}
+
+// Client is who the organization bills for the project hours
+type Client struct {
+	ID              uuid.UUID    `json:"id"`
+	OrganizationID  uuid.UUID    `json:"organization_id"`
+	Name            string       `json:"name"`
+	Document        string       `json:"document"`
+	Email           string       `json:"email"`
+	RoundingMinutes int          `json:"rounding_minutes"`
+	RoundingMode    RoundingMode `json:"rounding_mode"`
+	CreatedAt       time.Time    `json:"created_at"`
+}
+
+// CreateClient is the client form. Document is the CNPJ or CPF printed on
+// the invoices.
+type CreateClient struct {
+	Name            string `json:"name" form:"name" validate:"required,max=100"`
+	Document        string `json:"document" form:"document" validate:"max=20"`
+	Email           string `json:"email" form:"email" validate:"omitempty,email"`
+	RoundingMinutes int    `json:"rounding_minutes" form:"rounding_minutes" validate:"oneof=0 5 6 10 15 30 60"`
+	RoundingMode    string `json:"rounding_mode" form:"rounding_mode" validate:"omitempty,oneof=up nearest down"`
+}
+
+// UpdateClient changes a client. Nil fields are kept.
+type UpdateClient struct {
+	Name            *string `json:"name" form:"name" validate:"omitempty,min=1,max=100"`
+	Document        *string `json:"document" form:"document" validate:"omitempty,max=20"`
+	Email           *string `json:"email" form:"email" validate:"omitempty,email"`
+	RoundingMinutes *int    `json:"rounding_minutes" form:"rounding_minutes" validate:"omitempty,oneof=0 5 6 10 15 30 60"`
+	RoundingMode    *string `json:"rounding_mode" form:"rounding_mode" validate:"omitempty,oneof=up nearest down"`
+}
+
+// MemberRate is the hourly rate a member is billed at. Members without one
+// are billed at the rate of each project.
+type MemberRate struct {
+	UserID     uuid.UUID  `json:"user_id"`
+	Name       string     `json:"name"`
+	Email      string     `json:"email"`
+	HourlyRate Cents      `json:"hourly_rate_cents"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+// UpdateMemberRate sets the member hourly rate in reais; zero removes it
+type UpdateMemberRate struct {
+	HourlyRate float64 `json:"hourly_rate" form:"hourly_rate" validate:"min=0,max=100000"`
+}
+
+// BillableHours is the billable time a member allocated to a project of the
+// client in the invoice period
+type BillableHours struct {
+	ProjectName string
+	UserName    string
+	Minutes     int64
+	ProjectRate Cents
+	// MemberRate is nil when the member has no configured rate
+	MemberRate *Cents
+}
+
+// InvoiceStatus is the state of an invoice. Drafts may be discarded; issued
+// invoices are numbered and final.
+type InvoiceStatus string
+
+const (
+	InvoiceDraft  InvoiceStatus = "draft"
+	InvoiceIssued InvoiceStatus = "issued"
+)
+
+// Label returns the status name shown to users
+func (s InvoiceStatus) Label() string {
+	switch s {
+	case InvoiceDraft:
+		return "Rascunho"
+	case InvoiceIssued:
+		return "Emitida"
+	default:
+		return string(s)
+	}
+}
+
+// Invoice bills a client for the billable hours of a period. It is a
+// snapshot: names, rates and hours are copied when it is generated.
+type Invoice struct {
+	ID               uuid.UUID     `json:"id"`
+	OrganizationID   uuid.UUID     `json:"organization_id"`
+	ClientID         uuid.UUID     `json:"client_id"`
+	Number           *int          `json:"number,omitempty"`
+	Status           InvoiceStatus `json:"status"`
+	OrganizationName string        `json:"organization_name"`
+	ClientName       string        `json:"client_name"`
+	ClientDocument   string        `json:"client_document"`
+	PeriodStart      time.Time     `json:"period_start"`
+	PeriodEnd        time.Time     `json:"period_end"`
+	RoundingMinutes  int           `json:"rounding_minutes"`
+	RoundingMode     RoundingMode  `json:"rounding_mode"`
+	TotalMinutes     int64         `json:"total_minutes"`
+	Total            Cents         `json:"total_cents"`
+	CreatedBy        uuid.UUID     `json:"created_by"`
+	CreatedAt        time.Time     `json:"created_at"`
+	IssuedAt         *time.Time    `json:"issued_at,omitempty"`
+	Lines            []InvoiceLine `json:"lines"`
+}
+
+// Code returns the invoice number as printed, or "Rascunho" for drafts
+func (i Invoice) Code() string {
+	if i.Number == nil {
+		return InvoiceDraft.Label()
+	}
+	return fmt.Sprintf("%06d", *i.Number)
+}
+
+// InvoiceLine is the time of a member on a project, rounded and priced
+type InvoiceLine struct {
+	Position      int    `json:"position"`
+	UserName      string `json:"user_name"`
+	ProjectName   string `json:"project_name"`
+	Minutes       int64  `json:"minutes"`
+	BilledMinutes int64  `json:"billed_minutes"`
+	HourlyRate    Cents  `json:"hourly_rate_cents"`
+	Amount        Cents  `json:"amount_cents"`
+}
+
+// CreateInvoice is the invoice generation form, dates as YYYY-MM-DD
+type CreateInvoice struct {
+	ClientID string `json:"client_id" form:"client_id" validate:"required,uuid"`
+	Start    string `json:"start" form:"start" validate:"required"`
+	End      string `json:"end" form:"end" validate:"required"`
+}
+
+// NewInvoice prices the billable hours of the client: each member is billed
+// at the member rate, or at the project rate when there is none, and the
+// time of each line is rounded by the client rules
+func NewInvoice(client Client, hours []BillableHours, start, end time.Time) Invoice {
+	invoice := Invoice{
+		OrganizationID:  client.OrganizationID,
+		ClientID:        client.ID,
+		Status:          InvoiceDraft,
+		ClientName:      client.Name,
+		ClientDocument:  client.Document,
+		PeriodStart:     start,
+		PeriodEnd:       end,
+		RoundingMinutes: client.RoundingMinutes,
+		RoundingMode:    client.RoundingMode,
+		Lines:           []InvoiceLine{},
+	}
+
+	for _, h := range hours {
+		rate := h.ProjectRate
+		if h.MemberRate != nil {
+			rate = *h.MemberRate
+		}
+
+		billed := client.RoundingMode.Round(h.Minutes, client.RoundingMinutes)
+		if billed == 0 {
+			continue
+		}
+
+		line := InvoiceLine{
+			Position:      len(invoice.Lines) + 1,
+			UserName:      h.UserName,
+			ProjectName:   h.ProjectName,
+			Minutes:       h.Minutes,
+			BilledMinutes: billed,
+			HourlyRate:    rate,
+			Amount:        BillableAmount(billed, rate),
+		}
+		invoice.Lines = append(invoice.Lines, line)
+		invoice.TotalMinutes += line.BilledMinutes
+		invoice.Total += line.Amount
+	}
+
+	return invoice
+}
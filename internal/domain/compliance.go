@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxDailyMinutes is the longest workday, eight hours plus two of overtime (CLT art. 59)
+	MaxDailyMinutes = 10 * 60
+	// MinInterShiftRest is the rest between two workdays (interjornada, CLT art. 66)
+	MinInterShiftRest = 11 * time.Hour
+	// MaxConsecutiveDays is how many days may be worked before the weekly rest (CLT art. 67)
+	MaxConsecutiveDays = 6
+)
+
+// ComplianceRule is a labor-law rule checked on the timesheets
+type ComplianceRule string
+
+const (
+	ComplianceDailyHours      ComplianceRule = "daily_hours"
+	ComplianceInterShiftRest  ComplianceRule = "inter_shift_rest"
+	ComplianceIntraShiftBreak ComplianceRule = "intra_shift_break"
+	ComplianceConsecutiveDays ComplianceRule = "consecutive_days"
+)
+
+// ComplianceRules lists the rules in the order they are shown
+var ComplianceRules = []ComplianceRule{ComplianceDailyHours, ComplianceInterShiftRest, ComplianceIntraShiftBreak, ComplianceConsecutiveDays}
+
+// Label returns the rule description shown to users
+func (r ComplianceRule) Label() string {
+	switch r {
+	case ComplianceDailyHours:
+		return "Jornada acima de 10h"
+	case ComplianceInterShiftRest:
+		return "Interjornada menor que 11h"
+	case ComplianceIntraShiftBreak:
+		return "Intervalo intrajornada insuficiente"
+	case ComplianceConsecutiveDays:
+		return "Mais de 6 dias seguidos"
+	default:
+		return string(r)
+	}
+}
+
+// ComplianceViolation is a rule broken on a day of a member timesheet
+type ComplianceViolation struct {
+	Date   time.Time      `json:"date"`
+	Rule   ComplianceRule `json:"rule"`
+	Detail string         `json:"detail"`
+}
+
+// MemberCompliance lists the violations of a member, ordered by date
+type MemberCompliance struct {
+	UserID     uuid.UUID             `json:"user_id"`
+	Name       string                `json:"name"`
+	Email      string                `json:"email"`
+	Violations []ComplianceViolation `json:"violations"`
+}
+
+// ComplianceReport lists the members with violations in the month
+type ComplianceReport struct {
+	Month   time.Time          `json:"month"`
+	Members []MemberCompliance `json:"members"`
+}
+
+// Count returns how many violations of the rule the report has
+func (r ComplianceReport) Count(rule ComplianceRule) int {
+	n := 0
+	for _, m := range r.Members {
+		for _, v := range m.Violations {
+			if v.Rule == rule {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// CheckCompliance evaluates each timesheet against the labor-law rules and
+// returns the members with violations on days between start and end
+// (inclusive). Timesheets before start are only used to measure the rest
+// since the previous workday and the run of consecutive days, so callers
+// should include the MaxConsecutiveDays before start.
+func CheckCompliance(timesheets []UserTimesheet, start, end time.Time) []MemberCompliance {
+	members := []MemberCompliance{}
+	byUser := map[uuid.UUID]int{}
+	previous := map[uuid.UUID]UserTimesheet{}
+	streak := map[uuid.UUID]int{}
+
+	for _, ts := range timesheets {
+		if len(ts.Entries) == 0 {
+			continue
+		}
+
+		prev, hasPrev := previous[ts.UserID]
+		if hasPrev && prev.Date.AddDate(0, 0, 1).Equal(ts.Date) {
+			streak[ts.UserID]++
+		} else {
+			streak[ts.UserID] = 1
+		}
+		previous[ts.UserID] = ts
+
+		if ts.Date.Before(start) || ts.Date.After(end) {
+			continue
+		}
+
+		violations := []ComplianceViolation{}
+		add := func(rule ComplianceRule, detail string) {
+			violations = append(violations, ComplianceViolation{Date: ts.Date, Rule: rule, Detail: detail})
+		}
+
+		if worked := ts.WorkedMinutes(); worked > MaxDailyMinutes {
+			add(ComplianceDailyHours, fmt.Sprintf("%s trabalhadas", FormatMinutes(worked)))
+		}
+
+		// Shifts crossing midnight start the day with an exit and are not
+		// a new workday
+		if hasPrev {
+			last, first := prev.Entries[len(prev.Entries)-1], ts.Entries[0]
+			if last.TypeID == EntryTypeOut && first.TypeID == EntryTypeIn {
+				if rest := first.Timestamp.Sub(last.Timestamp); rest < MinInterShiftRest {
+					add(ComplianceInterShiftRest, fmt.Sprintf("%s de descanso desde a saída de %s", FormatMinutes(int64(rest.Minutes())), last.Timestamp.Format("02/01 15:04")))
+				}
+			}
+		}
+
+		if summary := ts.BreakSummary(); summary.Violation {
+			add(ComplianceIntraShiftBreak, fmt.Sprintf("%s de descanso, mínimo de %s", FormatMinutes(summary.RestMinutes), FormatMinutes(summary.RequiredMinutes)))
+		}
+
+		if n := streak[ts.UserID]; n > MaxConsecutiveDays {
+			add(ComplianceConsecutiveDays, fmt.Sprintf("%dº dia seguido de trabalho", n))
+		}
+
+		if len(violations) == 0 {
+			continue
+		}
+
+		i, ok := byUser[ts.UserID]
+		if !ok {
+			i = len(members)
+			byUser[ts.UserID] = i
+			members = append(members, MemberCompliance{UserID: ts.UserID, Name: ts.UserName, Email: ts.UserEmail})
+		}
+		members[i].Violations = append(members[i].Violations, violations...)
+	}
+
+	return members
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// NormalizeCPF strips punctuation from a CPF and validates its check digits.
+// An empty value is accepted and returned as is.
+func NormalizeCPF(cpf string) (string, error) {
+	digits := onlyDigits(cpf)
+	if digits == "" {
+		return "", nil
+	}
+
+	if len(digits) != 11 || allSameDigit(digits) ||
+		checkDigit(digits[:9], 10) != digits[9] || checkDigit(digits[:10], 11) != digits[10] {
+		return "", fmt.Errorf("CPF inválido")
+	}
+
+	return digits, nil
+}
+
+// NormalizeCNPJ strips punctuation from a CNPJ and validates its check digits.
+// An empty value is accepted and returned as is.
+func NormalizeCNPJ(cnpj string) (string, error) {
+	digits := onlyDigits(cnpj)
+	if digits == "" {
+		return "", nil
+	}
+
+	if len(digits) != 14 || allSameDigit(digits) ||
+		cnpjCheckDigit(digits[:12]) != digits[12] || cnpjCheckDigit(digits[:13]) != digits[13] {
+		return "", fmt.Errorf("CNPJ inválido")
+	}
+
+	return digits, nil
+}
+
+// NormalizeDocument strips punctuation from a CPF or a CNPJ, told apart by
+// the number of digits, and validates its check digits. An empty value is
+// accepted and returned as is.
+func NormalizeDocument(document string) (string, error) {
+	if len(onlyDigits(document)) == 11 {
+		return NormalizeCPF(document)
+	}
+
+	digits, err := NormalizeCNPJ(document)
+	if err != nil {
+		return "", fmt.Errorf("CPF ou CNPJ inválido")
+	}
+
+	return digits, nil
+}
+
+// FormatDocument masks a normalized CPF or CNPJ
+func FormatDocument(document string) string {
+	if len(document) == 11 {
+		return FormatCPF(document)
+	}
+	return FormatCNPJ(document)
+}
+
+// FormatCPF masks a normalized CPF as 000.000.000-00
+func FormatCPF(cpf string) string {
+	if len(cpf) != 11 {
+		return cpf
+	}
+	return cpf[:3] + "." + cpf[3:6] + "." + cpf[6:9] + "-" + cpf[9:]
+}
+
+// FormatCNPJ masks a normalized CNPJ as 00.000.000/0000-00
+func FormatCNPJ(cnpj string) string {
+	if len(cnpj) != 14 {
+		return cnpj
+	}
+	return cnpj[:2] + "." + cnpj[2:5] + "." + cnpj[5:8] + "/" + cnpj[8:12] + "-" + cnpj[12:]
+}
+
+func onlyDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+func allSameDigit(s string) bool {
+	return strings.Count(s, s[:1]) == len(s)
+}
+
+// checkDigit computes a CPF check digit with weights starting at weight
+func checkDigit(digits string, weight int) byte {
+	sum := 0
+	for i, d := range digits {
+		sum += int(d-'0') * (weight - i)
+	}
+	rest := sum * 10 % 11
+	if rest == 10 {
+		rest = 0
+	}
+	return byte('0' + rest)
+}
+
+func cnpjCheckDigit(digits string) byte {
+	sum := 0
+	weight := len(digits) - 7
+	for _, d := range digits {
+		sum += int(d-'0') * weight
+		weight--
+		if weight < 2 {
+			weight = 9
+		}
+	}
+	rest := sum % 11
+	if rest < 2 {
+		return '0'
+	}
+	return byte('0' + 11 - rest)
+}
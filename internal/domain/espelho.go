@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Espelho is the monthly espelho de ponto of an employee: every day of the
+// month with its punches, plus the month totals
+type Espelho struct {
+	Organization  Organization
+	EmployeeName  string
+	EmployeeCPF   string
+	Month         time.Time
+	Days          []EspelhoDay
+	WorkedMinutes int64
+	WorkedDays    int
+	Absences      int
+}
+
+// EspelhoDay is a calendar day of the espelho. Timesheet is nil on days
+// without any record.
+type EspelhoDay struct {
+	Date      time.Time
+	Timesheet *DailyTimesheet
+}
+
+// TimesheetSignature records the employee acknowledgment of a monthly espelho
+type TimesheetSignature struct {
+	ID             uuid.UUID `json:"id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	UserID         uuid.UUID `json:"user_id"`
+	Month          time.Time `json:"month"`
+	DocumentHash   string    `json:"document_hash"`
+	IP             string    `json:"ip"`
+	UserAgent      string    `json:"user_agent"`
+	SignedAt       time.Time `json:"signed_at"`
+}
+
+// SignEspelho is the request to sign a monthly espelho. DocumentHash is the
+// SHA-256 of the PDF the employee has seen.
+type SignEspelho struct {
+	DocumentHash string `json:"document_hash" form:"document_hash" validate:"required,len=64,hexadecimal"`
+}
+
+// EspelhoDocument is a rendered espelho PDF and its SHA-256
+type EspelhoDocument struct {
+	Content  []byte
+	Hash     string
+	FileName string
+}
+
+// EspelhoSignatureStatus tells whether a member signed the espelho of a month.
+// Outdated is set when the records changed after the signature.
+type EspelhoSignatureStatus struct {
+	UserID    uuid.UUID           `json:"user_id"`
+	Name      string              `json:"name"`
+	Email     string              `json:"email"`
+	Signature *TimesheetSignature `json:"signature,omitempty"`
+	Outdated  bool                `json:"outdated"`
+}
+
+// ParseMonth parses a YYYY-MM month into its first day
+func ParseMonth(s string) (time.Time, error) {
+	month, err := time.Parse("2006-01", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("formato de mês inválido (use YYYY-MM)")
+	}
+	return month, nil
+}
+
+// MonthLabel returns the month as MM/YYYY
+func MonthLabel(month time.Time) string {
+	return month.Format("01/2006")
+}
+
+// NewEspelho lays the timesheets out over every day of the month
+func NewEspelho(org Organization, employeeName, employeeCPF string, month time.Time, timesheets []UserTimesheet) Espelho {
+	e := Espelho{
+		Organization: org,
+		EmployeeName: employeeName,
+		EmployeeCPF:  employeeCPF,
+		Month:        month,
+	}
+
+	byDay := map[string]*DailyTimesheet{}
+	sort.Slice(timesheets, func(i, j int) bool { return timesheets[i].Date.Before(timesheets[j].Date) })
+	for i := range timesheets {
+		byDay[timesheets[i].Date.Format("2006-01-02")] = &timesheets[i].DailyTimesheet
+	}
+
+	for day := month; day.Month() == month.Month(); day = day.AddDate(0, 0, 1) {
+		ts := byDay[day.Format("2006-01-02")]
+		e.Days = append(e.Days, EspelhoDay{Date: day, Timesheet: ts})
+		if ts == nil {
+			continue
+		}
+
+		if ts.StatusID == StatusAbsent {
+			e.Absences++
+			continue
+		}
+
+		worked := ts.WorkedMinutes()
+		if worked > 0 {
+			e.WorkedDays++
+			e.WorkedMinutes += worked
+		}
+	}
+
+	return e
+}
@@ -0,0 +1,268 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Geofence limits and defaults. Readings less precise than
+// GeofenceMaxAccuracy cannot place a punch inside any fence.
+const (
+	GeofenceMinRadius     = 10.0
+	GeofenceMaxRadius     = 10000.0
+	GeofenceDefaultRadius = 200.0
+	GeofenceMaxVertices   = 100
+	GeofenceMaxAccuracy   = 500.0
+)
+
+// earthRadiusMeters is the mean radius used by the haversine distance
+const earthRadiusMeters = 6371000.0
+
+type GeofenceKind string
+
+const (
+	GeofenceCircle  GeofenceKind = "circle"
+	GeofencePolygon GeofenceKind = "polygon"
+)
+
+func (k GeofenceKind) Label() string {
+	switch k {
+	case GeofenceCircle:
+		return "Círculo"
+	case GeofencePolygon:
+		return "Polígono"
+	default:
+		return string(k)
+	}
+}
+
+// GeofenceMode is what happens to a punch made outside every fence of the
+// organization. Punches are always stored with their location and flag.
+type GeofenceMode string
+
+const (
+	GeofenceRecord  GeofenceMode = "record"
+	GeofenceWarn    GeofenceMode = "warn"
+	GeofenceEnforce GeofenceMode = "enforce"
+)
+
+// GeofenceModes lists every mode, used to build the policy form
+var GeofenceModes = []GeofenceMode{GeofenceRecord, GeofenceWarn, GeofenceEnforce}
+
+func (m GeofenceMode) Label() string {
+	switch m {
+	case GeofenceRecord:
+		return "Apenas registrar"
+	case GeofenceWarn:
+		return "Avisar o membro"
+	case GeofenceEnforce:
+		return "Bloquear o registro"
+	default:
+		return string(m)
+	}
+}
+
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Valid reports whether the point is a latitude and longitude in degrees
+func (p GeoPoint) Valid() bool {
+	return p.Latitude >= -90 && p.Latitude <= 90 && p.Longitude >= -180 && p.Longitude <= 180
+}
+
+// String formats the point as "latitude, longitude" with six decimals (about 10 cm)
+func (p GeoPoint) String() string {
+	return fmt.Sprintf("%.6f, %.6f", p.Latitude, p.Longitude)
+}
+
+// DistanceTo returns the great-circle distance in meters between the points
+func (p GeoPoint) DistanceTo(q GeoPoint) float64 {
+	lat1, lat2 := p.Latitude*math.Pi/180, q.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (q.Longitude - p.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// ParseGeoPoint parses a latitude and longitude typed by hand. Both empty
+// means no point; decimal commas are accepted.
+func ParseGeoPoint(latitude, longitude string) (*GeoPoint, error) {
+	latitude, longitude = strings.TrimSpace(latitude), strings.TrimSpace(longitude)
+	if latitude == "" && longitude == "" {
+		return nil, nil
+	}
+
+	lat, err := strconv.ParseFloat(strings.ReplaceAll(latitude, ",", "."), 64)
+	if err != nil {
+		return nil, fmt.Errorf("latitude inválida")
+	}
+
+	lng, err := strconv.ParseFloat(strings.ReplaceAll(longitude, ",", "."), 64)
+	if err != nil {
+		return nil, fmt.Errorf("longitude inválida")
+	}
+
+	p := GeoPoint{Latitude: lat, Longitude: lng}
+	if !p.Valid() {
+		return nil, fmt.Errorf("coordenadas fora do intervalo válido")
+	}
+
+	return &p, nil
+}
+
+// PunchLocation is the position the member device reported when punching.
+// Accuracy is the radius in meters of the reading, when the device knows it.
+type PunchLocation struct {
+	GeoPoint
+	Accuracy *float64 `json:"accuracy,omitempty"`
+}
+
+// Geofence is an area of the organization where members may punch
+type Geofence struct {
+	ID             uuid.UUID    `json:"id"`
+	OrganizationID uuid.UUID    `json:"organization_id"`
+	Name           string       `json:"name"`
+	Kind           GeofenceKind `json:"kind"`
+	Center         *GeoPoint    `json:"center,omitempty"`
+	RadiusMeters   float64      `json:"radius_meters,omitempty"`
+	Polygon        []GeoPoint   `json:"polygon,omitempty"`
+	CreatedAt      time.Time    `json:"created_at"`
+}
+
+// Contains reports whether the location is inside the fence. Circles give the
+// reading the benefit of its accuracy; polygons require the point inside.
+func (g Geofence) Contains(loc PunchLocation) bool {
+	var accuracy float64
+	if loc.Accuracy != nil {
+		accuracy = *loc.Accuracy
+	}
+	if accuracy > GeofenceMaxAccuracy {
+		return false
+	}
+
+	switch g.Kind {
+	case GeofenceCircle:
+		return g.Center != nil && g.Center.DistanceTo(loc.GeoPoint) <= g.RadiusMeters+accuracy
+	case GeofencePolygon:
+		return polygonContains(g.Polygon, loc.GeoPoint)
+	default:
+		return false
+	}
+}
+
+// polygonContains casts a ray from p and counts the edges it crosses. Fences
+// are small enough for latitude and longitude to be treated as planar.
+func polygonContains(polygon []GeoPoint, p GeoPoint) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a.Latitude > p.Latitude) != (b.Latitude > p.Latitude) &&
+			p.Longitude < (b.Longitude-a.Longitude)*(p.Latitude-a.Latitude)/(b.Latitude-a.Latitude)+a.Longitude {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// LocatePunch returns the first fence containing the location, or nil when
+// the punch is outside every fence or has no location
+func LocatePunch(fences []Geofence, loc *PunchLocation) *Geofence {
+	if loc == nil {
+		return nil
+	}
+
+	for i := range fences {
+		if fences[i].Contains(*loc) {
+			return &fences[i]
+		}
+	}
+	return nil
+}
+
+// CreateGeofence is the geofence form. Circles use the center and radius and
+// polygons the vertices, which the HTML form sends as "latitude, longitude"
+// lines in PolygonText.
+type CreateGeofence struct {
+	Name         string       `json:"name" form:"name" validate:"required,min=2,max=100"`
+	Kind         GeofenceKind `json:"kind" form:"kind" validate:"required,oneof=circle polygon"`
+	Latitude     float64      `json:"latitude" form:"latitude"`
+	Longitude    float64      `json:"longitude" form:"longitude"`
+	RadiusMeters float64      `json:"radius_meters" form:"radius_meters"`
+	Polygon      []GeoPoint   `json:"polygon" form:"-"`
+	PolygonText  string       `json:"-" form:"polygon_text"`
+}
+
+// Geofence validates the form and builds the fence it describes
+func (cg CreateGeofence) Geofence(orgID uuid.UUID) (Geofence, error) {
+	g := Geofence{OrganizationID: orgID, Name: strings.TrimSpace(cg.Name), Kind: cg.Kind}
+
+	switch cg.Kind {
+	case GeofenceCircle:
+		center := GeoPoint{Latitude: cg.Latitude, Longitude: cg.Longitude}
+		if !center.Valid() {
+			return g, fmt.Errorf("coordenadas fora do intervalo válido")
+		}
+		if cg.RadiusMeters < GeofenceMinRadius || cg.RadiusMeters > GeofenceMaxRadius {
+			return g, fmt.Errorf("o raio deve estar entre %.0f e %.0f metros", GeofenceMinRadius, GeofenceMaxRadius)
+		}
+		g.Center = &center
+		g.RadiusMeters = cg.RadiusMeters
+	case GeofencePolygon:
+		polygon := cg.Polygon
+		if len(polygon) == 0 {
+			var err error
+			if polygon, err = parsePolygon(cg.PolygonText); err != nil {
+				return g, err
+			}
+		}
+		if len(polygon) < 3 || len(polygon) > GeofenceMaxVertices {
+			return g, fmt.Errorf("o polígono deve ter entre 3 e %d vértices", GeofenceMaxVertices)
+		}
+		for _, p := range polygon {
+			if !p.Valid() {
+				return g, fmt.Errorf("coordenadas fora do intervalo válido")
+			}
+		}
+		g.Polygon = polygon
+	default:
+		return g, fmt.Errorf("tipo de área inválido: %q", cg.Kind)
+	}
+
+	return g, nil
+}
+
+// parsePolygon reads one "latitude, longitude" vertex per line
+func parsePolygon(text string) ([]GeoPoint, error) {
+	polygon := []GeoPoint{}
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		lat, lng, ok := strings.Cut(line, ",")
+		if !ok {
+			return nil, fmt.Errorf("vértice %d inválido: use \"latitude, longitude\"", i+1)
+		}
+
+		p, err := ParseGeoPoint(lat, lng)
+		if err != nil || p == nil {
+			return nil, fmt.Errorf("vértice %d inválido: use \"latitude, longitude\"", i+1)
+		}
+		polygon = append(polygon, *p)
+	}
+	return polygon, nil
+}
+
+// GeofenceFromAddress is the form that creates a circle around the organization address
+type GeofenceFromAddress struct {
+	RadiusMeters float64 `json:"radius_meters" form:"radius_meters"`
+}
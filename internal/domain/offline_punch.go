@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// OfflinePunchMaxDrift is how far the device clock may be from the server
+	// clock when the queue is sent. Punches keep the device time, so a device
+	// with a wrong clock would record wrong times.
+	OfflinePunchMaxDrift = 5 * time.Minute
+	// OfflinePunchMaxAge is how long a punch may wait in the device queue
+	OfflinePunchMaxAge = 72 * time.Hour
+)
+
+var (
+	// ErrOfflinePunchSynced reports a queued punch recorded by another sync of
+	// the same queue
+	ErrOfflinePunchSynced = errors.New("registro offline já sincronizado")
+	// ErrOfflinePunchSequence refuses a queued punch that, placed at its time,
+	// would leave a later break of the day without an entry before it
+	ErrOfflinePunchSequence = errors.New("este registro deixaria a sequência de entradas e saídas do dia inválida; solicite o ajuste à administração da organização")
+	// ErrOfflinePunchRetypes refuses a queued punch that, placed at its time,
+	// would change the type of punches already recorded and receipted after it
+	ErrOfflinePunchRetypes = errors.New("este registro mudaria o tipo de marcações posteriores já registradas; solicite o ajuste à administração da organização")
+)
+
+// OfflinePunch is a punch the device queued while it had no connection. The
+// client ID is generated by the device, so resending a batch after a failed
+// sync never records the same punch twice.
+type OfflinePunch struct {
+	ClientID   uuid.UUID `json:"client_id" validate:"required"`
+	CapturedAt time.Time `json:"captured_at" validate:"required"`
+	Break      bool      `json:"break"`
+	PunchProof
+}
+
+// SyncOfflinePunches is the device queue sent once the connection is back, at
+// most 50 punches at a time. SentAt is the device clock at sending time, used
+// to measure its drift.
+type SyncOfflinePunches struct {
+	SentAt  time.Time      `json:"sent_at" validate:"required"`
+	Punches []OfflinePunch `json:"punches" validate:"required,min=1,max=50,dive"`
+}
+
+// CheckDrift returns an error when the device clock is too far from now
+func (s SyncOfflinePunches) CheckDrift(now time.Time) error {
+	drift := now.Sub(s.SentAt)
+	if drift < 0 {
+		drift = -drift
+	}
+	if drift > OfflinePunchMaxDrift {
+		return fmt.Errorf("o relógio do aparelho difere do horário do servidor em mais de %d minutos; ajuste a data e a hora do aparelho", int(OfflinePunchMaxDrift.Minutes()))
+	}
+	return nil
+}
+
+// Check returns an error when the punch time does not fit the batch: it must
+// not be after the sending time nor older than OfflinePunchMaxAge
+func (p OfflinePunch) Check(sentAt time.Time) error {
+	if p.CapturedAt.After(sentAt) {
+		return fmt.Errorf("horário do registro posterior ao envio")
+	}
+	if sentAt.Sub(p.CapturedAt) > OfflinePunchMaxAge {
+		return fmt.Errorf("registro feito há mais de %d horas; solicite o ajuste à administração da organização", int(OfflinePunchMaxAge.Hours()))
+	}
+	return nil
+}
+
+// OfflineCapture marks a punch recorded from the device queue, with the
+// device time it was made at
+type OfflineCapture struct {
+	ClientID   uuid.UUID
+	CapturedAt time.Time
+}
+
+// OfflinePunchCheck is what the server already knows about a queued punch
+type OfflinePunchCheck struct {
+	// Synced is true when the punch was recorded by an earlier sync
+	Synced bool
+}
+
+// PlaceOfflinePunch returns the type of a punch made at at, placed among the
+// day entries (ordered by time). Recorded punches are never retyped: their
+// receipts were issued and their types hashed into the chain, so the punch is
+// refused when the types after it, recomputed with NextEntryType as if the
+// punches had been made in time order, would not stay the same.
+func PlaceOfflinePunch(entries []TimesheetEntry, at time.Time, startBreak bool) (EntryType, error) {
+	i := 0
+	for i < len(entries) && !entries[i].Timestamp.After(at) {
+		i++
+	}
+
+	var last *EntryType
+	if i > 0 {
+		last = &entries[i-1].TypeID
+	}
+
+	placed, err := NextEntryType(last, startBreak)
+	if err != nil {
+		return 0, err
+	}
+
+	prev := placed
+	for _, e := range entries[i:] {
+		t, err := NextEntryType(&prev, e.TypeID == EntryTypeBreakStart)
+		if err != nil {
+			return 0, ErrOfflinePunchSequence
+		}
+		if t != e.TypeID {
+			return 0, ErrOfflinePunchRetypes
+		}
+		prev = t
+	}
+
+	return placed, nil
+}
+
+// OfflinePunchStatus is the outcome of a queued punch
+type OfflinePunchStatus string
+
+const (
+	OfflinePunchRecorded  OfflinePunchStatus = "recorded"
+	OfflinePunchDuplicate OfflinePunchStatus = "duplicate"
+	OfflinePunchRejected  OfflinePunchStatus = "rejected"
+)
+
+// OfflinePunchResult tells the device what happened to each queued punch.
+// Recorded and duplicate punches leave the queue; rejected ones are shown to
+// the member with the reason and are not sent again.
+type OfflinePunchResult struct {
+	ClientID uuid.UUID          `json:"client_id"`
+	Status   OfflinePunchStatus `json:"status"`
+	Message  string             `json:"message,omitempty"`
+	Receipt  *PunchReceipt      `json:"receipt,omitempty"`
+}
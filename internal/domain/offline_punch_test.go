@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestPlaceOfflinePunch(t *testing.T) {
+	day := time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+	entries := func(types ...EntryType) []TimesheetEntry {
+		hours := []int{8, 12, 13, 17, 18, 19}
+		out := make([]TimesheetEntry, len(types))
+		for i, typ := range types {
+			out[i] = TimesheetEntry{ID: uuid.New(), TypeID: typ, Timestamp: at(hours[i], 0)}
+		}
+		return out
+	}
+
+	tests := []struct {
+		name       string
+		entries    []TimesheetEntry
+		at         time.Time
+		startBreak bool
+		want       EntryType
+		wantErr    error
+	}{
+		{"dia vazio", nil, at(8, 0), false, EntryTypeIn, nil},
+		{"após o último registro", entries(EntryTypeIn), at(12, 0), false, EntryTypeOut, nil},
+		{"mesmo horário fica depois", entries(EntryTypeIn), at(8, 0), false, EntryTypeOut, nil},
+		{"intervalo após o último registro", entries(EntryTypeIn), at(10, 0), true, EntryTypeBreakStart, nil},
+		{"antes de todos os registros", entries(EntryTypeIn, EntryTypeOut), at(7, 0), false, 0, ErrOfflinePunchRetypes},
+		{"entre registros", entries(EntryTypeIn, EntryTypeOut, EntryTypeIn), at(10, 0), false, 0, ErrOfflinePunchRetypes},
+		{"intervalo entre registros", entries(EntryTypeIn, EntryTypeOut), at(10, 0), true, 0, ErrOfflinePunchRetypes},
+		{"intervalo antes de intervalo", entries(EntryTypeIn, EntryTypeBreakStart, EntryTypeBreakEnd), at(10, 0), true, 0, ErrOfflinePunchRetypes},
+		{"intervalo posterior sem entrada", entries(EntryTypeIn, EntryTypeBreakStart, EntryTypeBreakEnd), at(9, 0), false, 0, ErrOfflinePunchSequence},
+		{"intervalo sem entrada", nil, at(8, 0), true, 0, ErrBreakWithoutEntry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PlaceOfflinePunch(tt.entries, tt.at, tt.startBreak)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("erro = %v, esperado %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("tipo = %s, esperado %s", got.Label(), tt.want.Label())
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ package domain
 import (
 	"fmt"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/google/uuid"
 )
@@ -12,11 +13,26 @@ import (
 type Organization struct {
 	ID        uuid.UUID `json:"id"`
 	Name      string    `json:"name"`
+	CNPJ      string    `json:"cnpj"`
 	CreatedBy uuid.UUID `json:"created_by"`
 	CreatedAt time.Time `json:"created_at"`
+	Timezone  string    `json:"timezone"`
 	Address   *Address  `json:"address,omitempty"`
 }
 
+// DefaultTimezone is the zone of organizations that did not choose one
+const DefaultTimezone = "America/Sao_Paulo"
+
+// Location returns the zone the organization works in, falling back to
+// DefaultTimezone when it is empty or unknown
+func (o Organization) Location() *time.Location {
+	if loc, err := time.LoadLocation(o.Timezone); err == nil && o.Timezone != "" {
+		return loc
+	}
+	loc, _ := time.LoadLocation(DefaultTimezone)
+	return loc
+}
+
 type Address struct {
 	ID             uuid.UUID `json:"id"`
 	OrganizationID uuid.UUID `json:"organization_id"`
@@ -25,6 +41,16 @@ type Address struct {
 	PublicPlace    string    `json:"public_place"`
 	City           string    `json:"city"`
 	State          string    `json:"state"`
+	Latitude       *float64  `json:"latitude,omitempty"`
+	Longitude      *float64  `json:"longitude,omitempty"`
+}
+
+// Coordinates returns the address position, or nil when it was not informed
+func (a Address) Coordinates() *GeoPoint {
+	if a.Latitude == nil || a.Longitude == nil {
+		return nil
+	}
+	return &GeoPoint{Latitude: *a.Latitude, Longitude: *a.Longitude}
 }
 
 type CreateOrganization struct {
@@ -39,16 +65,20 @@ type CreateOrganization struct {
 
 type UpdateOrganization struct {
 	Name        string `json:"name" form:"name" validate:"required,min=3,max=100"`
+	CNPJ        string `json:"cnpj" form:"cnpj"`
 	ZipCode     string `json:"zip_code" form:"zip_code" validate:"required"`
 	Complement  string `json:"complement" form:"complement" validate:"required"`
 	PublicPlace string `json:"public_place" form:"public_place" validate:"required"`
 	City        string `json:"city" form:"city" validate:"required"`
 	State       string `json:"state" form:"state" validate:"required"`
+	// Coordinates are typed by hand and may be left empty; see ParseGeoPoint
+	Latitude  string `json:"latitude" form:"latitude"`
+	Longitude string `json:"longitude" form:"longitude"`
 }
 
 type AddUserToOrganization struct {
 	Email string `json:"email" form:"email" validate:"required,email"`
-	Role  string `json:"role" form:"role" validate:"required,oneof=member admin"`
+	Role  string `json:"role" form:"role" validate:"required,oneof=member manager admin"`
 }
 
 type OrganizationUser struct {
@@ -62,8 +92,9 @@ type OrganizationUser struct {
 type Role string
 
 const (
-	Member Role = "member"
-	Admin  Role = "admin"
+	Member  Role = "member"
+	Admin   Role = "admin"
+	Manager Role = "manager"
 )
 
 func (r Role) String() string {
@@ -77,6 +108,8 @@ func ParseRole(s string) (Role, error) {
 		return r, nil
 	case Admin:
 		return r, nil
+	case Manager:
+		return r, nil
 	default:
 		return "", fmt.Errorf("invalid role: %q", s)
 	}
@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+// Cursor points at the last row of a keyset paginated listing: the value of the
+// sort column and the row ID used to break ties, both formatted as text
+type Cursor struct {
+	Key string `json:"k"`
+	ID  string `json:"id"`
+}
+
+// Encode returns the opaque representation sent to clients as next_cursor
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses a cursor produced by Encode
+func DecodeCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("cursor inválido")
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == "" {
+		return nil, fmt.Errorf("cursor inválido")
+	}
+
+	return &c, nil
+}
+
+// CursorField is the type of a cursor key or ID, matching the cast the
+// listing query applies to it
+type CursorField int
+
+const (
+	CursorText CursorField = iota
+	CursorUUID
+	CursorInt
+	CursorSmallInt
+	CursorDate
+	CursorTimestamp
+)
+
+// valid reports whether s can be cast to the field type
+func (f CursorField) valid(s string) bool {
+	var err error
+	switch f {
+	case CursorText:
+		return utf8.ValidString(s) && !strings.ContainsRune(s, 0)
+	case CursorUUID:
+		_, err = uuid.Parse(s)
+	case CursorInt:
+		_, err = strconv.ParseInt(s, 10, 64)
+	case CursorSmallInt:
+		_, err = strconv.ParseInt(s, 10, 16)
+	case CursorDate:
+		_, err = time.Parse("2006-01-02", s)
+	case CursorTimestamp:
+		_, err = time.Parse(time.RFC3339Nano, s)
+	default:
+		return false
+	}
+	return err == nil
+}
+
+// PageRequest selects a page of a keyset paginated listing. Rows come after
+// Cursor, or from the start when it is nil. A zero Limit returns every row,
+// which is how internal callers load complete listings.
+type PageRequest struct {
+	Limit  int
+	Cursor *Cursor
+}
+
+// ParsePageRequest builds a page request from the limit and cursor query string
+// values. limit defaults to DefaultPageLimit and cannot exceed MaxPageLimit.
+func ParsePageRequest(limit, cursor string) (PageRequest, error) {
+	page := PageRequest{Limit: DefaultPageLimit}
+
+	if limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 || n > MaxPageLimit {
+			return page, fmt.Errorf("limite inválido (use um valor entre 1 e %d)", MaxPageLimit)
+		}
+		page.Limit = n
+	}
+
+	if cursor != "" {
+		c, err := DecodeCursor(cursor)
+		if err != nil {
+			return page, err
+		}
+		page.Cursor = c
+	}
+
+	return page, nil
+}
+
+// Check refuses a cursor whose key or ID does not have the types of the
+// listing it was sent to, so a tampered cursor is reported as invalid input
+// instead of failing the query
+func (p PageRequest) Check(key, id CursorField) error {
+	if p.Cursor == nil {
+		return nil
+	}
+	if !key.valid(p.Cursor.Key) || !id.valid(p.Cursor.ID) {
+		return fmt.Errorf("cursor inválido")
+	}
+	return nil
+}
+
+// FetchLimit returns how many rows to query: one more than Limit, so the extra
+// row tells whether there is a next page. Zero means no limit.
+func (p PageRequest) FetchLimit() int {
+	if p.Limit <= 0 {
+		return 0
+	}
+	return p.Limit + 1
+}
+
+// CursorPage is a page of a keyset paginated listing. NextCursor is empty on
+// the last page.
+type CursorPage[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// NewCursorPage trims the rows fetched with FetchLimit to the page size and
+// points NextCursor at the last returned row when more rows follow
+func NewCursorPage[T any](items []T, page PageRequest, cursor func(T) Cursor) CursorPage[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	if page.Limit <= 0 || len(items) <= page.Limit {
+		return CursorPage[T]{Items: items}
+	}
+
+	items = items[:page.Limit]
+	return CursorPage[T]{Items: items, NextCursor: cursor(items[len(items)-1]).Encode()}
+}
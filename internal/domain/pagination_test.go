@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"encoding/base64"
+	"strconv"
+	"testing"
+)
+
+func TestParsePageRequestLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		limit   string
+		want    int
+		wantErr bool
+	}{
+		{"padrão", "", DefaultPageLimit, false},
+		{"mínimo", "1", 1, false},
+		{"máximo", strconv.Itoa(MaxPageLimit), MaxPageLimit, false},
+		{"zero", "0", 0, true},
+		{"negativo", "-5", 0, true},
+		{"acima do máximo", strconv.Itoa(MaxPageLimit + 1), 0, true},
+		{"não numérico", "dez", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := ParsePageRequest(tt.limit, "")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("limite %q aceito, esperado erro", tt.limit)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if page.Limit != tt.want {
+				t.Errorf("Limit = %d, esperado %d", page.Limit, tt.want)
+			}
+			if page.FetchLimit() != tt.want+1 {
+				t.Errorf("FetchLimit = %d, esperado %d", page.FetchLimit(), tt.want+1)
+			}
+		})
+	}
+}
+
+func TestParsePageRequestCursor(t *testing.T) {
+	valid := Cursor{Key: "2025-11-03", ID: "0b6c4f8e-6a0f-4c3e-9a4b-2f0b1d7e5c11"}
+
+	tests := []struct {
+		name    string
+		cursor  string
+		wantErr bool
+	}{
+		{"sem cursor", "", false},
+		{"emitido por Encode", valid.Encode(), false},
+		{"base64 inválido", "%%%", true},
+		{"json inválido", base64.RawURLEncoding.EncodeToString([]byte(`{"k":`)), true},
+		{"sem ID", base64.RawURLEncoding.EncodeToString([]byte(`{"k":"2025-11-03"}`)), true},
+		{"tipo errado", base64.RawURLEncoding.EncodeToString([]byte(`{"k":1,"id":2}`)), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := ParsePageRequest("", tt.cursor)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("cursor %q aceito, esperado erro", tt.cursor)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.cursor == "" && page.Cursor != nil {
+				t.Errorf("Cursor = %+v, esperado nil", page.Cursor)
+			}
+			if tt.cursor != "" && (page.Cursor == nil || *page.Cursor != valid) {
+				t.Errorf("Cursor = %+v, esperado %+v", page.Cursor, valid)
+			}
+		})
+	}
+}
+
+func TestPageRequestCheck(t *testing.T) {
+	const id = "0b6c4f8e-6a0f-4c3e-9a4b-2f0b1d7e5c11"
+
+	tests := []struct {
+		name    string
+		cursor  *Cursor
+		key     CursorField
+		id      CursorField
+		wantErr bool
+	}{
+		{"sem cursor", nil, CursorDate, CursorUUID, false},
+		{"data", &Cursor{Key: "2025-11-03", ID: id}, CursorDate, CursorUUID, false},
+		{"data inválida", &Cursor{Key: "2025-13-40", ID: id}, CursorDate, CursorUUID, true},
+		{"injeção na data", &Cursor{Key: "2025-11-03'; --", ID: id}, CursorDate, CursorUUID, true},
+		{"uuid inválido", &Cursor{Key: "2025-11-03", ID: "abc"}, CursorDate, CursorUUID, true},
+		{"timestamp", &Cursor{Key: "2025-11-03T08:00:00.123456-03:00", ID: id}, CursorTimestamp, CursorUUID, false},
+		{"data no lugar de timestamp", &Cursor{Key: "2025-11-03", ID: id}, CursorTimestamp, CursorUUID, true},
+		{"inteiro", &Cursor{Key: "42", ID: "7"}, CursorTimestamp, CursorInt, true},
+		{"id inteiro", &Cursor{Key: "2025-11-03T08:00:00Z", ID: "7"}, CursorTimestamp, CursorInt, false},
+		{"id inteiro inválido", &Cursor{Key: "2025-11-03T08:00:00Z", ID: id}, CursorTimestamp, CursorInt, true},
+		{"chave inteira", &Cursor{Key: "120", ID: id}, CursorInt, CursorUUID, false},
+		{"chave inteira inválida", &Cursor{Key: "1e3", ID: id}, CursorInt, CursorUUID, true},
+		{"status", &Cursor{Key: "4", ID: id}, CursorSmallInt, CursorUUID, false},
+		{"status fora de smallint", &Cursor{Key: "70000", ID: id}, CursorSmallInt, CursorUUID, true},
+		{"texto", &Cursor{Key: "Ana Souza", ID: id}, CursorText, CursorUUID, false},
+		{"texto vazio", &Cursor{Key: "", ID: id}, CursorText, CursorUUID, false},
+		{"texto com NUL", &Cursor{Key: "Ana\x00", ID: id}, CursorText, CursorUUID, true},
+		{"texto com UTF-8 inválido", &Cursor{Key: "\xff", ID: id}, CursorText, CursorUUID, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := PageRequest{Limit: DefaultPageLimit, Cursor: tt.cursor}.Check(tt.key, tt.id)
+			if tt.wantErr && err == nil {
+				t.Errorf("cursor %+v aceito, esperado erro", tt.cursor)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("cursor %+v recusado: %v", tt.cursor, err)
+			}
+		})
+	}
+}
+
+func TestTimesheetQueryCursorKey(t *testing.T) {
+	tests := map[string]CursorField{
+		"date":   CursorDate,
+		"name":   CursorText,
+		"status": CursorSmallInt,
+		"":       CursorDate,
+	}
+
+	for sort, want := range tests {
+		if got := (TimesheetQuery{Sort: sort}).CursorKey(); got != want {
+			t.Errorf("CursorKey(%q) = %d, esperado %d", sort, got, want)
+		}
+	}
+}
+
+func TestNewCursorPage(t *testing.T) {
+	cursor := func(n int) Cursor { return Cursor{Key: strconv.Itoa(n), ID: strconv.Itoa(n)} }
+	rows := func(n int) []int {
+		items := make([]int, n)
+		for i := range items {
+			items[i] = i + 1
+		}
+		return items
+	}
+
+	tests := []struct {
+		name     string
+		items    []int
+		limit    int
+		wantLen  int
+		wantNext *Cursor
+	}{
+		{"vazio", nil, 3, 0, nil},
+		{"última página parcial", rows(2), 3, 2, nil},
+		{"página exata", rows(3), 3, 3, nil},
+		{"limite mais um", rows(4), 3, 3, &Cursor{Key: "3", ID: "3"}},
+		{"sem limite", rows(10), 0, 10, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := NewCursorPage(tt.items, PageRequest{Limit: tt.limit}, cursor)
+
+			if page.Items == nil {
+				t.Fatal("Items nil, esperado slice vazio")
+			}
+			if len(page.Items) != tt.wantLen {
+				t.Errorf("%d itens, esperado %d", len(page.Items), tt.wantLen)
+			}
+
+			if tt.wantNext == nil {
+				if page.NextCursor != "" {
+					t.Errorf("NextCursor = %q, esperado vazio", page.NextCursor)
+				}
+				return
+			}
+
+			next, err := DecodeCursor(page.NextCursor)
+			if err != nil {
+				t.Fatalf("NextCursor %q não decodifica: %v", page.NextCursor, err)
+			}
+			if *next != *tt.wantNext {
+				t.Errorf("NextCursor = %+v, esperado %+v", *next, *tt.wantNext)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrPayPeriodClosed refuses changes to the timesheets of a closed pay period
+var ErrPayPeriodClosed = errors.New("o período de folha deste dia está fechado para alterações")
+
+// PayPeriod is a month of the organization payroll. Closing it keeps a copy
+// of each member totals and locks the timesheets of its days until an admin
+// reopens it.
+type PayPeriod struct {
+	ID             uuid.UUID       `json:"id"`
+	OrganizationID uuid.UUID       `json:"organization_id"`
+	Month          time.Time       `json:"month"`
+	Closed         bool            `json:"closed"`
+	ClosedBy       *uuid.UUID      `json:"closed_by,omitempty"`
+	ClosedByName   string          `json:"closed_by_name"`
+	ClosedAt       time.Time       `json:"closed_at"`
+	ReopenedBy     *uuid.UUID      `json:"reopened_by,omitempty"`
+	ReopenedByName string          `json:"reopened_by_name,omitempty"`
+	ReopenedAt     *time.Time      `json:"reopened_at,omitempty"`
+	ReopenReason   string          `json:"reopen_reason"`
+	Totals         []PayrollTotals `json:"totals,omitempty"`
+}
+
+// End returns the last day of the period
+func (p PayPeriod) End() time.Time {
+	return p.Month.AddDate(0, 1, -1)
+}
+
+// ReopenPayPeriod is the reason an admin gives to change a closed period
+type ReopenPayPeriod struct {
+	Reason string `json:"reason" form:"reason" validate:"required,min=10,max=500"`
+}
@@ -0,0 +1,210 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultDailyMinutes is the workday used for members without a contracted schedule
+const DefaultDailyMinutes = 8 * 60
+
+// PayrollEventKind is a computed total exported to the payroll system
+type PayrollEventKind string
+
+const (
+	PayrollRegular  PayrollEventKind = "regular"
+	PayrollOvertime PayrollEventKind = "overtime"
+	PayrollNight    PayrollEventKind = "night"
+	PayrollAbsence  PayrollEventKind = "absence"
+)
+
+// PayrollEventKinds lists the totals in the order they are exported
+var PayrollEventKinds = []PayrollEventKind{PayrollRegular, PayrollOvertime, PayrollNight, PayrollAbsence}
+
+// Label returns the event description shown to users
+func (k PayrollEventKind) Label() string {
+	switch k {
+	case PayrollRegular:
+		return "Horas normais"
+	case PayrollOvertime:
+		return "Horas extras"
+	case PayrollNight:
+		return "Horas noturnas"
+	case PayrollAbsence:
+		return "Faltas"
+	default:
+		return string(k)
+	}
+}
+
+// PayrollCSVColumns lists the columns the CSV payroll layout can hold
+var PayrollCSVColumns = []string{"company", "cpf", "name", "event", "description", "quantity", "hours", "start", "end"}
+
+// PayrollSettings maps the computed totals to the payroll event codes of an
+// organization and chooses the file layout its payroll system imports
+type PayrollSettings struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	Format         string    `json:"format"`
+	CompanyCode    string    `json:"company_code"`
+	RegularCode    string    `json:"regular_code"`
+	OvertimeCode   string    `json:"overtime_code"`
+	NightCode      string    `json:"night_code"`
+	AbsenceCode    string    `json:"absence_code"`
+	CSVColumns     []string  `json:"csv_columns"`
+	CSVDelimiter   string    `json:"csv_delimiter"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// DefaultPayrollSettings returns the settings of an organization that never configured the payroll
+func DefaultPayrollSettings(orgID uuid.UUID) PayrollSettings {
+	return PayrollSettings{
+		OrganizationID: orgID,
+		Format:         "csv",
+		CSVColumns:     []string{"cpf", "name", "event", "quantity"},
+		CSVDelimiter:   ";",
+	}
+}
+
+// EventCode returns the payroll code configured for a total. Totals without a
+// code are not exported.
+func (s PayrollSettings) EventCode(kind PayrollEventKind) string {
+	switch kind {
+	case PayrollRegular:
+		return s.RegularCode
+	case PayrollOvertime:
+		return s.OvertimeCode
+	case PayrollNight:
+		return s.NightCode
+	case PayrollAbsence:
+		return s.AbsenceCode
+	default:
+		return ""
+	}
+}
+
+// UpdatePayrollSettings is the payroll configuration form. CSVColumns is a
+// comma separated list of PayrollCSVColumns.
+type UpdatePayrollSettings struct {
+	Format       string `json:"format" form:"format" validate:"required"`
+	CompanyCode  string `json:"company_code" form:"company_code" validate:"max=10"`
+	RegularCode  string `json:"regular_code" form:"regular_code" validate:"max=5"`
+	OvertimeCode string `json:"overtime_code" form:"overtime_code" validate:"max=5"`
+	NightCode    string `json:"night_code" form:"night_code" validate:"max=5"`
+	AbsenceCode  string `json:"absence_code" form:"absence_code" validate:"max=5"`
+	CSVColumns   string `json:"csv_columns" form:"csv_columns"`
+	CSVDelimiter string `json:"csv_delimiter" form:"csv_delimiter" validate:"omitempty,oneof=; , |"`
+}
+
+// Columns parses the CSV column list, rejecting unknown columns
+func (u UpdatePayrollSettings) Columns() ([]string, error) {
+	columns := []string{}
+	for _, c := range strings.Split(u.CSVColumns, ",") {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		if !slices.Contains(PayrollCSVColumns, c) {
+			return nil, fmt.Errorf("coluna inválida: %q", c)
+		}
+		columns = append(columns, c)
+	}
+
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("informe ao menos uma coluna")
+	}
+
+	return columns, nil
+}
+
+// PayrollTotals accumulates the payroll totals of a member in a period
+type PayrollTotals struct {
+	UserID          uuid.UUID `json:"user_id"`
+	Name            string    `json:"name"`
+	CPF             string    `json:"cpf"`
+	RegularMinutes  int64     `json:"regular_minutes"`
+	OvertimeMinutes int64     `json:"overtime_minutes"`
+	NightMinutes    int64     `json:"night_minutes"`
+	AbsenceDays     int64     `json:"absence_days"`
+}
+
+// Add counts a day of the member. Minutes worked beyond dailyMinutes are
+// overtime; night minutes are counted in the organization zone loc.
+func (t *PayrollTotals) Add(ts DailyTimesheet, dailyMinutes int64, loc *time.Location) {
+	if ts.StatusID == StatusAbsent {
+		t.AbsenceDays++
+		return
+	}
+
+	worked := ts.WorkedMinutes()
+	t.RegularMinutes += min(worked, dailyMinutes)
+	t.OvertimeMinutes += max(worked-dailyMinutes, 0)
+	t.NightMinutes += ts.NightMinutes(loc)
+}
+
+// Quantity returns the total of a kind: minutes for hours, days for absences
+func (t PayrollTotals) Quantity(kind PayrollEventKind) int64 {
+	switch kind {
+	case PayrollRegular:
+		return t.RegularMinutes
+	case PayrollOvertime:
+		return t.OvertimeMinutes
+	case PayrollNight:
+		return t.NightMinutes
+	case PayrollAbsence:
+		return t.AbsenceDays
+	default:
+		return 0
+	}
+}
+
+// PayrollEvent is a line of the payroll import file
+type PayrollEvent struct {
+	CompanyCode string
+	CPF         string
+	Name        string
+	Code        string
+	Kind        PayrollEventKind
+	// Quantity is in minutes, or in days for absences
+	Quantity int64
+	Start    time.Time
+	End      time.Time
+}
+
+// Hundredths returns the quantity in hundredths of an hour, or of a day for
+// absences, which is how payroll systems usually import amounts
+func (e PayrollEvent) Hundredths() int64 {
+	if e.Kind == PayrollAbsence {
+		return e.Quantity * 100
+	}
+	return (e.Quantity*100 + 30) / 60
+}
+
+// PayrollEvents maps the totals of each member to the configured event codes,
+// skipping empty totals and totals without a code
+func PayrollEvents(settings PayrollSettings, totals []PayrollTotals, start, end time.Time) []PayrollEvent {
+	events := []PayrollEvent{}
+	for _, t := range totals {
+		for _, kind := range PayrollEventKinds {
+			code := settings.EventCode(kind)
+			quantity := t.Quantity(kind)
+			if code == "" || quantity == 0 {
+				continue
+			}
+			events = append(events, PayrollEvent{
+				CompanyCode: settings.CompanyCode,
+				CPF:         t.CPF,
+				Name:        t.Name,
+				Code:        code,
+				Kind:        kind,
+				Quantity:    quantity,
+				Start:       start,
+				End:         end,
+			})
+		}
+	}
+	return events
+}
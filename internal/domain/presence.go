@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PunchEvent is published when a member clocks in or out
+type PunchEvent struct {
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	EntryID        uuid.UUID  `json:"entry_id"`
+	TypeID         EntryType  `json:"type_id"`
+	Timestamp      time.Time  `json:"timestamp"`
+	KioskDeviceID  *uuid.UUID `json:"kiosk_device_id,omitempty"`
+	// OfflineCaptured is true when the punch was made without connection and
+	// synced later, so Timestamp may be well before the event
+	OfflineCaptured bool `json:"offline_captured,omitempty"`
+}
+
+// MemberPresence is the current status of an organization member. Working is
+// true when the last punch of today's timesheet is an entry or the return from
+// a break, and OnBreak when it is the exit for a break.
+type MemberPresence struct {
+	UserID    uuid.UUID  `json:"user_id"`
+	Name      string     `json:"name"`
+	Email     string     `json:"email"`
+	Working   bool       `json:"working"`
+	OnBreak   bool       `json:"on_break"`
+	LastType  *EntryType `json:"last_type,omitempty"`
+	LastPunch *time.Time `json:"last_punch,omitempty"`
+}
+
+// SinceLastPunch describes how long ago the last punch happened, e.g. "há 2h 05min"
+func (p MemberPresence) SinceLastPunch(now time.Time) string {
+	if p.LastPunch == nil {
+		return "sem registros"
+	}
+
+	elapsed := now.Sub(*p.LastPunch)
+	if elapsed < time.Minute {
+		return "agora"
+	}
+
+	minutes := int64(elapsed.Minutes())
+	if minutes < 60 {
+		return fmt.Sprintf("há %dmin", minutes)
+	}
+	if minutes < 24*60 {
+		return fmt.Sprintf("há %dh %02dmin", minutes/60, minutes%60)
+	}
+	if minutes < 2*24*60 {
+		return "há 1 dia"
+	}
+	return fmt.Sprintf("há %d dias", minutes/(24*60))
+}
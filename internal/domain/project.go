@@ -0,0 +1,249 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cents is an amount of money in centavos, kept whole so totals never round
+type Cents int64
+
+// CentsFromReais converts an amount typed in reais, e.g. 150.5
+func CentsFromReais(reais float64) Cents {
+	return Cents(math.Round(reais * 100))
+}
+
+// String formats the amount as R$ 1.234,56
+func (c Cents) String() string {
+	sign := ""
+	if c < 0 {
+		sign = "-"
+		c = -c
+	}
+
+	whole := fmt.Sprint(int64(c) / 100)
+	groups := []string{}
+	for len(whole) > 3 {
+		groups = append([]string{whole[len(whole)-3:]}, groups...)
+		whole = whole[:len(whole)-3]
+	}
+	groups = append([]string{whole}, groups...)
+
+	return fmt.Sprintf("%sR$ %s,%02d", sign, strings.Join(groups, "."), int64(c)%100)
+}
+
+// Project is client work the members allocate their worked hours to. Billable
+// projects charge the hourly rate for the billable allocations. Client is the
+// name of the client, when there is one.
+type Project struct {
+	ID             uuid.UUID     `json:"id"`
+	OrganizationID uuid.UUID     `json:"organization_id"`
+	Name           string        `json:"name"`
+	ClientID       *uuid.UUID    `json:"client_id,omitempty"`
+	Client         string        `json:"client"`
+	Billable       bool          `json:"billable"`
+	HourlyRate     Cents         `json:"hourly_rate_cents"`
+	Archived       bool          `json:"archived"`
+	CreatedAt      time.Time     `json:"created_at"`
+	Tasks          []ProjectTask `json:"tasks"`
+}
+
+// Task returns the project task with the ID, or nil when there is none
+func (p Project) Task(id uuid.UUID) *ProjectTask {
+	for i := range p.Tasks {
+		if p.Tasks[i].ID == id {
+			return &p.Tasks[i]
+		}
+	}
+	return nil
+}
+
+// ProjectTask is an optional breakdown of a project
+type ProjectTask struct {
+	ID        uuid.UUID `json:"id"`
+	ProjectID uuid.UUID `json:"project_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateProject is the project form. The hourly rate is typed in reais.
+type CreateProject struct {
+	Name       string  `json:"name" form:"name" validate:"required,max=100"`
+	ClientID   string  `json:"client_id" form:"client_id" validate:"omitempty,uuid"`
+	Billable   bool    `json:"billable" form:"billable"`
+	HourlyRate float64 `json:"hourly_rate" form:"hourly_rate" validate:"min=0,max=100000"`
+}
+
+// UpdateProject changes a project. Nil fields are kept; an empty ClientID
+// unlinks the client.
+type UpdateProject struct {
+	Name       *string  `json:"name" form:"name" validate:"omitempty,min=1,max=100"`
+	ClientID   *string  `json:"client_id" form:"client_id"`
+	Billable   *bool    `json:"billable" form:"billable"`
+	HourlyRate *float64 `json:"hourly_rate" form:"hourly_rate" validate:"omitempty,min=0,max=100000"`
+	Archived   *bool    `json:"archived" form:"archived"`
+}
+
+type CreateProjectTask struct {
+	Name string `json:"name" form:"name" validate:"required,max=100"`
+}
+
+// TimeAllocation is part of a member workday spent on a project. Allocations
+// tagging a worked interval keep its start and end; manual ones only the
+// minutes.
+type TimeAllocation struct {
+	ID             uuid.UUID  `json:"id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	TimesheetID    uuid.UUID  `json:"timesheet_id"`
+	Date           time.Time  `json:"date"`
+	ProjectID      uuid.UUID  `json:"project_id"`
+	ProjectName    string     `json:"project_name"`
+	TaskID         *uuid.UUID `json:"task_id,omitempty"`
+	TaskName       string     `json:"task_name,omitempty"`
+	StartsAt       *time.Time `json:"starts_at,omitempty"`
+	EndsAt         *time.Time `json:"ends_at,omitempty"`
+	Minutes        int        `json:"minutes"`
+	Billable       bool       `json:"billable"`
+	Note           string     `json:"note"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// CreateTimeAllocation is the allocation form. Either the worked interval
+// (start and end, HH:MM) or the minutes must be given. Billable defaults to
+// the project setting.
+type CreateTimeAllocation struct {
+	Date      string `json:"date" form:"date" validate:"required"`
+	ProjectID string `json:"project_id" form:"project_id" validate:"required,uuid"`
+	TaskID    string `json:"task_id" form:"task_id" validate:"omitempty,uuid"`
+	Start     string `json:"start" form:"start"`
+	End       string `json:"end" form:"end"`
+	Minutes   int    `json:"minutes" form:"minutes" validate:"omitempty,min=1,max=1440"`
+	Billable  *bool  `json:"billable" form:"billable"`
+	Note      string `json:"note" form:"note" validate:"max=200"`
+}
+
+// Allocation parses the form into an allocation of the user, without the
+// timesheet and project data
+func (ca CreateTimeAllocation) Allocation(orgID, userID uuid.UUID) (TimeAllocation, error) {
+	date, err := time.ParseInLocation("2006-01-02", ca.Date, time.Local)
+	if err != nil {
+		return TimeAllocation{}, fmt.Errorf("formato de data inválido (use YYYY-MM-DD)")
+	}
+
+	a := TimeAllocation{
+		OrganizationID: orgID,
+		UserID:         userID,
+		Date:           date,
+		ProjectID:      uuid.MustParse(ca.ProjectID),
+		Note:           strings.TrimSpace(ca.Note),
+	}
+	if ca.TaskID != "" {
+		taskID := uuid.MustParse(ca.TaskID)
+		a.TaskID = &taskID
+	}
+
+	switch {
+	case ca.Start != "" || ca.End != "":
+		start, err := time.ParseInLocation("2006-01-02 15:04", ca.Date+" "+ca.Start, time.Local)
+		if err != nil {
+			return TimeAllocation{}, fmt.Errorf("horário inicial inválido (use HH:MM)")
+		}
+		end, err := time.ParseInLocation("2006-01-02 15:04", ca.Date+" "+ca.End, time.Local)
+		if err != nil {
+			return TimeAllocation{}, fmt.Errorf("horário final inválido (use HH:MM)")
+		}
+		if !end.After(start) {
+			return TimeAllocation{}, fmt.Errorf("o horário final deve ser posterior ao inicial")
+		}
+		a.StartsAt, a.EndsAt = &start, &end
+		a.Minutes = int(end.Sub(start).Minutes())
+	case ca.Minutes > 0:
+		a.Minutes = ca.Minutes
+	default:
+		return TimeAllocation{}, fmt.Errorf("informe o intervalo trabalhado ou os minutos dedicados ao projeto")
+	}
+
+	return a, nil
+}
+
+// CheckAllocation returns an error when the allocation does not fit the
+// workday: the day must have worked time, the allocations together may not
+// exceed it, and a tagged interval must be worked time not tagged before
+func (t DailyTimesheet) CheckAllocation(a TimeAllocation, existing []TimeAllocation) error {
+	worked := t.WorkedMinutes()
+	if worked == 0 {
+		return fmt.Errorf("não há horas trabalhadas registradas neste dia")
+	}
+
+	allocated := int64(a.Minutes)
+	for _, e := range existing {
+		allocated += int64(e.Minutes)
+	}
+	if allocated > worked {
+		return fmt.Errorf("as horas apontadas excedem as horas trabalhadas no dia (%s)", FormatMinutes(worked))
+	}
+
+	if a.StartsAt == nil {
+		return nil
+	}
+
+	covered := false
+	for _, span := range t.workSpans() {
+		if !a.StartsAt.Before(span.Start) && !a.EndsAt.After(span.End) {
+			covered = true
+			break
+		}
+	}
+	if !covered {
+		return fmt.Errorf("o intervalo deve estar dentro de um período trabalhado")
+	}
+
+	for _, e := range existing {
+		if e.StartsAt != nil && a.StartsAt.Before(*e.EndsAt) && e.StartsAt.Before(*a.EndsAt) {
+			return fmt.Errorf("o intervalo já foi apontado para %s", e.ProjectName)
+		}
+	}
+
+	return nil
+}
+
+// ProjectHours is the time a member allocated to a project in a period
+type ProjectHours struct {
+	ProjectID       uuid.UUID `json:"project_id"`
+	ProjectName     string    `json:"project_name"`
+	Client          string    `json:"client"`
+	UserID          uuid.UUID `json:"user_id"`
+	UserName        string    `json:"user_name"`
+	Minutes         int64     `json:"minutes"`
+	BillableMinutes int64     `json:"billable_minutes"`
+	HourlyRate      Cents     `json:"hourly_rate_cents"`
+	Amount          Cents     `json:"amount_cents"`
+}
+
+// BillableAmount charges the hourly rate for the billable minutes
+func BillableAmount(minutes int64, hourlyRate Cents) Cents {
+	return Cents(math.Round(float64(minutes) * float64(hourlyRate) / 60))
+}
+
+// ProjectReport is the allocated time of a period by project and member
+type ProjectReport struct {
+	Start time.Time      `json:"start"`
+	End   time.Time      `json:"end"`
+	Rows  []ProjectHours `json:"rows"`
+}
+
+// Totals sums the rows of the report
+func (r ProjectReport) Totals() ProjectHours {
+	var total ProjectHours
+	for _, row := range r.Rows {
+		total.Minutes += row.Minutes
+		total.BillableMinutes += row.BillableMinutes
+		total.Amount += row.Amount
+	}
+	return total
+}
@@ -0,0 +1,221 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GenesisHash is the previous hash of the first punch of every organization chain
+var GenesisHash = strings.Repeat("0", 64)
+
+// PunchRecord is a timesheet entry as it takes part in the organization hash chain
+type PunchRecord struct {
+	Sequence       int64     `json:"sequence"`
+	EntryID        uuid.UUID `json:"entry_id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	UserID         uuid.UUID `json:"user_id"`
+	TimesheetID    uuid.UUID `json:"timesheet_id"`
+	TypeID         EntryType `json:"type_id"`
+	Timestamp      time.Time `json:"timestamp"`
+	PrevHash       string    `json:"prev_hash"`
+	Hash           string    `json:"hash"`
+}
+
+// ComputeHash returns the hex SHA-256 of the record content chained to PrevHash.
+// The timestamp is hashed in UTC with microsecond precision, as stored by PostgreSQL.
+func (r PunchRecord) ComputeHash() string {
+	canonical := fmt.Sprintf("%d|%s|%s|%s|%s|%d|%s|%s",
+		r.Sequence,
+		r.EntryID,
+		r.OrganizationID,
+		r.UserID,
+		r.TimesheetID,
+		r.TypeID,
+		r.Timestamp.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+		r.PrevHash,
+	)
+	sum := sha256.Sum256([]byte(canonical))
+	return hex.EncodeToString(sum[:])
+}
+
+// ChainHead is the last sequence and hash recorded for an organization chain,
+// updated with every punch
+type ChainHead struct {
+	Sequence int64  `json:"sequence"`
+	Hash     string `json:"hash"`
+}
+
+// PunchChain is an organization chain as stored: its records ordered by
+// sequence and the head they must end at
+type PunchChain struct {
+	Records []PunchRecord
+	Head    ChainHead
+}
+
+// ChainBreak describes a record that does not fit in the chain
+type ChainBreak struct {
+	Sequence int64     `json:"sequence"`
+	EntryID  uuid.UUID `json:"entry_id"`
+	Reason   string    `json:"reason"`
+}
+
+// ChainReport is the result of walking an organization chain
+type ChainReport struct {
+	OrganizationID uuid.UUID    `json:"organization_id"`
+	Records        int          `json:"records"`
+	Unchained      int          `json:"unchained"`
+	HeadHash       string       `json:"head_hash"`
+	StoredHead     ChainHead    `json:"stored_head"`
+	Valid          bool         `json:"valid"`
+	Breaks         []ChainBreak `json:"breaks"`
+	VerifiedAt     time.Time    `json:"verified_at"`
+}
+
+// VerifyChain walks records ordered by sequence, recomputing every hash and
+// checking each record points to the previous one. It reports every break found.
+func VerifyChain(records []PunchRecord) ([]ChainBreak, string) {
+	breaks := []ChainBreak{}
+	prevHash := GenesisHash
+	expectedSeq := int64(1)
+
+	for _, r := range records {
+		if r.Sequence != expectedSeq {
+			breaks = append(breaks, ChainBreak{Sequence: r.Sequence, EntryID: r.EntryID, Reason: fmt.Sprintf("sequência esperada %d", expectedSeq)})
+		}
+		if r.PrevHash != prevHash {
+			breaks = append(breaks, ChainBreak{Sequence: r.Sequence, EntryID: r.EntryID, Reason: "hash anterior não confere"})
+		}
+		if r.ComputeHash() != r.Hash {
+			breaks = append(breaks, ChainBreak{Sequence: r.Sequence, EntryID: r.EntryID, Reason: "conteúdo alterado"})
+		}
+		prevHash = r.Hash
+		expectedSeq = r.Sequence + 1
+	}
+
+	return breaks, prevHash
+}
+
+// VerifyChainHead compares the end of the walked chain with the stored head.
+// Removing the newest punches leaves a chain that still links, so only the
+// head reveals it.
+func VerifyChainHead(records []PunchRecord, head ChainHead) []ChainBreak {
+	breaks := []ChainBreak{}
+
+	last := ChainHead{Hash: GenesisHash}
+	if len(records) > 0 {
+		r := records[len(records)-1]
+		last = ChainHead{Sequence: r.Sequence, Hash: r.Hash}
+	}
+
+	if last.Sequence != head.Sequence {
+		breaks = append(breaks, ChainBreak{Sequence: last.Sequence, Reason: fmt.Sprintf("cadeia termina antes do último registro gravado (sequência %d)", head.Sequence)})
+	} else if last.Hash != head.Hash {
+		breaks = append(breaks, ChainBreak{Sequence: last.Sequence, Reason: "hash final não confere com o último registro gravado"})
+	}
+
+	return breaks
+}
+
+// PunchChainExport is the signed payload handed to third parties
+type PunchChainExport struct {
+	OrganizationID   uuid.UUID     `json:"organization_id"`
+	OrganizationName string        `json:"organization_name"`
+	Algorithm        string        `json:"algorithm"`
+	PublicKey        string        `json:"public_key"`
+	GeneratedAt      time.Time     `json:"generated_at"`
+	HeadHash         string        `json:"head_hash"`
+	Records          []PunchRecord `json:"records"`
+}
+
+// SignedPunchChainExport carries the export payload and its Ed25519 signature.
+// The signature covers the compact JSON encoding of Payload.
+type SignedPunchChainExport struct {
+	Payload   json.RawMessage `json:"payload"`
+	Signature string          `json:"signature"`
+}
+
+// SignPunchChainExport encodes and signs an export with the organization private key
+func SignPunchChainExport(export PunchChainExport, privateKey ed25519.PrivateKey) (SignedPunchChainExport, error) {
+	payload, err := json.Marshal(export)
+	if err != nil {
+		return SignedPunchChainExport{}, err
+	}
+
+	signature := ed25519.Sign(privateKey, payload)
+	return SignedPunchChainExport{
+		Payload:   payload,
+		Signature: base64.StdEncoding.EncodeToString(signature),
+	}, nil
+}
+
+// VerifyPunchChainExport checks the export signature against publicKey and walks
+// its chain. When publicKey is nil the key embedded in the payload is used, which
+// only proves integrity; pass a key obtained out of band to prove authenticity.
+func VerifyPunchChainExport(signed SignedPunchChainExport, publicKey ed25519.PublicKey) (PunchChainExport, []ChainBreak, error) {
+	var export PunchChainExport
+
+	var payload bytes.Buffer
+	if err := json.Compact(&payload, signed.Payload); err != nil {
+		return export, nil, fmt.Errorf("payload inválido: %w", err)
+	}
+
+	if err := json.Unmarshal(payload.Bytes(), &export); err != nil {
+		return export, nil, fmt.Errorf("payload inválido: %w", err)
+	}
+
+	if publicKey == nil {
+		embedded, err := base64.StdEncoding.DecodeString(export.PublicKey)
+		if err != nil || len(embedded) != ed25519.PublicKeySize {
+			return export, nil, fmt.Errorf("chave pública inválida")
+		}
+		publicKey = embedded
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(signed.Signature)
+	if err != nil {
+		return export, nil, fmt.Errorf("assinatura inválida")
+	}
+
+	if !ed25519.Verify(publicKey, payload.Bytes(), signature) {
+		return export, nil, fmt.Errorf("assinatura não confere")
+	}
+
+	breaks, head := VerifyChain(export.Records)
+	if head != export.HeadHash && len(export.Records) > 0 {
+		breaks = append(breaks, ChainBreak{Reason: "hash final não confere"})
+	}
+
+	return export, breaks, nil
+}
+
+// SigningKey is the Ed25519 key pair an organization signs its exports with
+type SigningKey struct {
+	OrganizationID uuid.UUID          `json:"organization_id"`
+	Algorithm      string             `json:"algorithm"`
+	PublicKey      ed25519.PublicKey  `json:"public_key"`
+	PrivateKey     ed25519.PrivateKey `json:"-"`
+	CreatedAt      time.Time          `json:"created_at"`
+}
+
+// EmployeePunch is a chained punch joined with the employee data the fiscal
+// files (AFD, AEJ) require
+type EmployeePunch struct {
+	NSR         int64     `json:"nsr"`
+	EntryID     uuid.UUID `json:"entry_id"`
+	TimesheetID uuid.UUID `json:"timesheet_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	UserName    string    `json:"user_name"`
+	CPF         string    `json:"cpf"`
+	TypeID      EntryType `json:"type_id"`
+	Timestamp   time.Time `json:"timestamp"`
+	Hash        string    `json:"hash"`
+}
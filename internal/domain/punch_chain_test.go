@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// buildChain returns n records linked from GenesisHash and the head stored
+// after the last of them
+func buildChain(n int) ([]PunchRecord, ChainHead) {
+	orgID := uuid.New()
+	records := make([]PunchRecord, n)
+	head := ChainHead{Hash: GenesisHash}
+
+	for i := range records {
+		r := PunchRecord{
+			Sequence:       int64(i + 1),
+			EntryID:        uuid.New(),
+			OrganizationID: orgID,
+			UserID:         uuid.New(),
+			TimesheetID:    uuid.New(),
+			TypeID:         EntryTypeIn,
+			Timestamp:      time.Date(2025, 11, 3, 8, i, 0, 0, time.UTC),
+			PrevHash:       head.Hash,
+		}
+		r.Hash = r.ComputeHash()
+		records[i] = r
+		head = ChainHead{Sequence: r.Sequence, Hash: r.Hash}
+	}
+
+	return records, head
+}
+
+func TestVerifyChainHead(t *testing.T) {
+	records, head := buildChain(5)
+
+	tests := []struct {
+		name    string
+		records []PunchRecord
+		head    ChainHead
+		breaks  int
+	}{
+		{"cadeia completa", records, head, 0},
+		{"cadeia vazia sem topo", nil, ChainHead{Hash: GenesisHash}, 0},
+		{"últimos registros removidos", records[:3], head, 1},
+		{"todos os registros removidos", nil, head, 1},
+		{"hash do topo diferente", records, ChainHead{Sequence: head.Sequence, Hash: GenesisHash}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			walked, _ := VerifyChain(tt.records)
+			if len(walked) != 0 {
+				t.Fatalf("cadeia percorrida com quebras: %+v", walked)
+			}
+
+			if got := VerifyChainHead(tt.records, tt.head); len(got) != tt.breaks {
+				t.Errorf("%d quebras, esperado %d: %+v", len(got), tt.breaks, got)
+			}
+		})
+	}
+}
+
+func TestVerifyChain(t *testing.T) {
+	records, head := buildChain(3)
+
+	breaks, last := VerifyChain(records)
+	if len(breaks) != 0 || last != head.Hash {
+		t.Fatalf("cadeia íntegra reportou %+v, hash final %s", breaks, last)
+	}
+
+	tampered := append([]PunchRecord(nil), records...)
+	tampered[1].TypeID = EntryTypeOut
+	if breaks, _ := VerifyChain(tampered); len(breaks) != 1 || breaks[0].Sequence != 2 {
+		t.Errorf("alteração do registro 2 reportou %+v", breaks)
+	}
+
+	removed := []PunchRecord{records[0], records[2]}
+	if breaks, _ := VerifyChain(removed); len(breaks) != 2 {
+		t.Errorf("remoção do registro 2 reportou %+v", breaks)
+	}
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NetworkMode is what happens to a punch made from outside every network of
+// the organization. Punches always store the client IP and flag.
+type NetworkMode string
+
+const (
+	NetworkFlag  NetworkMode = "flag"
+	NetworkBlock NetworkMode = "block"
+)
+
+// NetworkModes lists every mode, used to build the policy form
+var NetworkModes = []NetworkMode{NetworkFlag, NetworkBlock}
+
+func (m NetworkMode) Label() string {
+	switch m {
+	case NetworkFlag:
+		return "Marcar para revisão"
+	case NetworkBlock:
+		return "Bloquear o registro"
+	default:
+		return string(m)
+	}
+}
+
+// PunchNetwork is an IP range, usually the office network, members may punch from
+type PunchNetwork struct {
+	ID             uuid.UUID `json:"id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	CIDR           string    `json:"cidr"`
+	Description    string    `json:"description"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Contains reports whether the IP is inside the range
+func (n PunchNetwork) Contains(ip netip.Addr) bool {
+	prefix, err := netip.ParsePrefix(n.CIDR)
+	return err == nil && prefix.Contains(ip)
+}
+
+type CreatePunchNetwork struct {
+	CIDR        string `json:"cidr" form:"cidr" validate:"required,max=64"`
+	Description string `json:"description" form:"description" validate:"max=100"`
+}
+
+// Prefix parses the range in CIDR notation, or a single address, and returns
+// it masked so equal ranges are always stored the same way
+func (cn CreatePunchNetwork) Prefix() (netip.Prefix, error) {
+	text := strings.TrimSpace(cn.CIDR)
+	if !strings.Contains(text, "/") {
+		addr, err := netip.ParseAddr(text)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("faixa de IP inválida: use a notação CIDR, ex.: 203.0.113.0/24")
+		}
+		addr = addr.Unmap()
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+
+	prefix, err := netip.ParsePrefix(text)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("faixa de IP inválida: use a notação CIDR, ex.: 203.0.113.0/24")
+	}
+
+	return prefix.Masked(), nil
+}
+
+// AllowedNetwork returns the first network containing the client IP, or nil
+// when the IP is outside every network or cannot be parsed
+func AllowedNetwork(networks []PunchNetwork, clientIP string) *PunchNetwork {
+	ip, err := netip.ParseAddr(clientIP)
+	if err != nil {
+		return nil
+	}
+	ip = ip.Unmap()
+
+	for i := range networks {
+		if networks[i].Contains(ip) {
+			return &networks[i]
+		}
+	}
+	return nil
+}
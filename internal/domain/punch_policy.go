@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PunchQRStep is how long each on-site QR code is shown before it rotates
+const PunchQRStep = 30 * time.Second
+
+// PunchPolicy restricts where members of an organization may punch from.
+// Punches made at kiosk devices are always on-site and skip the policy.
+// Geofences and networks are managed on their own and loaded along with the
+// policy.
+type PunchPolicy struct {
+	OrganizationID uuid.UUID      `json:"organization_id"`
+	RequireQR      bool           `json:"require_qr"`
+	QRSecret       string         `json:"-"`
+	GeofenceMode   GeofenceMode   `json:"geofence_mode"`
+	Geofences      []Geofence     `json:"geofences"`
+	NetworkMode    NetworkMode    `json:"network_mode"`
+	Networks       []PunchNetwork `json:"networks"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// CollectsLocation reports whether punches must send the device location
+func (p PunchPolicy) CollectsLocation() bool {
+	return len(p.Geofences) > 0
+}
+
+// RestrictsNetwork reports whether punches are checked against the allowed networks
+func (p PunchPolicy) RestrictsNetwork() bool {
+	return len(p.Networks) > 0
+}
+
+type UpdatePunchPolicy struct {
+	RequireQR    *bool         `json:"require_qr" form:"require_qr"`
+	GeofenceMode *GeofenceMode `json:"geofence_mode" form:"geofence_mode" validate:"omitempty,oneof=record warn enforce"`
+	NetworkMode  *NetworkMode  `json:"network_mode" form:"network_mode" validate:"omitempty,oneof=flag block"`
+}
+
+// PunchProof is what a member presents with a punch to satisfy the
+// organization punch policy
+type PunchProof struct {
+	QRCode    string   `json:"qr_code" form:"qr_code"`
+	Latitude  *float64 `json:"latitude" form:"latitude"`
+	Longitude *float64 `json:"longitude" form:"longitude"`
+	Accuracy  *float64 `json:"accuracy" form:"accuracy"`
+}
+
+// Location returns the reported device location, or nil when the member sent none
+func (p PunchProof) Location() (*PunchLocation, error) {
+	if p.Latitude == nil && p.Longitude == nil {
+		return nil, nil
+	}
+
+	if p.Latitude == nil || p.Longitude == nil {
+		return nil, fmt.Errorf("informe a latitude e a longitude")
+	}
+
+	loc := PunchLocation{GeoPoint: GeoPoint{Latitude: *p.Latitude, Longitude: *p.Longitude}, Accuracy: p.Accuracy}
+	if !loc.Valid() {
+		return nil, fmt.Errorf("coordenadas fora do intervalo válido")
+	}
+
+	if loc.Accuracy != nil && *loc.Accuracy < 0 {
+		return nil, fmt.Errorf("precisão da localização inválida")
+	}
+
+	return &loc, nil
+}
+
+// PunchOrigin records where a punch was made: the client IP and kiosk device,
+// or the location the member reported and the fence it matched. Offline is set
+// for punches recorded from the device queue. Break is set when the member
+// asked to leave for a break instead of clocking out.
+type PunchOrigin struct {
+	Break           bool
+	ClientIP        string
+	KioskDeviceID   *uuid.UUID
+	Offline         *OfflineCapture
+	Location        *PunchLocation
+	GeofenceID      *uuid.UUID
+	OutsideGeofence bool
+	OutsideNetwork  bool
+}
+
+// PunchFlag is why a punch was flagged for review by the punch policy
+type PunchFlag string
+
+const (
+	PunchFlagGeofence PunchFlag = "geofence"
+	PunchFlagNetwork  PunchFlag = "network"
+)
+
+func (f PunchFlag) Label() string {
+	switch f {
+	case PunchFlagGeofence:
+		return "Fora das áreas"
+	case PunchFlagNetwork:
+		return "Fora da rede"
+	default:
+		return string(f)
+	}
+}
+
+// FlaggedPunch is a punch made outside every fence of the organization (or
+// without a location while it had fences), or from outside its networks
+type FlaggedPunch struct {
+	EntryID         uuid.UUID      `json:"entry_id"`
+	UserID          uuid.UUID      `json:"user_id"`
+	UserName        string         `json:"user_name"`
+	UserEmail       string         `json:"user_email"`
+	TypeID          EntryType      `json:"type_id"`
+	Timestamp       time.Time      `json:"timestamp"`
+	Location        *PunchLocation `json:"location,omitempty"`
+	ClientIP        string         `json:"client_ip"`
+	OutsideGeofence bool           `json:"outside_geofence"`
+	OutsideNetwork  bool           `json:"outside_network"`
+}
+
+// Flags lists why the punch was flagged
+func (p FlaggedPunch) Flags() []PunchFlag {
+	var flags []PunchFlag
+	if p.OutsideGeofence {
+		flags = append(flags, PunchFlagGeofence)
+	}
+	if p.OutsideNetwork {
+		flags = append(flags, PunchFlagNetwork)
+	}
+	return flags
+}
+
+// ClockInResult is the receipt of a punch made from the member session, with
+// the warning of a punch the policy accepted outside the allowed area
+type ClockInResult struct {
+	*PunchReceipt
+	Warning string `json:"warning,omitempty"`
+}
+
+// PunchQR is the code currently shown by the office display
+type PunchQR struct {
+	Code      string    `json:"code"`
+	URL       string    `json:"url,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Display returns the code in groups of four characters, for typing it by hand
+func (q PunchQR) Display() string {
+	var groups []string
+	for code := q.Code; code != ""; {
+		n := min(4, len(code))
+		groups = append(groups, code[:n])
+		code = code[n:]
+	}
+	return strings.Join(groups, " ")
+}
+
+var punchQREncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// punchQRCode returns the organization code of a step. Codes are the
+// HMAC-SHA256 of the organization and the step number truncated to 80 bits,
+// so they cannot be derived without the secret.
+func punchQRCode(secret string, orgID uuid.UUID, step int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(orgID.String()))
+	mac.Write([]byte(":"))
+	mac.Write([]byte(strconv.FormatInt(step, 10)))
+	return punchQREncoding.EncodeToString(mac.Sum(nil)[:10])
+}
+
+func punchQRStep(t time.Time) int64 {
+	return t.Unix() / int64(PunchQRStep/time.Second)
+}
+
+// NewPunchQR returns the code shown at now and when it stops being shown
+func (p PunchPolicy) NewPunchQR(now time.Time) PunchQR {
+	step := punchQRStep(now)
+	return PunchQR{
+		Code:      punchQRCode(p.QRSecret, p.OrganizationID, step),
+		ExpiresAt: time.Unix((step+1)*int64(PunchQRStep/time.Second), 0),
+	}
+}
+
+// VerifyQR reports whether code is the one shown at now or in the previous
+// step, which leaves the member at least one full step to submit the punch.
+// Codes typed by hand may have spaces and lower case letters.
+func (p PunchPolicy) VerifyQR(code string, now time.Time) bool {
+	code = strings.ToUpper(strings.Join(strings.Fields(code), ""))
+	if code == "" || p.QRSecret == "" {
+		return false
+	}
+
+	step := punchQRStep(now)
+	for _, s := range []int64{step, step - 1} {
+		if hmac.Equal([]byte(code), []byte(punchQRCode(p.QRSecret, p.OrganizationID, s))) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PunchReceipt is the comprovante de registro de ponto handed to the employee
+// for every punch (Portaria 671). Names and address are a snapshot taken at punch time.
+type PunchReceipt struct {
+	ID                  uuid.UUID `json:"id"`
+	EntryID             uuid.UUID `json:"entry_id"`
+	OrganizationID      uuid.UUID `json:"organization_id"`
+	UserID              uuid.UUID `json:"user_id"`
+	NSR                 int64     `json:"nsr"`
+	EmployeeName        string    `json:"employee_name"`
+	OrganizationName    string    `json:"organization_name"`
+	OrganizationAddress string    `json:"organization_address"`
+	TypeID              EntryType `json:"type_id"`
+	Timestamp           time.Time `json:"timestamp"`
+	VerificationCode    string    `json:"verification_code"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+// FormatNSR returns the NSR zero padded to nine digits, as printed on receipts
+func FormatNSR(nsr int64) string {
+	return fmt.Sprintf("%09d", nsr)
+}
+
+// ReceiptVerificationCode derives the receipt code from the punch chain hash,
+// so a receipt can be matched against a signed chain export
+func ReceiptVerificationCode(hash string) string {
+	code := strings.ToUpper(hash)
+	if len(code) > 16 {
+		code = code[:16]
+	}
+
+	groups := []string{}
+	for i := 0; i < len(code); i += 4 {
+		groups = append(groups, code[i:min(i+4, len(code))])
+	}
+	return strings.Join(groups, "-")
+}
@@ -11,5 +11,9 @@ type (
 		Status  int    `json:"status"`
 		Message string `json:"message"`
 		Data    any    `json:"data,omitempty"`
+		// Limit and NextCursor are set by paginated listings; pass NextCursor
+		// back as the cursor query parameter to fetch the next page
+		Limit      int    `json:"limit,omitempty"`
+		NextCursor string `json:"next_cursor,omitempty"`
 	}
 )
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkSchedule is a contracted daily schedule (horário contratual). Times use
+// the HHMM layout; the second shift is optional.
+type WorkSchedule struct {
+	ID             uuid.UUID `json:"id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	Code           string    `json:"code"`
+	DailyMinutes   int       `json:"daily_minutes"`
+	Entry1         string    `json:"entry1"`
+	Exit1          string    `json:"exit1"`
+	Entry2         string    `json:"entry2"`
+	Exit2          string    `json:"exit2"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type CreateWorkSchedule struct {
+	Code         string `json:"code" form:"code" validate:"required,max=30"`
+	DailyMinutes int    `json:"daily_minutes" form:"daily_minutes" validate:"required,min=1,max=1440"`
+	Entry1       string `json:"entry1" form:"entry1" validate:"required,len=4,numeric"`
+	Exit1        string `json:"exit1" form:"exit1" validate:"required,len=4,numeric"`
+	Entry2       string `json:"entry2" form:"entry2" validate:"omitempty,len=4,numeric"`
+	Exit2        string `json:"exit2" form:"exit2" validate:"omitempty,len=4,numeric"`
+}
+
+// AssignWorkSchedule sets the member contracted schedule; a nil ScheduleID clears it
+type AssignWorkSchedule struct {
+	ScheduleID *uuid.UUID `json:"schedule_id" form:"schedule_id"`
+}
+
+// MemberSchedule is an organization member with the data the fiscal files need
+type MemberSchedule struct {
+	UserID     uuid.UUID  `json:"user_id"`
+	Name       string     `json:"name"`
+	CPF        string     `json:"cpf"`
+	ScheduleID *uuid.UUID `json:"schedule_id"`
+}
+
+// Validate checks the schedule times are valid clock times and the second
+// shift is either complete or absent
+func (s CreateWorkSchedule) Validate() error {
+	for _, t := range []string{s.Entry1, s.Exit1, s.Entry2, s.Exit2} {
+		if t != "" && !ValidHHMM(t) {
+			return fmt.Errorf("horário inválido: %s (use HHMM)", t)
+		}
+	}
+
+	if (s.Entry2 == "") != (s.Exit2 == "") {
+		return fmt.Errorf("informe entrada e saída do segundo turno")
+	}
+
+	return nil
+}
+
+// ValidHHMM reports whether s is a clock time in the HHMM layout
+func ValidHHMM(s string) bool {
+	if len(s) != 4 {
+		return false
+	}
+	_, err := time.Parse("1504", s)
+	return err == nil
+}
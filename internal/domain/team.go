@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Team groups organization members under a department or squad
+type Team struct {
+	ID             uuid.UUID    `json:"id"`
+	OrganizationID uuid.UUID    `json:"organization_id"`
+	Name           string       `json:"name"`
+	CreatedAt      time.Time    `json:"created_at"`
+	Members        []TeamMember `json:"members"`
+}
+
+// TeamMember represents an organization member assigned to a team
+type TeamMember struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email"`
+	Role     Role      `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type CreateTeam struct {
+	Name string `json:"name" form:"name" validate:"required,min=2,max=100"`
+}
+
+type AddTeamMember struct {
+	UserID string `json:"user_id" form:"user_id" validate:"required,uuid"`
+}
+
+// TeamTotal summarizes the worked time of a team over the filtered timesheets
+type TeamTotal struct {
+	TeamID       uuid.UUID `json:"team_id"`
+	TeamName     string    `json:"team_name"`
+	Members      int       `json:"members"`
+	Timesheets   int       `json:"timesheets"`
+	TotalMinutes int64     `json:"total_minutes"`
+}
+
+// HasMember reports whether the given user is assigned to the team
+func (t Team) HasMember(userID uuid.UUID) bool {
+	for _, m := range t.Members {
+		if m.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,16 +16,105 @@ const (
 	StatusClosed
 	StatusAbsent
 	StatusApproved
+	StatusReproved
 )
 
-// EntryType represents the type of timesheet entry (clock in/out)
+// Label returns the status name shown to users
+func (s TimesheetStatus) Label() string {
+	switch s {
+	case StatusOpen:
+		return "Aberto"
+	case StatusClosed:
+		return "Fechado"
+	case StatusAbsent:
+		return "Ausente"
+	case StatusApproved:
+		return "Aprovado"
+	case StatusReproved:
+		return "Reprovado"
+	default:
+		return "Desconhecido"
+	}
+}
+
+// EntryType represents the type of timesheet entry (clock in/out and the
+// start and end of a break)
 type EntryType int
 
 const (
 	EntryTypeIn EntryType = iota + 1
 	EntryTypeOut
+	EntryTypeBreakStart
+	EntryTypeBreakEnd
 )
 
+// Label returns the entry type description shown to users
+func (t EntryType) Label() string {
+	switch t {
+	case EntryTypeIn:
+		return "Entrada"
+	case EntryTypeOut:
+		return "Saída"
+	case EntryTypeBreakStart:
+		return "Saída para intervalo"
+	case EntryTypeBreakEnd:
+		return "Volta do intervalo"
+	default:
+		return fmt.Sprintf("Tipo %d", int(t))
+	}
+}
+
+// Short returns the abbreviation used in dense listings such as the espelho
+func (t EntryType) Short() string {
+	switch t {
+	case EntryTypeIn:
+		return "E"
+	case EntryTypeOut:
+		return "S"
+	case EntryTypeBreakStart:
+		return "SI"
+	case EntryTypeBreakEnd:
+		return "VI"
+	default:
+		return "?"
+	}
+}
+
+// StartsWork reports whether the member is working after the entry
+func (t EntryType) StartsWork() bool {
+	return t == EntryTypeIn || t == EntryTypeBreakEnd
+}
+
+// ErrBreakWithoutEntry refuses a break requested before the day's entry
+var ErrBreakWithoutEntry = errors.New("registre a entrada antes de sair para o intervalo")
+
+// NextEntryType returns the type of the next punch after last, the latest
+// entry of the day (nil when there is none). A member on a break always comes
+// back from it; a working member leaves for a break when startBreak is set and
+// clocks out otherwise.
+func NextEntryType(last *EntryType, startBreak bool) (EntryType, error) {
+	switch {
+	case last == nil || *last == EntryTypeOut:
+		if startBreak {
+			return 0, ErrBreakWithoutEntry
+		}
+		return EntryTypeIn, nil
+	case *last == EntryTypeBreakStart:
+		return EntryTypeBreakEnd, nil
+	case startBreak:
+		return EntryTypeBreakStart, nil
+	default:
+		return EntryTypeOut, nil
+	}
+}
+
+// ClockInRequest is the body of a punch from the member session: the proof
+// required by the punch policy and whether the member is leaving for a break
+type ClockInRequest struct {
+	PunchProof
+	Break bool `json:"break" form:"break"`
+}
+
 // DailyTimesheet represents a user's timesheet for a specific day
 type DailyTimesheet struct {
 	ID             uuid.UUID       `json:"id"`
@@ -38,11 +129,14 @@ type DailyTimesheet struct {
 
 // TimesheetEntry represents a single clock in/out entry
 type TimesheetEntry struct {
-	ID             uuid.UUID `json:"id"`
-	TimesheetID    uuid.UUID `json:"timesheet_id"`
-	OrganizationID uuid.UUID `json:"organization_id"`
-	TypeID         EntryType `json:"type_id"`
-	Timestamp      time.Time `json:"timestamp"`
+	ID             uuid.UUID  `json:"id"`
+	TimesheetID    uuid.UUID  `json:"timesheet_id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	TypeID         EntryType  `json:"type_id"`
+	Timestamp      time.Time  `json:"timestamp"`
+	KioskDeviceID  *uuid.UUID `json:"kiosk_device_id,omitempty"`
+	// OfflineCaptured is true for punches made without connection and synced later
+	OfflineCaptured bool `json:"offline_captured"`
 }
 
 // UserTimesheet combines user information with their timesheet
@@ -51,3 +145,96 @@ type UserTimesheet struct {
 	UserName  string `json:"user_name"`
 	UserEmail string `json:"user_email"`
 }
+
+// WorkedMinutes sums the closed work periods of the timesheet entries plus
+// the paid breaks. A period without a matching end is not counted until the
+// user clocks out or leaves for a break.
+func (t DailyTimesheet) WorkedMinutes() int64 {
+	var total time.Duration
+	for _, span := range t.workSpans() {
+		total += span.End.Sub(span.Start)
+	}
+	return int64(total.Minutes())
+}
+
+// timeSpan is a closed period of time
+type timeSpan struct {
+	Start time.Time
+	End   time.Time
+}
+
+// workSpans returns the periods counted as worked: from an entry or the end
+// of a break to the next exit or break, and the paid breaks
+func (t DailyTimesheet) workSpans() []timeSpan {
+	spans := []timeSpan{}
+	var start *time.Time
+	for i := range t.Entries {
+		entry := t.Entries[i]
+		switch {
+		case entry.TypeID.StartsWork():
+			start = &entry.Timestamp
+		case start != nil:
+			spans = append(spans, timeSpan{Start: *start, End: entry.Timestamp})
+			start = nil
+		}
+	}
+
+	for _, b := range t.Breaks() {
+		if b.Paid {
+			spans = append(spans, timeSpan{Start: b.Start, End: b.End})
+		}
+	}
+	return spans
+}
+
+// FormatMinutes renders a minute count as HH:MM
+func FormatMinutes(minutes int64) string {
+	sign := ""
+	if minutes < 0 {
+		sign = "-"
+		minutes = -minutes
+	}
+	return fmt.Sprintf("%s%02d:%02d", sign, minutes/60, minutes%60)
+}
+
+// NightMinutes sums the worked minutes between 22:00 and 05:00 (CLT art. 73)
+// of the organization zone loc. They are clock minutes: the reduced night hour
+// of 52m30s (art. 73 §1) and the night premium are applied by the payroll
+// system to the night event.
+func (t DailyTimesheet) NightMinutes(loc *time.Location) int64 {
+	var total time.Duration
+	for _, span := range t.workSpans() {
+		total += nightOverlap(span.Start.In(loc), span.End.In(loc))
+	}
+	return int64(total.Minutes())
+}
+
+// nightOverlap returns how much of [from, to) falls in night periods of the
+// zone of from
+func nightOverlap(from, to time.Time) time.Duration {
+	var total time.Duration
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location()).AddDate(0, 0, -1)
+	for !day.After(to) {
+		nightStart := day.Add(22 * time.Hour)
+		nightEnd := day.AddDate(0, 0, 1).Add(5 * time.Hour)
+		if s, e := later(from, nightStart), earlier(to, nightEnd); e.After(s) {
+			total += e.Sub(s)
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return total
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlier(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
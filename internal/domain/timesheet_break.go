@@ -0,0 +1,97 @@
+package domain
+
+import "time"
+
+// PaidBreakLimit is the longest break still counted as worked time. Short
+// pauses are paid (Súmula 118 do TST); longer breaks are the unpaid rest of
+// CLT art. 71.
+const PaidBreakLimit = 15 * time.Minute
+
+// Break is a closed break of the day, from the exit to the return punch
+type Break struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Paid  bool      `json:"paid"`
+}
+
+// Minutes returns the duration of the break
+func (b Break) Minutes() int64 {
+	return int64(b.End.Sub(b.Start).Minutes())
+}
+
+// Breaks returns the closed breaks of the timesheet. A break without a return
+// punch is not listed until the member comes back.
+func (t DailyTimesheet) Breaks() []Break {
+	breaks := []Break{}
+	var start *time.Time
+	for i := range t.Entries {
+		entry := t.Entries[i]
+		switch entry.TypeID {
+		case EntryTypeBreakStart:
+			start = &entry.Timestamp
+		case EntryTypeBreakEnd:
+			if start != nil {
+				length := entry.Timestamp.Sub(*start)
+				breaks = append(breaks, Break{Start: *start, End: entry.Timestamp, Paid: length <= PaidBreakLimit})
+				start = nil
+			}
+		}
+	}
+	return breaks
+}
+
+// BreakSummary is the break time of a day against the minimum rest the law
+// requires for the worked time. Rest is the unpaid break time plus the gaps
+// between an exit and the next entry.
+type BreakSummary struct {
+	PaidMinutes     int64 `json:"paid_minutes"`
+	UnpaidMinutes   int64 `json:"unpaid_minutes"`
+	RestMinutes     int64 `json:"rest_minutes"`
+	RequiredMinutes int64 `json:"required_minutes"`
+	Violation       bool  `json:"violation"`
+}
+
+// requiredRest returns the minimum rest for the worked minutes (CLT art. 71):
+// one hour above six hours of work and fifteen minutes above four hours
+func requiredRest(worked int64) int64 {
+	switch {
+	case worked > 6*60:
+		return 60
+	case worked > 4*60:
+		return 15
+	default:
+		return 0
+	}
+}
+
+// BreakSummary sums the breaks of the timesheet. A member may also clock out
+// for lunch, so the gaps between punches count as rest; paid breaks do not.
+func (t DailyTimesheet) BreakSummary() BreakSummary {
+	var summary BreakSummary
+	for _, b := range t.Breaks() {
+		if b.Paid {
+			summary.PaidMinutes += b.Minutes()
+		} else {
+			summary.UnpaidMinutes += b.Minutes()
+		}
+	}
+
+	summary.RestMinutes = summary.UnpaidMinutes
+	var out *time.Time
+	for i := range t.Entries {
+		entry := t.Entries[i]
+		switch entry.TypeID {
+		case EntryTypeOut:
+			out = &entry.Timestamp
+		case EntryTypeIn:
+			if out != nil {
+				summary.RestMinutes += int64(entry.Timestamp.Sub(*out).Minutes())
+				out = nil
+			}
+		}
+	}
+
+	summary.RequiredMinutes = requiredRest(t.WorkedMinutes())
+	summary.Violation = summary.RestMinutes < summary.RequiredMinutes
+	return summary
+}
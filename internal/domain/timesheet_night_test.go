@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNightMinutes(t *testing.T) {
+	saoPaulo := Organization{Timezone: "America/Sao_Paulo"}.Location()
+	manaus := Organization{Timezone: "America/Manaus"}.Location()
+
+	// 00:30 to 02:00 UTC: 21:30 to 23:00 in São Paulo, 20:30 to 22:00 in Manaus
+	shift := DailyTimesheet{Entries: []TimesheetEntry{
+		{TypeID: EntryTypeIn, Timestamp: time.Date(2025, 11, 4, 0, 30, 0, 0, time.UTC)},
+		{TypeID: EntryTypeOut, Timestamp: time.Date(2025, 11, 4, 2, 0, 0, 0, time.UTC)},
+	}}
+
+	tests := []struct {
+		name string
+		loc  *time.Location
+		want int64
+	}{
+		{"UTC", time.UTC, 90},
+		{"São Paulo", saoPaulo, 60},
+		{"Manaus", manaus, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shift.NightMinutes(tt.loc); got != tt.want {
+				t.Errorf("NightMinutes = %d, esperado %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrganizationLocation(t *testing.T) {
+	for _, tz := range []string{"", "Lua/Base"} {
+		if got := (Organization{Timezone: tz}).Location().String(); got != DefaultTimezone {
+			t.Errorf("Location(%q) = %s, esperado %s", tz, got, DefaultTimezone)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultTimesheetPageSize = 20
+	MaxTimesheetPageSize     = 100
+	// MaxTimesheetRangeDays bounds custom ranges so a query cannot scan years of records
+	MaxTimesheetRangeDays = 366
+)
+
+// TimesheetSorts lists the columns the organization timesheets can be sorted by
+var TimesheetSorts = []string{"date", "name", "status"}
+
+// TimesheetQuery filters, sorts and paginates the organization timesheets.
+// Start and End are inclusive dates.
+type TimesheetQuery struct {
+	Period   string
+	Start    time.Time
+	End      time.Time
+	MemberID *uuid.UUID
+	TeamID   *uuid.UUID
+	Status   TimesheetStatus
+	Sort     string
+	Desc     bool
+	Page     int
+	PageSize int
+}
+
+// TimesheetPage is a page of organization timesheets
+type TimesheetPage struct {
+	Timesheets []UserTimesheet `json:"timesheets"`
+	Total      int             `json:"total"`
+	Page       int             `json:"page"`
+	PageSize   int             `json:"page_size"`
+}
+
+// TotalPages returns the number of pages, at least one
+func (p TimesheetPage) TotalPages() int {
+	if p.Total == 0 || p.PageSize == 0 {
+		return 1
+	}
+	return (p.Total + p.PageSize - 1) / p.PageSize
+}
+
+// ParseTimesheetQuery builds a query from query string values read with get.
+// period is today (default), week, month or custom, which takes start and end
+// (YYYY-MM-DD); a single date selects that day. Invalid values are reported in Portuguese.
+func ParseTimesheetQuery(get func(key string) string, now time.Time) (TimesheetQuery, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	q := TimesheetQuery{
+		Period:   get("period"),
+		Start:    today,
+		End:      today,
+		Sort:     "date",
+		Desc:     true,
+		Page:     1,
+		PageSize: DefaultTimesheetPageSize,
+	}
+
+	if date := get("date"); date != "" && q.Period == "" {
+		day, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			return q, fmt.Errorf("formato de data inválido (use YYYY-MM-DD)")
+		}
+		q.Period, q.Start, q.End = "custom", day, day
+	}
+
+	switch q.Period {
+	case "", "today":
+		q.Period = "today"
+	case "week":
+		q.Start = WeekStart(today)
+		q.End = q.Start.AddDate(0, 0, 6)
+	case "month":
+		q.Start = time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+		q.End = q.Start.AddDate(0, 1, -1)
+	case "custom":
+		if s := get("start"); s != "" {
+			start, err := time.Parse("2006-01-02", s)
+			if err != nil {
+				return q, fmt.Errorf("formato de data inicial inválido (use YYYY-MM-DD)")
+			}
+			q.Start = start
+		}
+		if e := get("end"); e != "" {
+			end, err := time.Parse("2006-01-02", e)
+			if err != nil {
+				return q, fmt.Errorf("formato de data final inválido (use YYYY-MM-DD)")
+			}
+			q.End = end
+		}
+		if q.End.Before(q.Start) {
+			return q, fmt.Errorf("data final deve ser posterior à data inicial")
+		}
+		if q.End.Sub(q.Start) > MaxTimesheetRangeDays*24*time.Hour {
+			return q, fmt.Errorf("o período não pode ultrapassar %d dias", MaxTimesheetRangeDays)
+		}
+	default:
+		return q, fmt.Errorf("período inválido: %q", q.Period)
+	}
+
+	if member := get("member"); member != "" {
+		id, err := uuid.Parse(member)
+		if err != nil {
+			return q, fmt.Errorf("ID do usuário inválido")
+		}
+		q.MemberID = &id
+	}
+
+	if team := get("team"); team != "" {
+		id, err := uuid.Parse(team)
+		if err != nil {
+			return q, fmt.Errorf("ID da equipe inválido")
+		}
+		q.TeamID = &id
+	}
+
+	if status := get("status"); status != "" {
+		s, err := strconv.Atoi(status)
+		if err != nil || s < int(StatusOpen) || s > int(StatusReproved) {
+			return q, fmt.Errorf("status inválido: %q", status)
+		}
+		q.Status = TimesheetStatus(s)
+	}
+
+	if sort := get("sort"); sort != "" {
+		valid := false
+		for _, s := range TimesheetSorts {
+			valid = valid || s == sort
+		}
+		if !valid {
+			return q, fmt.Errorf("ordenação inválida: %q", sort)
+		}
+		q.Sort = sort
+		q.Desc = sort == "date"
+	}
+
+	switch get("order") {
+	case "":
+	case "asc":
+		q.Desc = false
+	case "desc":
+		q.Desc = true
+	default:
+		return q, fmt.Errorf("ordem inválida (use asc ou desc)")
+	}
+
+	if page := get("page"); page != "" {
+		p, err := strconv.Atoi(page)
+		if err != nil || p < 1 {
+			return q, fmt.Errorf("página inválida")
+		}
+		q.Page = p
+	}
+
+	if size := get("page_size"); size != "" {
+		s, err := strconv.Atoi(size)
+		if err != nil || s < 1 || s > MaxTimesheetPageSize {
+			return q, fmt.Errorf("tamanho de página deve estar entre 1 e %d", MaxTimesheetPageSize)
+		}
+		q.PageSize = s
+	}
+
+	return q, nil
+}
+
+// CursorKey returns the type of the sort column the keyset listing puts in
+// its cursors
+func (q TimesheetQuery) CursorKey() CursorField {
+	switch q.Sort {
+	case "name":
+		return CursorText
+	case "status":
+		return CursorSmallInt
+	default:
+		return CursorDate
+	}
+}
+
+// Values encodes the query back into query string values, so links keep the
+// current filters
+func (q TimesheetQuery) Values() url.Values {
+	v := url.Values{}
+	v.Set("period", q.Period)
+	if q.Period == "custom" {
+		v.Set("start", q.Start.Format("2006-01-02"))
+		v.Set("end", q.End.Format("2006-01-02"))
+	}
+	if q.MemberID != nil {
+		v.Set("member", q.MemberID.String())
+	}
+	if q.TeamID != nil {
+		v.Set("team", q.TeamID.String())
+	}
+	if q.Status != 0 {
+		v.Set("status", strconv.Itoa(int(q.Status)))
+	}
+	v.Set("sort", q.Sort)
+	if q.Desc {
+		v.Set("order", "desc")
+	} else {
+		v.Set("order", "asc")
+	}
+	v.Set("page", strconv.Itoa(q.Page))
+	if q.PageSize != DefaultTimesheetPageSize {
+		v.Set("page_size", strconv.Itoa(q.PageSize))
+	}
+	return v
+}
+
+// PageURL returns base with the current filters and another page
+func (q TimesheetQuery) PageURL(base string, page int) string {
+	q.Page = page
+	return base + "?" + q.Values().Encode()
+}
+
+// SortURL returns base sorted by column, toggling the order when the query is
+// already sorted by it, back on the first page
+func (q TimesheetQuery) SortURL(base, column string) string {
+	if q.Sort == column {
+		q.Desc = !q.Desc
+	} else {
+		q.Sort, q.Desc = column, column == "date"
+	}
+	q.Page = 1
+	return base + "?" + q.Values().Encode()
+}
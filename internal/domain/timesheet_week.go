@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WeekStatus is the state of a submission week. Members submit drafts and
+// rejected weeks; reviewers approve or reject submitted ones.
+type WeekStatus string
+
+const (
+	WeekDraft     WeekStatus = "draft"
+	WeekSubmitted WeekStatus = "submitted"
+	WeekApproved  WeekStatus = "approved"
+	WeekRejected  WeekStatus = "rejected"
+)
+
+// Label returns the status name shown to users
+func (s WeekStatus) Label() string {
+	switch s {
+	case WeekDraft:
+		return "Rascunho"
+	case WeekSubmitted:
+		return "Enviada"
+	case WeekApproved:
+		return "Aprovada"
+	case WeekRejected:
+		return "Rejeitada"
+	default:
+		return string(s)
+	}
+}
+
+// CanSubmit reports whether a week in the status may be submitted
+func (s WeekStatus) CanSubmit() bool {
+	return s == WeekDraft || s == WeekRejected
+}
+
+// WeekSettings is whether the organization asks its members to submit their
+// weeks for review
+type WeekSettings struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	Enabled        bool      `json:"enabled"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type UpdateWeekSettings struct {
+	Enabled bool `json:"enabled" form:"enabled"`
+}
+
+// TimesheetWeek groups the daily timesheets of a member from Monday to Sunday.
+// WorkedMinutes is the total when the week was last submitted.
+type TimesheetWeek struct {
+	ID             uuid.UUID  `json:"id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	UserName       string     `json:"user_name"`
+	UserEmail      string     `json:"user_email"`
+	WeekStart      time.Time  `json:"week_start"`
+	Status         WeekStatus `json:"status"`
+	WorkedMinutes  int64      `json:"worked_minutes"`
+	Note           string     `json:"note"`
+	SubmittedAt    *time.Time `json:"submitted_at,omitempty"`
+	ReviewedBy     *uuid.UUID `json:"reviewed_by,omitempty"`
+	ReviewerName   string     `json:"reviewer_name,omitempty"`
+	ReviewedAt     *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote     string     `json:"review_note"`
+	RemindedAt     *time.Time `json:"reminded_at,omitempty"`
+}
+
+// WeekEnd returns the Sunday of the week
+func (w TimesheetWeek) WeekEnd() time.Time {
+	return w.WeekStart.AddDate(0, 0, 6)
+}
+
+// Label returns the week period as DD/MM a DD/MM/YYYY
+func (w TimesheetWeek) Label() string {
+	return WeekLabel(w.WeekStart)
+}
+
+// WeekStart returns the Monday of the week of the date
+func WeekStart(date time.Time) time.Time {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// ParseWeek parses a YYYY-MM-DD date into the Monday of its week
+func ParseWeek(s string) (time.Time, error) {
+	date, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("formato de semana inválido (use YYYY-MM-DD)")
+	}
+	return WeekStart(date), nil
+}
+
+// WeekLabel returns the week starting at the Monday as DD/MM a DD/MM/YYYY
+func WeekLabel(start time.Time) string {
+	return fmt.Sprintf("%s a %s", start.Format("02/01"), start.AddDate(0, 0, 6).Format("02/01/2006"))
+}
+
+// SubmitWeek is the week submission form. Week is any date of the week as
+// YYYY-MM-DD.
+type SubmitWeek struct {
+	Week string `json:"week" form:"week" validate:"required"`
+	Note string `json:"note" form:"note" validate:"max=500"`
+}
+
+// ReviewWeek is the reviewer note sent with an approval or rejection. It is
+// required to reject, so the member knows what to fix.
+type ReviewWeek struct {
+	Note string `json:"note" form:"note" validate:"max=500"`
+}
+
+// WeekDay is a day of the week with its timesheet, nil when there is none
+type WeekDay struct {
+	Date      time.Time       `json:"date"`
+	Timesheet *DailyTimesheet `json:"timesheet,omitempty"`
+}
+
+// WeekSummary is a week with its days and totals computed from the current
+// timesheets
+type WeekSummary struct {
+	Week          TimesheetWeek `json:"week"`
+	Days          []WeekDay     `json:"days"`
+	WorkedDays    int           `json:"worked_days"`
+	Absences      int           `json:"absences"`
+	WorkedMinutes int64         `json:"worked_minutes"`
+	NightMinutes  int64         `json:"night_minutes"`
+}
+
+// NewWeekSummary lays the timesheets out over the seven days of the week,
+// counting night minutes in the organization zone loc
+func NewWeekSummary(week TimesheetWeek, timesheets []UserTimesheet, loc *time.Location) WeekSummary {
+	summary := WeekSummary{Week: week}
+
+	byDay := map[string]*DailyTimesheet{}
+	for i := range timesheets {
+		byDay[timesheets[i].Date.Format("2006-01-02")] = &timesheets[i].DailyTimesheet
+	}
+
+	for i := range 7 {
+		day := week.WeekStart.AddDate(0, 0, i)
+		ts := byDay[day.Format("2006-01-02")]
+		summary.Days = append(summary.Days, WeekDay{Date: day, Timesheet: ts})
+		if ts == nil {
+			continue
+		}
+
+		if ts.StatusID == StatusAbsent {
+			summary.Absences++
+			continue
+		}
+
+		worked := ts.WorkedMinutes()
+		if worked > 0 {
+			summary.WorkedDays++
+			summary.WorkedMinutes += worked
+			summary.NightMinutes += ts.NightMinutes(loc)
+		}
+	}
+
+	return summary
+}
+
+// TimesheetWeekEvent is the payload of the week webhook events
+type TimesheetWeekEvent struct {
+	WeekID        uuid.UUID  `json:"week_id"`
+	UserID        uuid.UUID  `json:"user_id"`
+	WeekStart     time.Time  `json:"week_start"`
+	Status        WeekStatus `json:"status"`
+	WorkedMinutes int64      `json:"worked_minutes"`
+}
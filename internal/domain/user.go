@@ -7,6 +7,7 @@ type User struct {
 	Name     string    `json:"name" form:"name" validate:"required,min=5,max=100"`
 	Email    string    `json:"email" form:"email" validate:"required,email"`
 	Password string    `json:"password" form:"password" validate:"required,min=6,max=30"`
+	CPF      string    `json:"cpf" form:"cpf"`
 }
 type LoginUser struct {
 	ID       uuid.UUID `json:"id" form:"id"`
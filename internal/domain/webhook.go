@@ -0,0 +1,304 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookEvent identifies something that happened inside an organization and
+// can be sent to the webhooks subscribed to it
+type WebhookEvent string
+
+const (
+	WebhookPunchCreated      WebhookEvent = "punch.created"
+	WebhookTimesheetApproved WebhookEvent = "timesheet.approved"
+	WebhookTimesheetReproved WebhookEvent = "timesheet.reproved"
+	WebhookMemberAdded       WebhookEvent = "member.added"
+	WebhookMemberRemoved     WebhookEvent = "member.removed"
+	WebhookWeekSubmitted     WebhookEvent = "timesheet_week.submitted"
+	WebhookWeekApproved      WebhookEvent = "timesheet_week.approved"
+	WebhookWeekRejected      WebhookEvent = "timesheet_week.rejected"
+	WebhookWeekOverdue       WebhookEvent = "timesheet_week.overdue"
+)
+
+// WebhookEvents lists every event a webhook can subscribe to
+var WebhookEvents = []WebhookEvent{
+	WebhookPunchCreated,
+	WebhookTimesheetApproved,
+	WebhookTimesheetReproved,
+	WebhookMemberAdded,
+	WebhookMemberRemoved,
+	WebhookWeekSubmitted,
+	WebhookWeekApproved,
+	WebhookWeekRejected,
+	WebhookWeekOverdue,
+}
+
+// Label returns the event description shown to users
+func (e WebhookEvent) Label() string {
+	switch e {
+	case WebhookPunchCreated:
+		return "Ponto registrado"
+	case WebhookTimesheetApproved:
+		return "Ponto aprovado"
+	case WebhookTimesheetReproved:
+		return "Ponto reprovado"
+	case WebhookMemberAdded:
+		return "Membro adicionado"
+	case WebhookMemberRemoved:
+		return "Membro removido"
+	case WebhookWeekSubmitted:
+		return "Semana enviada"
+	case WebhookWeekApproved:
+		return "Semana aprovada"
+	case WebhookWeekRejected:
+		return "Semana rejeitada"
+	case WebhookWeekOverdue:
+		return "Semana não enviada"
+	default:
+		return string(e)
+	}
+}
+
+// Headers sent with every delivery. The signature is the hex HMAC-SHA256 of
+// "<timestamp>.<body>" using the webhook secret, prefixed with "sha256=".
+const (
+	WebhookEventHeader     = "X-Timesheet-Event"
+	WebhookDeliveryHeader  = "X-Timesheet-Delivery"
+	WebhookTimestampHeader = "X-Timesheet-Timestamp"
+	WebhookSignatureHeader = "X-Timesheet-Signature"
+)
+
+// WebhookMaxAttempts is how many times a delivery is tried before it is marked as failed
+const WebhookMaxAttempts = 10
+
+// Webhook is an organization subscription that receives events over HTTP
+type Webhook struct {
+	ID             uuid.UUID      `json:"id"`
+	OrganizationID uuid.UUID      `json:"organization_id"`
+	URL            string         `json:"url"`
+	Secret         string         `json:"secret,omitempty"`
+	Events         []WebhookEvent `json:"events"`
+	Active         bool           `json:"active"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+// Subscribes reports whether the webhook receives the event
+func (w Webhook) Subscribes(e WebhookEvent) bool {
+	return slices.Contains(w.Events, e)
+}
+
+type CreateWebhook struct {
+	URL    string         `json:"url" form:"url" validate:"required,url,max=500"`
+	Events []WebhookEvent `json:"events" form:"events" validate:"required,min=1"`
+}
+
+// Validate checks the URL uses HTTP(S) on a public host and every event is known
+func (cw CreateWebhook) Validate() error {
+	if err := validateWebhookURL(cw.URL); err != nil {
+		return err
+	}
+	return validateWebhookEvents(cw.Events)
+}
+
+// UpdateWebhook changes a webhook. Nil or empty fields are kept as they are.
+type UpdateWebhook struct {
+	URL    *string        `json:"url" form:"url" validate:"omitempty,url,max=500"`
+	Events []WebhookEvent `json:"events" form:"events"`
+	Active *bool          `json:"active" form:"active"`
+}
+
+// Validate checks the fields being changed
+func (uw UpdateWebhook) Validate() error {
+	if uw.URL != nil {
+		if err := validateWebhookURL(*uw.URL); err != nil {
+			return err
+		}
+	}
+	if len(uw.Events) > 0 {
+		return validateWebhookEvents(uw.Events)
+	}
+	return nil
+}
+
+// Apply returns w with the requested changes
+func (uw UpdateWebhook) Apply(w Webhook) Webhook {
+	if uw.URL != nil {
+		w.URL = *uw.URL
+	}
+	if len(uw.Events) > 0 {
+		w.Events = uw.Events
+	}
+	if uw.Active != nil {
+		w.Active = *uw.Active
+	}
+	return w
+}
+
+func validateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("URL do webhook inválida (use http ou https)")
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrWebhookAddressBlocked
+	}
+	if ip, err := netip.ParseAddr(host); err == nil && WebhookAddressBlocked(ip) {
+		return ErrWebhookAddressBlocked
+	}
+	return nil
+}
+
+// ErrWebhookAddressBlocked is returned for webhook URLs that point, or
+// resolve, to an address of the server own network
+var ErrWebhookAddressBlocked = errors.New("o webhook não pode apontar para um endereço interno")
+
+// WebhookAddressBlocked reports whether deliveries to ip are refused:
+// loopback, private, link-local, unspecified and multicast addresses. The URL
+// check only sees IP literals, so the sender checks the dialed address too.
+func WebhookAddressBlocked(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return !ip.IsValid() ||
+		ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified()
+}
+
+func validateWebhookEvents(events []WebhookEvent) error {
+	for _, e := range events {
+		if !slices.Contains(WebhookEvents, e) {
+			return fmt.Errorf("evento de webhook inválido: %s", e)
+		}
+	}
+	return nil
+}
+
+// WebhookDeliveryStatus is the state of a delivery in the queue
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// Label returns the status description shown to users
+func (s WebhookDeliveryStatus) Label() string {
+	switch s {
+	case WebhookDeliveryPending:
+		return "Pendente"
+	case WebhookDeliveryDelivered:
+		return "Entregue"
+	case WebhookDeliveryFailed:
+		return "Falhou"
+	default:
+		return string(s)
+	}
+}
+
+// WebhookDelivery is one event queued for a webhook, with the result of its last attempt
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id"`
+	WebhookID      uuid.UUID             `json:"webhook_id"`
+	OrganizationID uuid.UUID             `json:"organization_id"`
+	URL            string                `json:"url"`
+	Event          WebhookEvent          `json:"event"`
+	Payload        json.RawMessage       `json:"payload"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	ResponseStatus *int                  `json:"response_status,omitempty"`
+	ResponseBody   string                `json:"response_body,omitempty"`
+	LastError      string                `json:"last_error,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+}
+
+// WebhookEnvelope is the JSON body posted to the webhook URL
+type WebhookEnvelope struct {
+	ID             uuid.UUID       `json:"id"`
+	Event          WebhookEvent    `json:"event"`
+	OrganizationID uuid.UUID       `json:"organization_id"`
+	CreatedAt      time.Time       `json:"created_at"`
+	Data           json.RawMessage `json:"data"`
+}
+
+// Envelope wraps the delivery payload with its metadata
+func (d WebhookDelivery) Envelope() WebhookEnvelope {
+	return WebhookEnvelope{
+		ID:             d.ID,
+		Event:          d.Event,
+		OrganizationID: d.OrganizationID,
+		CreatedAt:      d.CreatedAt,
+		Data:           d.Payload,
+	}
+}
+
+// WebhookAttempt is the outcome of posting a delivery. StatusCode is nil when
+// no response was received.
+type WebhookAttempt struct {
+	StatusCode   *int
+	ResponseBody string
+	Error        string
+}
+
+// Succeeded reports whether the receiver answered with a 2xx status
+func (a WebhookAttempt) Succeeded() bool {
+	return a.StatusCode != nil && *a.StatusCode >= 200 && *a.StatusCode < 300
+}
+
+// WebhookBackoff returns how long to wait before retrying a delivery that
+// already failed attempts times: one minute, doubling up to six hours
+func WebhookBackoff(attempts int) time.Duration {
+	const maxBackoff = 6 * time.Hour
+
+	if attempts < 1 {
+		attempts = 1
+	}
+	if attempts > 10 {
+		return maxBackoff
+	}
+	return min(time.Minute<<(attempts-1), maxBackoff)
+}
+
+// SignWebhook returns the signature header value for body sent at timestamp
+func SignWebhook(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// TimesheetReviewedEvent is the payload of timesheet.approved and timesheet.reproved
+type TimesheetReviewedEvent struct {
+	TimesheetID uuid.UUID       `json:"timesheet_id"`
+	UserID      uuid.UUID       `json:"user_id"`
+	Date        time.Time       `json:"date"`
+	StatusID    TimesheetStatus `json:"status_id"`
+	ReviewerID  uuid.UUID       `json:"reviewer_id"`
+}
+
+// PendingWebhookDelivery is a delivery claimed for sending, with the secret used to sign it
+type PendingWebhookDelivery struct {
+	WebhookDelivery
+	Secret string
+}
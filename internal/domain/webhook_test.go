@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func TestValidateWebhookURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr error
+	}{
+		{"https público", "https://hooks.example.com/timesheet", nil},
+		{"http com porta", "http://203.0.113.10:8080/hook", nil},
+		{"localhost", "http://localhost:8080/hook", ErrWebhookAddressBlocked},
+		{"subdomínio de localhost", "http://api.localhost/hook", ErrWebhookAddressBlocked},
+		{"loopback", "http://127.0.0.1/hook", ErrWebhookAddressBlocked},
+		{"loopback IPv6", "http://[::1]/hook", ErrWebhookAddressBlocked},
+		{"rede privada", "http://10.0.0.5/hook", ErrWebhookAddressBlocked},
+		{"metadados da nuvem", "http://169.254.169.254/latest/meta-data", ErrWebhookAddressBlocked},
+		{"não especificado", "http://0.0.0.0/hook", ErrWebhookAddressBlocked},
+		{"IPv4 mapeado em IPv6", "http://[::ffff:192.168.0.1]/hook", ErrWebhookAddressBlocked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateWebhookURL(tt.url)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("URL %q recusada: %v", tt.url, err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("erro = %v, esperado %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := validateWebhookURL("ftp://hooks.example.com"); err == nil {
+		t.Error("esquema ftp aceito")
+	}
+}
+
+func TestWebhookAddressBlocked(t *testing.T) {
+	tests := map[string]bool{
+		"8.8.8.8":         false,
+		"2001:4860::8888": false,
+		"127.0.0.53":      true,
+		"172.16.3.4":      true,
+		"192.168.1.1":     true,
+		"fd00::1":         true,
+		"fe80::1":         true,
+		"::":              true,
+		"224.0.0.1":       true,
+	}
+
+	for addr, want := range tests {
+		if got := WebhookAddressBlocked(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("WebhookAddressBlocked(%s) = %v, esperado %v", addr, got, want)
+		}
+	}
+}
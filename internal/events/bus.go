@@ -0,0 +1,76 @@
+// Package events implements the in-process event bus that fans out punch
+// events to live subscribers, such as the presence dashboard streams.
+package events
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+// subscriberBuffer is how many events a subscriber can lag behind before
+// new events are dropped for it
+const subscriberBuffer = 16
+
+type subscriber struct {
+	ch     chan domain.PunchEvent
+	filter func(domain.PunchEvent) bool
+}
+
+// Bus delivers the punch events of each organization to its subscribers.
+// It only reaches subscribers of the same process.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[uuid.UUID]map[*subscriber]struct{}
+}
+
+func NewBus() *Bus {
+	return &Bus{subscribers: map[uuid.UUID]map[*subscriber]struct{}{}}
+}
+
+// Subscribe returns a channel receiving the organization punch events accepted
+// by filter (every event when filter is nil) and a function that cancels the
+// subscription and closes the channel.
+func (b *Bus) Subscribe(orgID uuid.UUID, filter func(domain.PunchEvent) bool) (<-chan domain.PunchEvent, func()) {
+	sub := &subscriber{ch: make(chan domain.PunchEvent, subscriberBuffer), filter: filter}
+
+	b.mu.Lock()
+	if b.subscribers[orgID] == nil {
+		b.subscribers[orgID] = map[*subscriber]struct{}{}
+	}
+	b.subscribers[orgID][sub] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers[orgID], sub)
+			if len(b.subscribers[orgID]) == 0 {
+				delete(b.subscribers, orgID)
+			}
+			b.mu.Unlock()
+			close(sub.ch)
+		})
+	}
+
+	return sub.ch, cancel
+}
+
+// Publish delivers ev to the subscribers of its organization. Subscribers whose
+// buffer is full miss the event instead of blocking the publisher.
+func (b *Bus) Publish(ev domain.PunchEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subscribers[ev.OrganizationID] {
+		if sub.filter != nil && !sub.filter(ev) {
+			continue
+		}
+		select {
+		case sub.ch <- ev:
+		default:
+		}
+	}
+}
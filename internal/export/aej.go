@@ -0,0 +1,270 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+// AEJMember is an employee bond (vínculo) listed in the AEJ
+type AEJMember struct {
+	UserID       uuid.UUID
+	Name         string
+	CPF          string
+	ScheduleCode string
+}
+
+// AEJData holds everything the AEJ is built from
+type AEJData struct {
+	// EmployerDocument is the CNPJ (14 digits) or CPF (11 digits) of the employer
+	EmployerDocument string
+	EmployerName     string
+	Start            time.Time
+	End              time.Time
+	GeneratedAt      time.Time
+	Software         Software
+	Schedules        []domain.WorkSchedule
+	Members          []AEJMember
+	Timesheets       []domain.UserTimesheet
+}
+
+const (
+	aejLayoutVersion = "001"
+	aejRepID         = "1"
+	aejRepTypeP      = "3"
+	// aejAbsence is the tipoAusenOuComp of an unjustified absence
+	aejAbsence = "2"
+)
+
+// AEJFileName returns the file name of the AEJ
+func AEJFileName(data AEJData) string {
+	return fmt.Sprintf("AEJ%s%s.txt", data.EmployerDocument, data.GeneratedAt.Format("20060102150405"))
+}
+
+// WriteAEJ writes the Arquivo Eletrônico de Jornada (Portaria 671, anexo VI).
+// Records are pipe separated, ISO-8859-1 encoded with CRLF line endings, and the
+// whole file is checked against the layout before anything is written.
+func WriteAEJ(w io.Writer, data AEJData) error {
+	records := buildAEJ(data)
+	if err := validateAEJ(records); err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, record := range records {
+		raw := append(latin1(strings.Join(record, "|")), '\r', '\n')
+		if _, err := bw.Write(raw); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+func buildAEJ(data AEJData) [][]string {
+	employerType := "1"
+	if len(data.EmployerDocument) == 11 {
+		employerType = "2"
+	}
+
+	records := [][]string{{
+		"01",
+		employerType,
+		data.EmployerDocument,
+		"",
+		"",
+		data.EmployerName,
+		data.Start.Format(afdDateLayout),
+		data.End.Format(afdDateLayout),
+		data.GeneratedAt.Format(afdTimeLayout),
+		aejLayoutVersion,
+	}}
+
+	records = append(records, []string{"02", aejRepID, aejRepTypeP, data.Software.Registration})
+
+	bonds := map[uuid.UUID]string{}
+	schedules := map[string]domain.WorkSchedule{}
+	for _, s := range data.Schedules {
+		schedules[s.Code] = s
+	}
+
+	usedSchedules := []string{}
+	for i, m := range data.Members {
+		bond := strconv.Itoa(i + 1)
+		bonds[m.UserID] = bond
+		records = append(records, []string{"03", bond, m.CPF, m.Name})
+
+		if !slices.Contains(usedSchedules, m.ScheduleCode) {
+			usedSchedules = append(usedSchedules, m.ScheduleCode)
+		}
+	}
+
+	for _, code := range usedSchedules {
+		s := schedules[code]
+		records = append(records, []string{"04", code, strconv.Itoa(s.DailyMinutes), s.Entry1, s.Exit1, s.Entry2, s.Exit2})
+	}
+
+	memberSchedule := map[uuid.UUID]AEJMember{}
+	for _, m := range data.Members {
+		memberSchedule[m.UserID] = m
+	}
+
+	absences := [][]string{}
+	for _, ts := range data.Timesheets {
+		bond, ok := bonds[ts.UserID]
+		if !ok {
+			continue
+		}
+		member := memberSchedule[ts.UserID]
+
+		seq := 0
+		for _, entry := range ts.Entries {
+			// Breaks are exits and entries of their own in the AEJ
+			kind := "S"
+			if entry.TypeID.StartsWork() {
+				kind = "E"
+				seq++
+			}
+			records = append(records, []string{
+				"05",
+				bond,
+				entry.Timestamp.Format(afdTimeLayout),
+				aejRepID,
+				kind,
+				strconv.Itoa(max(seq, 1)),
+				"O",
+				member.ScheduleCode,
+				"",
+			})
+		}
+
+		if ts.StatusID == domain.StatusAbsent {
+			minutes := schedules[member.ScheduleCode].DailyMinutes
+			absences = append(absences, []string{"07", bond, aejAbsence, ts.Date.Format(afdDateLayout), strconv.Itoa(minutes), ""})
+		}
+	}
+	records = append(records, absences...)
+
+	records = append(records, []string{
+		"08",
+		data.Software.Model,
+		data.Software.Version,
+		"1",
+		data.Software.Document,
+		data.Software.DeveloperName,
+		data.Software.DeveloperEmail,
+	})
+
+	counts := make([]int, 9)
+	for _, r := range records {
+		n, _ := strconv.Atoi(r[0])
+		counts[n]++
+	}
+	trailer := []string{"99"}
+	for _, n := range counts[1:] {
+		trailer = append(trailer, strconv.Itoa(n))
+	}
+
+	return append(records, trailer)
+}
+
+var (
+	aejDate     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	aejDateTime = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}[+-]\d{4}$`)
+	aejDigits   = regexp.MustCompile(`^\d+$`)
+)
+
+// aejFieldCount is the number of fields of each record type
+var aejFieldCount = map[string]int{
+	"01": 10, "02": 4, "03": 4, "04": 7, "05": 9, "07": 6, "08": 7, "99": 9,
+}
+
+// validateAEJ checks field counts, formats and references between records
+func validateAEJ(records [][]string) error {
+	bonds := map[string]bool{}
+	schedules := map[string]bool{}
+
+	for i, r := range records {
+		line := i + 1
+		fail := func(format string, args ...any) error {
+			return fmt.Errorf("leiaute AEJ inválido na linha %d (registro %s): %s", line, r[0], fmt.Sprintf(format, args...))
+		}
+
+		want, ok := aejFieldCount[r[0]]
+		if !ok {
+			return fail("tipo de registro desconhecido")
+		}
+		if len(r) != want {
+			return fail("esperados %d campos, encontrados %d", want, len(r))
+		}
+		for _, field := range r {
+			if strings.ContainsAny(field, "|\r\n") {
+				return fail("campo contém separador")
+			}
+		}
+
+		switch r[0] {
+		case "01":
+			if !aejDigits.MatchString(r[2]) || (len(r[2]) != 11 && len(r[2]) != 14) {
+				return fail("CNPJ/CPF do empregador inválido")
+			}
+			if r[5] == "" {
+				return fail("razão social obrigatória")
+			}
+			if !aejDate.MatchString(r[6]) || !aejDate.MatchString(r[7]) || !aejDateTime.MatchString(r[8]) {
+				return fail("data inválida")
+			}
+		case "03":
+			if !aejDigits.MatchString(r[2]) || len(r[2]) != 11 {
+				return fail("CPF inválido")
+			}
+			if r[3] == "" {
+				return fail("nome do empregado obrigatório")
+			}
+			bonds[r[1]] = true
+		case "04":
+			if r[1] == "" || !aejDigits.MatchString(r[2]) {
+				return fail("horário contratual inválido")
+			}
+			for _, t := range r[3:] {
+				if t != "" && !domain.ValidHHMM(t) {
+					return fail("horário %q inválido", t)
+				}
+			}
+			if r[3] == "" || r[4] == "" {
+				return fail("primeiro turno obrigatório")
+			}
+			schedules[r[1]] = true
+		case "05":
+			if !bonds[r[1]] {
+				return fail("vínculo %s não declarado", r[1])
+			}
+			if !aejDateTime.MatchString(r[2]) {
+				return fail("data e hora da marcação inválida")
+			}
+			if r[4] != "E" && r[4] != "S" {
+				return fail("tipo de marcação inválido")
+			}
+			if !schedules[r[7]] {
+				return fail("horário contratual %q não declarado", r[7])
+			}
+		case "07":
+			if !bonds[r[1]] {
+				return fail("vínculo %s não declarado", r[1])
+			}
+			if !aejDate.MatchString(r[3]) || !aejDigits.MatchString(r[4]) {
+				return fail("ausência inválida")
+			}
+		}
+	}
+
+	return nil
+}
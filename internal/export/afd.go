@@ -0,0 +1,114 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+// AFDHeader holds the data of the AFD header record
+type AFDHeader struct {
+	// EmployerDocument is the CNPJ (14 digits) or CPF (11 digits) of the employer
+	EmployerDocument string
+	EmployerName     string
+	Start            time.Time
+	End              time.Time
+	GeneratedAt      time.Time
+	Software         Software
+}
+
+const (
+	afdLayoutVersion = "003"
+	afdDateLayout    = "2006-01-02"
+	afdTimeLayout    = "2006-01-02T15:04:05-0700"
+)
+
+// AFDFileName returns the file name expected by auditors
+func AFDFileName(header AFDHeader) string {
+	return fmt.Sprintf("AFD%s%sREP_P.txt", header.Software.Registration, header.EmployerDocument)
+}
+
+// WriteAFD writes the Arquivo Fonte de Dados (Portaria 671, anexo V) with a
+// header (type 1), one marking record (type 3) per punch and the trailer (type 9).
+// Every header and marking line ends with its CRC-16/KERMIT; the file is ISO-8859-1
+// with CRLF line endings.
+func WriteAFD(w io.Writer, header AFDHeader, punches []domain.EmployeePunch) error {
+	bw := bufio.NewWriter(w)
+
+	employerType := "1"
+	if len(header.EmployerDocument) == 11 {
+		employerType = "2"
+	}
+
+	head := "000000000" +
+		"1" +
+		employerType +
+		digits(header.EmployerDocument, 14) +
+		alpha("", 14) +
+		alpha(header.EmployerName, 150) +
+		alpha(header.Software.Registration, 17) +
+		header.Start.Format(afdDateLayout) +
+		header.End.Format(afdDateLayout) +
+		header.GeneratedAt.Format(afdTimeLayout) +
+		afdLayoutVersion +
+		"1" +
+		digits(header.Software.Document, 14) +
+		alpha(header.Software.Model, 30)
+	if err := writeAFDLine(bw, head, true); err != nil {
+		return err
+	}
+
+	for _, p := range punches {
+		line := num(p.NSR, 9) +
+			"3" +
+			p.Timestamp.Format(afdTimeLayout) +
+			digits(p.CPF, 12)
+		if err := writeAFDLine(bw, line, true); err != nil {
+			return err
+		}
+	}
+
+	trailer := "999999999" +
+		num(0, 9) + // tipo 2
+		num(int64(len(punches)), 9) + // tipo 3
+		num(0, 9) + // tipo 4
+		num(0, 9) + // tipo 5
+		num(0, 9) + // tipo 6
+		num(0, 9) + // tipo 7
+		"9"
+	if err := writeAFDLine(bw, trailer, false); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+func writeAFDLine(w *bufio.Writer, line string, withCRC bool) error {
+	raw := latin1(line)
+	if withCRC {
+		raw = fmt.Appendf(raw, "%04X", CRC16(raw))
+	}
+	raw = append(raw, '\r', '\n')
+
+	_, err := w.Write(raw)
+	return err
+}
+
+// CRC16 computes the CRC-16/KERMIT checksum used by the AFD layout
+func CRC16(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b)
+		for range 8 {
+			if crc&1 != 0 {
+				crc = crc>>1 ^ 0x8408
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+	return crc
+}
@@ -0,0 +1,177 @@
+package export
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func afdFixture() (AFDHeader, []domain.EmployeePunch) {
+	loc := time.FixedZone("BRT", -3*60*60)
+	header := AFDHeader{
+		EmployerDocument: "12345678000195",
+		EmployerName:     "Padaria São João Ltda",
+		Start:            time.Date(2025, 11, 1, 0, 0, 0, 0, loc),
+		End:              time.Date(2025, 11, 30, 0, 0, 0, 0, loc),
+		GeneratedAt:      time.Date(2025, 12, 1, 8, 30, 0, 0, loc),
+		Software: Software{
+			Document:     "98765432000110",
+			Registration: "BR512025000001",
+			Model:        "Timesheet Pro",
+			Version:      "1.0",
+		},
+	}
+	punches := []domain.EmployeePunch{
+		{NSR: 1, CPF: "52998224725", TypeID: domain.EntryTypeIn, Timestamp: time.Date(2025, 11, 3, 8, 0, 0, 0, loc)},
+		{NSR: 2, CPF: "52998224725", TypeID: domain.EntryTypeOut, Timestamp: time.Date(2025, 11, 3, 12, 0, 0, 0, loc)},
+		{NSR: 3, CPF: "11144477735", TypeID: domain.EntryTypeIn, Timestamp: time.Date(2025, 11, 3, 13, 5, 0, 0, loc)},
+		{NSR: 4, CPF: "11144477735", TypeID: domain.EntryTypeOut, Timestamp: time.Date(2025, 11, 3, 17, 59, 0, 0, loc)},
+	}
+	return header, punches
+}
+
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("lendo %s: %v (rode com -update para gerar)", path, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("saída difere de %s\n got: %q\nwant: %q", path, got, want)
+	}
+}
+
+func TestWriteAFDGolden(t *testing.T) {
+	tests := []struct {
+		name    string
+		golden  string
+		punches func([]domain.EmployeePunch) []domain.EmployeePunch
+	}{
+		{"com marcações", "afd.golden", func(p []domain.EmployeePunch) []domain.EmployeePunch { return p }},
+		{"sem marcações", "afd_empty.golden", func([]domain.EmployeePunch) []domain.EmployeePunch { return nil }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header, punches := afdFixture()
+
+			var buf bytes.Buffer
+			if err := WriteAFD(&buf, header, tt.punches(punches)); err != nil {
+				t.Fatal(err)
+			}
+			assertGolden(t, tt.golden, buf.Bytes())
+		})
+	}
+}
+
+func afdLines(t *testing.T, raw []byte) []string {
+	t.Helper()
+
+	if !bytes.HasSuffix(raw, []byte("\r\n")) {
+		t.Fatalf("arquivo não termina com CRLF")
+	}
+	return strings.Split(strings.TrimSuffix(string(raw), "\r\n"), "\r\n")
+}
+
+func TestWriteAFDRecords(t *testing.T) {
+	header, punches := afdFixture()
+
+	var buf bytes.Buffer
+	if err := WriteAFD(&buf, header, punches); err != nil {
+		t.Fatal(err)
+	}
+	lines := afdLines(t, buf.Bytes())
+	if len(lines) != len(punches)+2 {
+		t.Fatalf("esperado %d linhas, obtido %d", len(punches)+2, len(lines))
+	}
+
+	head := lines[0]
+	if len(head) != 302 {
+		t.Errorf("cabeçalho com %d posições, esperado 302", len(head))
+	}
+	if head[:9] != "000000000" || head[9] != '1' {
+		t.Errorf("cabeçalho deve começar com NSR zero e tipo 1: %q", head[:10])
+	}
+	if head[10] != '1' || head[11:25] != header.EmployerDocument {
+		t.Errorf("identificação do empregador inválida: %q", head[10:25])
+	}
+
+	for i, line := range lines[1 : len(lines)-1] {
+		if len(line) != 50 {
+			t.Errorf("marcação %d com %d posições, esperado 50", i+1, len(line))
+		}
+		if want := fmt.Sprintf("%09d", i+1); line[:9] != want {
+			t.Errorf("NSR fora de sequência na linha %d: %q, esperado %q", i+2, line[:9], want)
+		}
+		if line[9] != '3' {
+			t.Errorf("linha %d deve ser tipo 3, obtido %q", i+2, line[9])
+		}
+		if want := "0" + punches[i].CPF; line[34:46] != want {
+			t.Errorf("CPF da linha %d: %q, esperado %q", i+2, line[34:46], want)
+		}
+	}
+
+	trailer := lines[len(lines)-1]
+	if len(trailer) != 64 {
+		t.Errorf("trailer com %d posições, esperado 64", len(trailer))
+	}
+	if trailer[:9] != "999999999" || trailer[63] != '9' {
+		t.Errorf("trailer deve ter NSR 999999999 e tipo 9: %q", trailer)
+	}
+	if want := fmt.Sprintf("%09d", len(punches)); trailer[18:27] != want {
+		t.Errorf("quantidade de registros tipo 3 no trailer: %q, esperado %q", trailer[18:27], want)
+	}
+}
+
+func TestWriteAFDLineCRC(t *testing.T) {
+	header, punches := afdFixture()
+
+	var buf bytes.Buffer
+	if err := WriteAFD(&buf, header, punches); err != nil {
+		t.Fatal(err)
+	}
+	lines := afdLines(t, buf.Bytes())
+
+	// header and type 3 records end with the CRC of the preceding bytes; the
+	// trailer has none
+	for i, line := range lines[:len(lines)-1] {
+		body, crc := line[:len(line)-4], line[len(line)-4:]
+		if want := fmt.Sprintf("%04X", CRC16([]byte(body))); crc != want {
+			t.Errorf("CRC da linha %d: %s, esperado %s", i+1, crc, want)
+		}
+	}
+}
+
+func TestCRC16(t *testing.T) {
+	tests := []struct {
+		in   string
+		want uint16
+	}{
+		{"", 0x0000},
+		// check value of CRC-16/KERMIT
+		{"123456789", 0x2189},
+	}
+
+	for _, tt := range tests {
+		if got := CRC16([]byte(tt.in)); got != tt.want {
+			t.Errorf("CRC16(%q) = %04X, esperado %04X", tt.in, got, tt.want)
+		}
+	}
+}
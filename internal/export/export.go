@@ -0,0 +1,55 @@
+// Package export writes organization data in the file layouts required by
+// auditors and external systems.
+package export
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Software identifies this application in the fiscal files (Portaria 671)
+type Software struct {
+	// Document is the developer CNPJ
+	Document string
+	// Registration is the REP-P registration number at INPI
+	Registration string
+	Model        string
+	Version      string
+	// DeveloperName and DeveloperEmail identify the developer in the AEJ
+	DeveloperName  string
+	DeveloperEmail string
+}
+
+// alpha left-aligns s in a field of n characters, padding with spaces
+func alpha(s string, n int) string {
+	if utf8.RuneCountInString(s) > n {
+		s = string([]rune(s)[:n])
+	}
+	return s + strings.Repeat(" ", n-utf8.RuneCountInString(s))
+}
+
+// num right-aligns a number in a field of n digits, padding with zeros
+func num(v int64, n int) string {
+	return fmt.Sprintf("%0*d", n, v)
+}
+
+// digits right-aligns a numeric string in a field of n digits, padding with zeros
+func digits(s string, n int) string {
+	if len(s) > n {
+		s = s[len(s)-n:]
+	}
+	return strings.Repeat("0", n-len(s)) + s
+}
+
+// latin1 encodes s as ISO-8859-1, replacing characters outside it with '?'
+func latin1(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		if r > 0xFF {
+			r = '?'
+		}
+		out = append(out, byte(r))
+	}
+	return out
+}
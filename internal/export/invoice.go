@@ -0,0 +1,46 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+// InvoiceCSV writes the lines of an invoice separated by semicolons, with
+// hours as HH:MM and amounts with a decimal comma, ending with the total
+func InvoiceCSV(w io.Writer, inv domain.Invoice) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = ';'
+
+	rows := [][]string{{"item", "membro", "projeto", "horas", "horas_faturadas", "valor_hora", "valor"}}
+	for _, l := range inv.Lines {
+		rows = append(rows, []string{
+			strconv.Itoa(l.Position),
+			l.UserName,
+			l.ProjectName,
+			domain.FormatMinutes(l.Minutes),
+			domain.FormatMinutes(l.BilledMinutes),
+			decimal(l.HourlyRate),
+			decimal(l.Amount),
+		})
+	}
+	rows = append(rows, []string{"", "Total", "", "", domain.FormatMinutes(inv.TotalMinutes), "", decimal(inv.Total)})
+
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// decimal formats an amount as 1234,56
+func decimal(c domain.Cents) string {
+	sign := ""
+	if c < 0 {
+		sign = "-"
+		c = -c
+	}
+	return fmt.Sprintf("%s%d,%02d", sign, int64(c)/100, int64(c)%100)
+}
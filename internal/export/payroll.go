@@ -0,0 +1,107 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+// PayrollCSV writes payroll events as a CSV with the columns chosen by the
+// organization, in the configured order
+type PayrollCSV struct {
+	Columns   []string
+	Delimiter string
+}
+
+func NewPayrollCSV(columns []string, delimiter string) PayrollCSV {
+	return PayrollCSV{Columns: columns, Delimiter: delimiter}
+}
+
+func (PayrollCSV) ContentType() string   { return "text/csv; charset=utf-8" }
+func (PayrollCSV) FileExtension() string { return "csv" }
+
+func (p PayrollCSV) Write(w io.Writer, events []domain.PayrollEvent) error {
+	cw := csv.NewWriter(w)
+	if p.Delimiter != "" {
+		cw.Comma = []rune(p.Delimiter)[0]
+	}
+
+	if err := cw.Write(p.Columns); err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		row := make([]string, len(p.Columns))
+		for i, column := range p.Columns {
+			row[i] = payrollColumn(e, column)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func payrollColumn(e domain.PayrollEvent, column string) string {
+	switch column {
+	case "company":
+		return e.CompanyCode
+	case "cpf":
+		return e.CPF
+	case "name":
+		return e.Name
+	case "event":
+		return e.Code
+	case "description":
+		return e.Kind.Label()
+	case "quantity":
+		h := e.Hundredths()
+		return fmt.Sprintf("%d,%02d", h/100, h%100)
+	case "hours":
+		if e.Kind == domain.PayrollAbsence {
+			return ""
+		}
+		return domain.FormatMinutes(e.Quantity)
+	case "start":
+		return e.Start.Format("02/01/2006")
+	case "end":
+		return e.End.Format("02/01/2006")
+	default:
+		return ""
+	}
+}
+
+// PayrollFixedWidth writes payroll events in a fixed-width layout, ISO-8859-1
+// with CRLF line endings. Each line has 81 characters:
+//
+//	001-010 company code, left-aligned
+//	011-021 employee CPF
+//	022-027 competence (MMYYYY of the period start)
+//	028-032 event code, left-aligned
+//	033-041 quantity in hundredths of hours, or of days for absences
+//	042-081 employee name, left-aligned
+type PayrollFixedWidth struct{}
+
+func (PayrollFixedWidth) ContentType() string   { return "text/plain; charset=iso-8859-1" }
+func (PayrollFixedWidth) FileExtension() string { return "txt" }
+
+func (PayrollFixedWidth) Write(w io.Writer, events []domain.PayrollEvent) error {
+	bw := bufio.NewWriter(w)
+	for _, e := range events {
+		line := alpha(e.CompanyCode, 10) +
+			digits(e.CPF, 11) +
+			e.Start.Format("012006") +
+			alpha(e.Code, 5) +
+			num(e.Hundredths(), 9) +
+			alpha(e.Name, 40)
+		if _, err := bw.Write(latin1(line + "\r\n")); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
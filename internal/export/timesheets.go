@@ -0,0 +1,239 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	"github.com/xuri/excelize/v2"
+)
+
+// TimesheetWriter receives the timesheets of a spreadsheet export one at a time.
+// Close writes the per member summary and flushes the output.
+type TimesheetWriter interface {
+	WriteTimesheet(ts domain.UserTimesheet) error
+	Close() error
+}
+
+var (
+	timesheetHeader = []string{"Membro", "E-mail", "Data", "Status", "Registros", "Minutos trabalhados", "Horas trabalhadas"}
+	summaryHeader   = []string{"Membro", "E-mail", "Dias", "Ausências", "Minutos trabalhados", "Horas trabalhadas"}
+)
+
+// MemberSummary totals the exported days of a member
+type MemberSummary struct {
+	UserID        uuid.UUID
+	Name          string
+	Email         string
+	Days          int
+	Absences      int
+	WorkedMinutes int64
+}
+
+// summaries accumulates member totals in the order members first appear
+type summaries struct {
+	order []uuid.UUID
+	byID  map[uuid.UUID]*MemberSummary
+}
+
+func newSummaries() *summaries {
+	return &summaries{byID: map[uuid.UUID]*MemberSummary{}}
+}
+
+func (s *summaries) add(ts domain.UserTimesheet) {
+	sum, ok := s.byID[ts.UserID]
+	if !ok {
+		sum = &MemberSummary{UserID: ts.UserID, Name: ts.UserName, Email: ts.UserEmail}
+		s.byID[ts.UserID] = sum
+		s.order = append(s.order, ts.UserID)
+	}
+	sum.Days++
+	if ts.StatusID == domain.StatusAbsent {
+		sum.Absences++
+	}
+	sum.WorkedMinutes += ts.WorkedMinutes()
+}
+
+func (s *summaries) rows() [][]string {
+	rows := [][]string{}
+	for _, id := range s.order {
+		sum := s.byID[id]
+		rows = append(rows, []string{
+			sum.Name,
+			sum.Email,
+			strconv.Itoa(sum.Days),
+			strconv.Itoa(sum.Absences),
+			strconv.FormatInt(sum.WorkedMinutes, 10),
+			domain.FormatMinutes(sum.WorkedMinutes),
+		})
+	}
+	return rows
+}
+
+func timesheetRow(ts domain.UserTimesheet) []string {
+	entries := make([]string, 0, len(ts.Entries))
+	for _, e := range ts.Entries {
+		entries = append(entries, fmt.Sprintf("%s %s", e.Timestamp.Format("15:04"), e.TypeID.Label()))
+	}
+
+	worked := ts.WorkedMinutes()
+	return []string{
+		ts.UserName,
+		ts.UserEmail,
+		ts.Date.Format("02/01/2006"),
+		ts.StatusID.Label(),
+		strings.Join(entries, ", "),
+		strconv.FormatInt(worked, 10),
+		domain.FormatMinutes(worked),
+	}
+}
+
+// timesheetCSV writes one row per day, or only the member summary when summaryOnly
+// is set, since a CSV file holds a single sheet
+type timesheetCSV struct {
+	w           *csv.Writer
+	summaries   *summaries
+	summaryOnly bool
+}
+
+// NewTimesheetCSV returns a writer producing semicolon separated UTF-8 CSV with a
+// BOM, which spreadsheet applications in pt-BR open directly
+func NewTimesheetCSV(w io.Writer, summaryOnly bool) (TimesheetWriter, error) {
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return nil, err
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = ';'
+
+	header := timesheetHeader
+	if summaryOnly {
+		header = summaryHeader
+	}
+	if err := cw.Write(header); err != nil {
+		return nil, err
+	}
+
+	return &timesheetCSV{w: cw, summaries: newSummaries(), summaryOnly: summaryOnly}, nil
+}
+
+func (t *timesheetCSV) WriteTimesheet(ts domain.UserTimesheet) error {
+	t.summaries.add(ts)
+	if t.summaryOnly {
+		return nil
+	}
+	return t.w.Write(timesheetRow(ts))
+}
+
+func (t *timesheetCSV) Close() error {
+	if t.summaryOnly {
+		if err := t.w.WriteAll(t.summaries.rows()); err != nil {
+			return err
+		}
+	}
+	t.w.Flush()
+	return t.w.Error()
+}
+
+// timesheetXLSX streams the daily rows to the "Dias" sheet and writes the
+// member totals to the "Resumo" sheet on Close
+type timesheetXLSX struct {
+	out       io.Writer
+	file      *excelize.File
+	stream    *excelize.StreamWriter
+	row       int
+	summaries *summaries
+}
+
+const (
+	xlsxDaysSheet    = "Dias"
+	xlsxSummarySheet = "Resumo"
+)
+
+// NewTimesheetXLSX returns a writer producing an XLSX workbook. Rows are streamed
+// to a temporary file by excelize rather than kept in memory.
+func NewTimesheetXLSX(w io.Writer) (TimesheetWriter, error) {
+	f := excelize.NewFile()
+	if err := f.SetSheetName("Sheet1", xlsxDaysSheet); err != nil {
+		return nil, err
+	}
+
+	stream, err := f.NewStreamWriter(xlsxDaysSheet)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &timesheetXLSX{out: w, file: f, stream: stream, row: 1, summaries: newSummaries()}
+	if err := t.writeRow(stream, timesheetHeader); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (t *timesheetXLSX) WriteTimesheet(ts domain.UserTimesheet) error {
+	t.summaries.add(ts)
+
+	row := timesheetRow(ts)
+	cells := make([]any, len(row))
+	for i, v := range row {
+		cells[i] = v
+	}
+	// Keep worked minutes numeric so they can be summed in the spreadsheet
+	cells[5] = ts.WorkedMinutes()
+
+	t.row++
+	cell, _ := excelize.CoordinatesToCellName(1, t.row)
+	return t.stream.SetRow(cell, cells)
+}
+
+func (t *timesheetXLSX) Close() error {
+	defer t.file.Close()
+
+	if err := t.stream.Flush(); err != nil {
+		return err
+	}
+
+	if _, err := t.file.NewSheet(xlsxSummarySheet); err != nil {
+		return err
+	}
+
+	summary, err := t.file.NewStreamWriter(xlsxSummarySheet)
+	if err != nil {
+		return err
+	}
+
+	t.row = 1
+	if err := t.writeRow(summary, summaryHeader); err != nil {
+		return err
+	}
+	for _, id := range t.summaries.order {
+		sum := t.summaries.byID[id]
+		t.row++
+		cell, _ := excelize.CoordinatesToCellName(1, t.row)
+		err := summary.SetRow(cell, []any{sum.Name, sum.Email, sum.Days, sum.Absences, sum.WorkedMinutes, domain.FormatMinutes(sum.WorkedMinutes)})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := summary.Flush(); err != nil {
+		return err
+	}
+
+	_, err = t.file.WriteTo(t.out)
+	return err
+}
+
+func (t *timesheetXLSX) writeRow(stream *excelize.StreamWriter, values []string) error {
+	cells := make([]any, len(values))
+	for i, v := range values {
+		cells[i] = v
+	}
+	cell, _ := excelize.CoordinatesToCellName(1, t.row)
+	return stream.SetRow(cell, cells)
+}
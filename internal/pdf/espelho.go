@@ -0,0 +1,104 @@
+package pdf
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+var weekdays = [...]string{"Dom", "Seg", "Ter", "Qua", "Qui", "Sex", "Sáb"}
+
+// Espelho renders the monthly espelho de ponto of an employee. The output only
+// depends on e, so its hash identifies the records the employee signs.
+func Espelho(e domain.Espelho) ([]byte, error) {
+	d := newDocument("P", "A4")
+	d.fixDates(e.Month)
+	d.AddPage()
+
+	d.SetFont("Helvetica", "B", 13)
+	d.line(7, "Espelho de Ponto - "+domain.MonthLabel(e.Month))
+	d.Ln(2)
+
+	d.SetFont("Helvetica", "B", 10)
+	d.line(5, e.Organization.Name)
+	d.SetFont("Helvetica", "", 9)
+	if e.Organization.CNPJ != "" {
+		d.line(5, "CNPJ: "+domain.FormatCNPJ(e.Organization.CNPJ))
+	}
+	if a := e.Organization.Address; a != nil {
+		d.line(5, strings.Join(nonEmpty(a.PublicPlace, a.Complement, a.City, a.State, a.ZipCode), ", "))
+	}
+	d.Ln(2)
+
+	d.SetFont("Helvetica", "B", 9)
+	d.text(25, 5, "Empregado", "", "L")
+	d.SetFont("Helvetica", "", 9)
+	d.line(5, e.EmployeeName)
+	if e.EmployeeCPF != "" {
+		d.SetFont("Helvetica", "B", 9)
+		d.text(25, 5, "CPF", "", "L")
+		d.SetFont("Helvetica", "", 9)
+		d.line(5, domain.FormatCPF(e.EmployeeCPF))
+	}
+	d.Ln(3)
+
+	widths := []float64{22, 14, 104, 28, 22}
+	d.SetFont("Helvetica", "B", 8)
+	for i, h := range []string{"Data", "Dia", "Registros", "Situação", "Trabalhado"} {
+		d.text(widths[i], 6, h, "1", "C")
+	}
+	d.Ln(-1)
+
+	d.SetFont("Helvetica", "", 8)
+	for _, day := range e.Days {
+		records, status, worked := "", "", ""
+		if ts := day.Timesheet; ts != nil {
+			punches := []string{}
+			for _, entry := range ts.Entries {
+				punches = append(punches, entry.TypeID.Short()+" "+entry.Timestamp.Format("15:04"))
+			}
+			records = strings.Join(punches, "   ")
+			status = ts.StatusID.Label()
+			if ts.StatusID != domain.StatusAbsent {
+				worked = domain.FormatMinutes(ts.WorkedMinutes())
+			}
+		}
+
+		d.text(widths[0], 5, day.Date.Format("02/01/2006"), "1", "C")
+		d.text(widths[1], 5, weekdays[day.Date.Weekday()], "1", "C")
+		d.text(widths[2], 5, records, "1", "L")
+		d.text(widths[3], 5, status, "1", "C")
+		d.text(widths[4], 5, worked, "1", "C")
+		d.Ln(-1)
+	}
+	d.Ln(3)
+
+	totals := [][2]string{
+		{"Dias trabalhados", strconv.Itoa(e.WorkedDays)},
+		{"Faltas", strconv.Itoa(e.Absences)},
+		{"Horas trabalhadas", domain.FormatMinutes(e.WorkedMinutes)},
+	}
+	for _, row := range totals {
+		d.SetFont("Helvetica", "B", 9)
+		d.text(40, 5, row[0], "", "L")
+		d.SetFont("Helvetica", "", 9)
+		d.line(5, row[1])
+	}
+
+	d.Ln(6)
+	d.SetFont("Helvetica", "", 7)
+	d.MultiCell(0, 3.5, d.tr("Legenda: E = entrada, S = saída. A assinatura eletrônica deste espelho registra data, hora, IP e o hash SHA-256 deste documento."), "", "L", false)
+
+	return d.bytes()
+}
+
+func nonEmpty(values ...string) []string {
+	out := []string{}
+	for _, v := range values {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,82 @@
+package pdf
+
+import (
+	"strconv"
+
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+// Invoice renders an invoice or invoice draft. The output only depends on
+// inv, so an issued invoice always downloads as the same document.
+func Invoice(inv domain.Invoice) ([]byte, error) {
+	d := newDocument("P", "A4")
+	d.fixDates(inv.CreatedAt)
+	d.AddPage()
+
+	d.SetFont("Helvetica", "B", 13)
+	if inv.Status == domain.InvoiceIssued {
+		d.line(7, "Fatura Nº "+inv.Code())
+	} else {
+		d.line(7, "Rascunho de Fatura")
+	}
+	d.Ln(2)
+
+	d.SetFont("Helvetica", "B", 10)
+	d.line(5, inv.OrganizationName)
+	d.Ln(2)
+
+	rows := [][2]string{
+		{"Cliente", inv.ClientName},
+	}
+	if inv.ClientDocument != "" {
+		rows = append(rows, [2]string{"CPF/CNPJ", domain.FormatDocument(inv.ClientDocument)})
+	}
+	rows = append(rows,
+		[2]string{"Período", inv.PeriodStart.Format("02/01/2006") + " a " + inv.PeriodEnd.Format("02/01/2006")},
+		[2]string{"Arredondamento", domain.RoundingLabel(inv.RoundingMinutes, inv.RoundingMode)},
+	)
+	if inv.IssuedAt != nil {
+		rows = append(rows, [2]string{"Emissão", inv.IssuedAt.Format("02/01/2006")})
+	}
+	for _, row := range rows {
+		d.SetFont("Helvetica", "B", 9)
+		d.text(30, 5, row[0], "", "L")
+		d.SetFont("Helvetica", "", 9)
+		d.line(5, row[1])
+	}
+	d.Ln(3)
+
+	widths := []float64{8, 50, 50, 20, 20, 20, 22}
+	d.SetFont("Helvetica", "B", 8)
+	for i, h := range []string{"#", "Membro", "Projeto", "Horas", "Faturadas", "Valor/hora", "Valor"} {
+		d.text(widths[i], 6, h, "1", "C")
+	}
+	d.Ln(-1)
+
+	d.SetFont("Helvetica", "", 8)
+	for _, l := range inv.Lines {
+		d.text(widths[0], 5, strconv.Itoa(l.Position), "1", "C")
+		d.text(widths[1], 5, l.UserName, "1", "L")
+		d.text(widths[2], 5, l.ProjectName, "1", "L")
+		d.text(widths[3], 5, domain.FormatMinutes(l.Minutes), "1", "C")
+		d.text(widths[4], 5, domain.FormatMinutes(l.BilledMinutes), "1", "C")
+		d.text(widths[5], 5, l.HourlyRate.String(), "1", "R")
+		d.text(widths[6], 5, l.Amount.String(), "1", "R")
+		d.Ln(-1)
+	}
+
+	d.SetFont("Helvetica", "B", 8)
+	d.text(widths[0]+widths[1]+widths[2]+widths[3], 6, "Total", "1", "R")
+	d.text(widths[4], 6, domain.FormatMinutes(inv.TotalMinutes), "1", "C")
+	d.text(widths[5], 6, "", "1", "C")
+	d.text(widths[6], 6, inv.Total.String(), "1", "R")
+	d.Ln(-1)
+
+	if inv.Status != domain.InvoiceIssued {
+		d.Ln(6)
+		d.SetFont("Helvetica", "", 7)
+		d.MultiCell(0, 3.5, d.tr("Documento sem valor fiscal: rascunho gerado para conferência antes da emissão."), "", "L", false)
+	}
+
+	return d.bytes()
+}
@@ -0,0 +1,47 @@
+// Package pdf renders the documents the application hands out as PDF files.
+package pdf
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// document wraps fpdf with a translator so UTF-8 text renders with the core fonts
+type document struct {
+	*fpdf.Fpdf
+	tr func(string) string
+}
+
+func newDocument(orientation, size string) *document {
+	f := fpdf.New(orientation, "mm", size, "")
+	f.SetAutoPageBreak(true, 15)
+	return &document{Fpdf: f, tr: f.UnicodeTranslatorFromDescriptor("")}
+}
+
+// text writes a single line cell
+func (d *document) text(w, h float64, s string, border string, align string) {
+	d.CellFormat(w, h, d.tr(s), border, 0, align, false, 0, "")
+}
+
+// line writes a full width line and moves to the next one
+func (d *document) line(h float64, s string) {
+	d.CellFormat(0, h, d.tr(s), "", 1, "L", false, 0, "")
+}
+
+// fixDates pins the document dates and the catalog order so the same content
+// always renders to the same bytes, which lets a signature refer to its hash
+func (d *document) fixDates(t time.Time) {
+	d.SetCreationDate(t)
+	d.SetModificationDate(t)
+	d.SetCatalogSort(true)
+}
+
+func (d *document) bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := d.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,43 @@
+package pdf
+
+import (
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+// Receipt renders the comprovante de registro de ponto of a punch
+func Receipt(r domain.PunchReceipt) ([]byte, error) {
+	d := newDocument("P", "A6")
+	d.AddPage()
+
+	d.SetFont("Helvetica", "B", 11)
+	d.line(6, "Comprovante de Registro de Ponto do Trabalhador")
+	d.Ln(2)
+
+	d.SetFont("Helvetica", "", 9)
+	d.line(5, r.OrganizationName)
+	if r.OrganizationAddress != "" {
+		d.MultiCell(0, 4, d.tr(r.OrganizationAddress), "", "L", false)
+	}
+	d.Ln(3)
+
+	rows := [][2]string{
+		{"NSR", domain.FormatNSR(r.NSR)},
+		{"Trabalhador", r.EmployeeName},
+		{"Registro", r.TypeID.Label()},
+		{"Data", r.Timestamp.Format("02/01/2006")},
+		{"Hora", r.Timestamp.Format("15:04:05")},
+		{"Código de verificação", r.VerificationCode},
+	}
+	for _, row := range rows {
+		d.SetFont("Helvetica", "B", 9)
+		d.text(38, 5, row[0], "", "L")
+		d.SetFont("Helvetica", "", 9)
+		d.line(5, row[1])
+	}
+
+	d.Ln(4)
+	d.SetFont("Helvetica", "", 7)
+	d.MultiCell(0, 3.5, d.tr("O código de verificação corresponde ao início do hash do registro na cadeia de integridade da organização."), "", "L", false)
+
+	return d.bytes()
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+type AuditRepository struct {
+	DB *pgxpool.Pool
+}
+
+func NewAuditRepository(db *pgxpool.Pool) *AuditRepository {
+	return &AuditRepository{db}
+}
+
+// Insert appends an entry to the audit log, joining the context transaction if
+// there is one. The actor name is copied so the entry outlives the user.
+func (r *AuditRepository) Insert(ctx context.Context, entry domain.AuditLog) (domain.DBResponse, error) {
+	const query = `
+		INSERT INTO audit_logs (organization_id, actor_id, actor_name, action, target_type, target_id, before, after, ip, user_agent)
+		VALUES (
+			@orgID, @actorID, COALESCE((SELECT name FROM users WHERE id = @actorID), ''),
+			@action, @targetType, @targetID, @before, @after, @ip, @userAgent
+		)
+		RETURNING id
+	`
+	args := pgx.StrictNamedArgs{
+		"orgID":      entry.OrganizationID,
+		"actorID":    entry.ActorID,
+		"action":     string(entry.Action),
+		"targetType": entry.TargetType,
+		"targetID":   entry.TargetID,
+		"before":     nullableJSON(entry.Before),
+		"after":      nullableJSON(entry.After),
+		"ip":         entry.IP,
+		"userAgent":  entry.UserAgent,
+	}
+
+	var id int64
+	err := conn(ctx, r.DB).QueryRow(ctx, query, args).Scan(&id)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao registrar auditoria"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: id}, nil
+}
+
+// List retrieves the organization audit log, newest first
+func (r *AuditRepository) List(ctx context.Context, orgID uuid.UUID, filter domain.AuditFilter, page domain.PageRequest) (domain.DBResponse, error) {
+	const query = `
+		SELECT
+			a.id,
+			a.organization_id,
+			a.actor_id,
+			a.actor_name,
+			a.action,
+			a.target_type,
+			a.target_id,
+			a.before,
+			a.after,
+			a.ip,
+			a.user_agent,
+			a.created_at
+		FROM audit_logs a
+		WHERE a.organization_id = @orgID
+			AND (@actorID::uuid IS NULL OR a.actor_id = @actorID::uuid)
+			AND (@action::text = '' OR a.action = @action::text)
+			AND (@start::timestamptz IS NULL OR a.created_at >= @start::timestamptz)
+			AND (@end::timestamptz IS NULL OR a.created_at < @end::timestamptz)
+			AND (@cursorKey::timestamptz IS NULL OR (a.created_at, a.id) < (@cursorKey::timestamptz, @cursorID::bigint))
+		ORDER BY a.created_at DESC, a.id DESC
+		LIMIT NULLIF(@limit::int, 0)
+	`
+	args := withPage(pgx.StrictNamedArgs{
+		"orgID":   orgID,
+		"actorID": filter.ActorID,
+		"action":  string(filter.Action),
+		"start":   filter.Start,
+		"end":     filter.End,
+	}, page)
+
+	rows, err := r.DB.Query(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar auditoria"}, err
+	}
+	defer rows.Close()
+
+	logs := []domain.AuditLog{}
+	for rows.Next() {
+		var entry domain.AuditLog
+		var action string
+		var before, after []byte
+		err := rows.Scan(
+			&entry.ID,
+			&entry.OrganizationID,
+			&entry.ActorID,
+			&entry.ActorName,
+			&action,
+			&entry.TargetType,
+			&entry.TargetID,
+			&before,
+			&after,
+			&entry.IP,
+			&entry.UserAgent,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return domain.DBResponse{Message: "erro ao ler auditoria"}, err
+		}
+		entry.Action = domain.AuditAction(action)
+		entry.Before = before
+		entry.After = after
+		logs = append(logs, entry)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar auditoria"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: domain.NewCursorPage(logs, page, func(l domain.AuditLog) domain.Cursor {
+		return domain.Cursor{Key: l.CreatedAt.Format(time.RFC3339Nano), ID: strconv.FormatInt(l.ID, 10)}
+	})}, nil
+}
+
+func nullableJSON(raw []byte) any {
+	if len(raw) == 0 {
+		return nil
+	}
+	return string(raw)
+}
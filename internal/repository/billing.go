@@ -0,0 +1,490 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+type BillingRepository struct {
+	DB *pgxpool.Pool
+}
+
+func NewBillingRepository(db *pgxpool.Pool) *BillingRepository {
+	return &BillingRepository{db}
+}
+
+const clientColumns = `
+	id,
+	organization_id,
+	name,
+	document,
+	email,
+	rounding_minutes,
+	rounding_mode,
+	created_at
+`
+
+// CreateClient stores a client of the organization. It joins the context
+// transaction if there is one.
+func (r *BillingRepository) CreateClient(ctx context.Context, c domain.Client) (domain.DBResponse, error) {
+	query := `
+		INSERT INTO clients (organization_id, name, document, email, rounding_minutes, rounding_mode)
+		VALUES (@orgID, @name, @document, @email, @roundingMinutes, @roundingMode)
+		RETURNING` + clientColumns
+
+	args := pgx.StrictNamedArgs{
+		"orgID":           c.OrganizationID,
+		"name":            c.Name,
+		"document":        c.Document,
+		"email":           c.Email,
+		"roundingMinutes": c.RoundingMinutes,
+		"roundingMode":    c.RoundingMode,
+	}
+
+	saved, err := scanClient(conn(ctx, r.DB).QueryRow(ctx, query, args))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.DBResponse{Success: false, Message: "já existe um cliente com este nome"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao criar cliente"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: saved}, nil
+}
+
+// UpdateClient saves the changes of a client. It joins the context
+// transaction if there is one.
+func (r *BillingRepository) UpdateClient(ctx context.Context, c domain.Client) (domain.DBResponse, error) {
+	query := `
+		UPDATE clients
+		SET name = @name, document = @document, email = @email, rounding_minutes = @roundingMinutes, rounding_mode = @roundingMode
+		WHERE id = @id AND organization_id = @orgID
+		RETURNING` + clientColumns
+
+	args := pgx.StrictNamedArgs{
+		"id":              c.ID,
+		"orgID":           c.OrganizationID,
+		"name":            c.Name,
+		"document":        c.Document,
+		"email":           c.Email,
+		"roundingMinutes": c.RoundingMinutes,
+		"roundingMode":    c.RoundingMode,
+	}
+
+	saved, err := scanClient(conn(ctx, r.DB).QueryRow(ctx, query, args))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "cliente não encontrado"}, nil
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.DBResponse{Success: false, Message: "já existe um cliente com este nome"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao atualizar cliente"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: saved}, nil
+}
+
+// GetClient retrieves a client of the organization
+func (r *BillingRepository) GetClient(ctx context.Context, orgID, clientID uuid.UUID) (domain.DBResponse, error) {
+	query := `SELECT` + clientColumns + `FROM clients WHERE id = @id AND organization_id = @orgID`
+
+	c, err := scanClient(conn(ctx, r.DB).QueryRow(ctx, query, pgx.StrictNamedArgs{"id": clientID, "orgID": orgID}))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "cliente não encontrado"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao buscar cliente"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: c}, nil
+}
+
+// ListClients retrieves the organization clients ordered by name
+func (r *BillingRepository) ListClients(ctx context.Context, orgID uuid.UUID) (domain.DBResponse, error) {
+	query := `SELECT` + clientColumns + `FROM clients WHERE organization_id = @orgID ORDER BY name, id`
+
+	rows, err := conn(ctx, r.DB).Query(ctx, query, pgx.StrictNamedArgs{"orgID": orgID})
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar clientes"}, err
+	}
+	defer rows.Close()
+
+	clients := []domain.Client{}
+	for rows.Next() {
+		c, err := scanClient(rows)
+		if err != nil {
+			return domain.DBResponse{Message: "erro ao ler cliente"}, err
+		}
+		clients = append(clients, c)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar clientes"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: clients}, nil
+}
+
+const memberRateQuery = `
+	SELECT u.id, u.name, u.email, COALESCE(mr.hourly_rate_cents, 0), mr.updated_at
+	FROM organization_users ou
+	JOIN users u ON u.id = ou.user_id
+	LEFT JOIN member_billing_rates mr ON mr.organization_id = ou.organization_id AND mr.user_id = ou.user_id
+	WHERE ou.organization_id = @orgID AND (@userID::uuid IS NULL OR ou.user_id = @userID::uuid)
+	ORDER BY u.name, u.id
+`
+
+// ListMemberRates retrieves every member of the organization with the
+// configured hourly rate, zero for members without one
+func (r *BillingRepository) ListMemberRates(ctx context.Context, orgID uuid.UUID) (domain.DBResponse, error) {
+	rates, err := r.memberRates(ctx, orgID, nil)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar valores/hora dos membros"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: rates}, nil
+}
+
+// GetMemberRate retrieves the hourly rate of a member of the organization.
+// It joins the context transaction if there is one.
+func (r *BillingRepository) GetMemberRate(ctx context.Context, orgID, userID uuid.UUID) (domain.DBResponse, error) {
+	rates, err := r.memberRates(ctx, orgID, &userID)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar valor/hora do membro"}, err
+	}
+
+	if len(rates) == 0 {
+		return domain.DBResponse{Success: false, Message: "usuário não é membro desta organização"}, nil
+	}
+
+	return domain.DBResponse{Success: true, Data: rates[0]}, nil
+}
+
+func (r *BillingRepository) memberRates(ctx context.Context, orgID uuid.UUID, userID *uuid.UUID) ([]domain.MemberRate, error) {
+	rows, err := conn(ctx, r.DB).Query(ctx, memberRateQuery, pgx.StrictNamedArgs{"orgID": orgID, "userID": userID})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rates := []domain.MemberRate{}
+	for rows.Next() {
+		var m domain.MemberRate
+		if err := rows.Scan(&m.UserID, &m.Name, &m.Email, &m.HourlyRate, &m.UpdatedAt); err != nil {
+			return nil, err
+		}
+		rates = append(rates, m)
+	}
+
+	return rates, rows.Err()
+}
+
+// SetMemberRate stores the hourly rate of a member, removing it when the rate
+// is zero. It joins the context transaction if there is one.
+func (r *BillingRepository) SetMemberRate(ctx context.Context, orgID, userID uuid.UUID, rate domain.Cents) error {
+	args := pgx.StrictNamedArgs{"orgID": orgID, "userID": userID}
+	if rate == 0 {
+		_, err := conn(ctx, r.DB).Exec(ctx, `DELETE FROM member_billing_rates WHERE organization_id = @orgID AND user_id = @userID`, args)
+		return err
+	}
+
+	const query = `
+		INSERT INTO member_billing_rates (organization_id, user_id, hourly_rate_cents)
+		VALUES (@orgID, @userID, @rate)
+		ON CONFLICT (organization_id, user_id)
+		DO UPDATE SET hourly_rate_cents = EXCLUDED.hourly_rate_cents, updated_at = NOW()
+	`
+	args["rate"] = rate
+
+	_, err := conn(ctx, r.DB).Exec(ctx, query, args)
+	return err
+}
+
+// BillableHours sums the billable allocations to projects of the client
+// between start and end (inclusive) by member and project, with the rates
+// that apply. Reproved timesheets are not billed.
+func (r *BillingRepository) BillableHours(ctx context.Context, orgID, clientID uuid.UUID, start, end time.Time) (domain.DBResponse, error) {
+	const query = `
+		SELECT p.name, u.name, SUM(a.minutes)::bigint, p.hourly_rate_cents, mr.hourly_rate_cents
+		FROM time_allocations a
+		JOIN daily_timesheets dt ON dt.id = a.timesheet_id
+		JOIN projects p ON p.id = a.project_id
+		JOIN users u ON u.id = a.user_id
+		LEFT JOIN member_billing_rates mr ON mr.organization_id = a.organization_id AND mr.user_id = a.user_id
+		WHERE a.organization_id = @orgID
+			AND p.client_id = @clientID
+			AND a.billable
+			AND dt.date >= @start
+			AND dt.date <= @end
+			AND dt.status_id <> @reproved
+		GROUP BY p.id, u.id, mr.hourly_rate_cents
+		ORDER BY u.name, u.id, p.name, p.id
+	`
+	args := pgx.StrictNamedArgs{
+		"orgID":    orgID,
+		"clientID": clientID,
+		"start":    start,
+		"end":      end,
+		"reproved": domain.StatusReproved,
+	}
+
+	rows, err := conn(ctx, r.DB).Query(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao calcular horas faturáveis"}, err
+	}
+	defer rows.Close()
+
+	hours := []domain.BillableHours{}
+	for rows.Next() {
+		var h domain.BillableHours
+		if err := rows.Scan(&h.ProjectName, &h.UserName, &h.Minutes, &h.ProjectRate, &h.MemberRate); err != nil {
+			return domain.DBResponse{Message: "erro ao ler horas faturáveis"}, err
+		}
+		hours = append(hours, h)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar horas faturáveis"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: hours}, nil
+}
+
+const invoiceColumns = `
+	id,
+	organization_id,
+	client_id,
+	number,
+	status,
+	organization_name,
+	client_name,
+	client_document,
+	period_start,
+	period_end,
+	rounding_minutes,
+	rounding_mode,
+	total_minutes,
+	total_cents,
+	created_by,
+	created_at,
+	issued_at
+`
+
+// CreateInvoice stores a draft invoice with its lines. It must run inside a
+// transaction so the lines are stored with the invoice.
+func (r *BillingRepository) CreateInvoice(ctx context.Context, inv domain.Invoice) (domain.DBResponse, error) {
+	query := `
+		INSERT INTO invoices (
+			organization_id, client_id, organization_name, client_name, client_document, period_start, period_end,
+			rounding_minutes, rounding_mode, total_minutes, total_cents, created_by
+		)
+		VALUES (
+			@orgID, @clientID, @orgName, @clientName, @clientDocument, @start, @end,
+			@roundingMinutes, @roundingMode, @totalMinutes, @total, @createdBy
+		)
+		RETURNING` + invoiceColumns
+
+	args := pgx.StrictNamedArgs{
+		"orgID":           inv.OrganizationID,
+		"clientID":        inv.ClientID,
+		"orgName":         inv.OrganizationName,
+		"clientName":      inv.ClientName,
+		"clientDocument":  inv.ClientDocument,
+		"start":           inv.PeriodStart,
+		"end":             inv.PeriodEnd,
+		"roundingMinutes": inv.RoundingMinutes,
+		"roundingMode":    inv.RoundingMode,
+		"totalMinutes":    inv.TotalMinutes,
+		"total":           inv.Total,
+		"createdBy":       inv.CreatedBy,
+	}
+
+	saved, err := scanInvoice(conn(ctx, r.DB).QueryRow(ctx, query, args))
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao gerar fatura"}, err
+	}
+
+	const lineQuery = `
+		INSERT INTO invoice_lines (invoice_id, position, user_name, project_name, minutes, billed_minutes, hourly_rate_cents, amount_cents)
+		VALUES (@invoiceID, @position, @userName, @projectName, @minutes, @billedMinutes, @hourlyRate, @amount)
+	`
+	for _, line := range inv.Lines {
+		lineArgs := pgx.StrictNamedArgs{
+			"invoiceID":     saved.ID,
+			"position":      line.Position,
+			"userName":      line.UserName,
+			"projectName":   line.ProjectName,
+			"minutes":       line.Minutes,
+			"billedMinutes": line.BilledMinutes,
+			"hourlyRate":    line.HourlyRate,
+			"amount":        line.Amount,
+		}
+		if _, err := conn(ctx, r.DB).Exec(ctx, lineQuery, lineArgs); err != nil {
+			return domain.DBResponse{Message: "erro ao salvar itens da fatura"}, err
+		}
+	}
+	saved.Lines = inv.Lines
+
+	return domain.DBResponse{Success: true, Data: saved}, nil
+}
+
+// GetInvoice retrieves an invoice of the organization with its lines. It
+// joins the context transaction if there is one.
+func (r *BillingRepository) GetInvoice(ctx context.Context, orgID, invoiceID uuid.UUID) (domain.DBResponse, error) {
+	query := `SELECT` + invoiceColumns + `FROM invoices WHERE id = @id AND organization_id = @orgID`
+
+	inv, err := scanInvoice(conn(ctx, r.DB).QueryRow(ctx, query, pgx.StrictNamedArgs{"id": invoiceID, "orgID": orgID}))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "fatura não encontrada"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao buscar fatura"}, err
+	}
+
+	const lineQuery = `
+		SELECT position, user_name, project_name, minutes, billed_minutes, hourly_rate_cents, amount_cents
+		FROM invoice_lines
+		WHERE invoice_id = @invoiceID
+		ORDER BY position
+	`
+
+	rows, err := conn(ctx, r.DB).Query(ctx, lineQuery, pgx.StrictNamedArgs{"invoiceID": inv.ID})
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar itens da fatura"}, err
+	}
+	defer rows.Close()
+
+	inv.Lines = []domain.InvoiceLine{}
+	for rows.Next() {
+		var l domain.InvoiceLine
+		if err := rows.Scan(&l.Position, &l.UserName, &l.ProjectName, &l.Minutes, &l.BilledMinutes, &l.HourlyRate, &l.Amount); err != nil {
+			return domain.DBResponse{Message: "erro ao ler item da fatura"}, err
+		}
+		inv.Lines = append(inv.Lines, l)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar itens da fatura"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: inv}, nil
+}
+
+// ListInvoices retrieves the organization invoices without their lines,
+// newest first
+func (r *BillingRepository) ListInvoices(ctx context.Context, orgID uuid.UUID) (domain.DBResponse, error) {
+	query := `SELECT` + invoiceColumns + `FROM invoices WHERE organization_id = @orgID ORDER BY created_at DESC, id`
+
+	rows, err := conn(ctx, r.DB).Query(ctx, query, pgx.StrictNamedArgs{"orgID": orgID})
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar faturas"}, err
+	}
+	defer rows.Close()
+
+	invoices := []domain.Invoice{}
+	for rows.Next() {
+		inv, err := scanInvoice(rows)
+		if err != nil {
+			return domain.DBResponse{Message: "erro ao ler fatura"}, err
+		}
+		invoices = append(invoices, inv)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar faturas"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: invoices}, nil
+}
+
+// IssueInvoice numbers a draft with the next number of the organization and
+// makes it final. It joins the context transaction if there is one.
+func (r *BillingRepository) IssueInvoice(ctx context.Context, orgID, invoiceID uuid.UUID) (domain.DBResponse, error) {
+	query := `
+		UPDATE invoices
+		SET status = 'issued',
+			issued_at = NOW(),
+			number = (SELECT COALESCE(MAX(number), 0) + 1 FROM invoices WHERE organization_id = @orgID)
+		WHERE id = @id AND organization_id = @orgID AND status = 'draft'
+		RETURNING` + invoiceColumns
+
+	inv, err := scanInvoice(conn(ctx, r.DB).QueryRow(ctx, query, pgx.StrictNamedArgs{"id": invoiceID, "orgID": orgID}))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "apenas rascunhos podem ser emitidos"}, nil
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.DBResponse{Success: false, Message: "outra fatura foi emitida ao mesmo tempo, tente novamente"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao emitir fatura"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: inv}, nil
+}
+
+// DeleteInvoice discards a draft invoice. It joins the context transaction
+// if there is one.
+func (r *BillingRepository) DeleteInvoice(ctx context.Context, orgID, invoiceID uuid.UUID) (domain.DBResponse, error) {
+	const query = `DELETE FROM invoices WHERE id = @id AND organization_id = @orgID AND status = 'draft'`
+
+	tag, err := conn(ctx, r.DB).Exec(ctx, query, pgx.StrictNamedArgs{"id": invoiceID, "orgID": orgID})
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao descartar fatura"}, err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.DBResponse{Success: false, Message: "apenas rascunhos podem ser descartados"}, nil
+	}
+
+	return domain.DBResponse{Success: true, Message: "rascunho descartado com sucesso"}, nil
+}
+
+func scanClient(row pgx.Row) (domain.Client, error) {
+	var c domain.Client
+	err := row.Scan(
+		&c.ID,
+		&c.OrganizationID,
+		&c.Name,
+		&c.Document,
+		&c.Email,
+		&c.RoundingMinutes,
+		&c.RoundingMode,
+		&c.CreatedAt,
+	)
+	return c, err
+}
+
+func scanInvoice(row pgx.Row) (domain.Invoice, error) {
+	var inv domain.Invoice
+	err := row.Scan(
+		&inv.ID,
+		&inv.OrganizationID,
+		&inv.ClientID,
+		&inv.Number,
+		&inv.Status,
+		&inv.OrganizationName,
+		&inv.ClientName,
+		&inv.ClientDocument,
+		&inv.PeriodStart,
+		&inv.PeriodEnd,
+		&inv.RoundingMinutes,
+		&inv.RoundingMode,
+		&inv.TotalMinutes,
+		&inv.Total,
+		&inv.CreatedBy,
+		&inv.CreatedAt,
+		&inv.IssuedAt,
+	)
+	return inv, err
+}
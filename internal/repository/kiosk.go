@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+type KioskRepository struct {
+	DB *pgxpool.Pool
+}
+
+func NewKioskRepository(db *pgxpool.Pool) *KioskRepository {
+	return &KioskRepository{db}
+}
+
+const kioskDeviceColumns = `
+	id,
+	organization_id,
+	name,
+	created_at,
+	last_seen_at,
+	revoked_at
+`
+
+// Create registers a device with the hash of its token, joining the context transaction if there is one
+func (r *KioskRepository) Create(ctx context.Context, orgID uuid.UUID, name, tokenHash string) (domain.DBResponse, error) {
+	query := `
+		INSERT INTO kiosk_devices (organization_id, name, token_hash)
+		VALUES (@orgID, @name, @tokenHash)
+		RETURNING` + kioskDeviceColumns
+	args := pgx.StrictNamedArgs{
+		"orgID":     orgID,
+		"name":      name,
+		"tokenHash": tokenHash,
+	}
+
+	device, err := scanKioskDevice(conn(ctx, r.DB).QueryRow(ctx, query, args))
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao cadastrar terminal"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: device}, nil
+}
+
+// ListByOrganization retrieves a page of the organization devices, oldest first
+func (r *KioskRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID, page domain.PageRequest) (domain.DBResponse, error) {
+	query := `SELECT` + kioskDeviceColumns + `
+		FROM kiosk_devices
+		WHERE organization_id = @orgID
+			AND (@cursorKey::timestamptz IS NULL OR (created_at, id) > (@cursorKey::timestamptz, @cursorID::uuid))
+		ORDER BY created_at, id
+		LIMIT NULLIF(@limit::int, 0)
+	`
+
+	rows, err := r.DB.Query(ctx, query, withPage(pgx.StrictNamedArgs{"orgID": orgID}, page))
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar terminais"}, err
+	}
+	defer rows.Close()
+
+	devices := []domain.KioskDevice{}
+	for rows.Next() {
+		device, err := scanKioskDevice(rows)
+		if err != nil {
+			return domain.DBResponse{Message: "erro ao ler terminal"}, err
+		}
+		devices = append(devices, device)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar terminais"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: domain.NewCursorPage(devices, page, func(d domain.KioskDevice) domain.Cursor {
+		return domain.Cursor{Key: d.CreatedAt.Format(time.RFC3339Nano), ID: d.ID.String()}
+	})}, nil
+}
+
+// Revoke disables a device of the organization, joining the context transaction if there is one
+func (r *KioskRepository) Revoke(ctx context.Context, orgID, deviceID uuid.UUID) (domain.DBResponse, error) {
+	query := `
+		UPDATE kiosk_devices
+		SET revoked_at = NOW()
+		WHERE id = @id AND organization_id = @orgID AND revoked_at IS NULL
+		RETURNING` + kioskDeviceColumns
+
+	device, err := scanKioskDevice(conn(ctx, r.DB).QueryRow(ctx, query, pgx.StrictNamedArgs{"id": deviceID, "orgID": orgID}))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "terminal não encontrado"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao revogar terminal"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: device}, nil
+}
+
+// Authenticate finds the active device with the token hash and records it was seen
+func (r *KioskRepository) Authenticate(ctx context.Context, tokenHash string) (domain.DBResponse, error) {
+	query := `
+		UPDATE kiosk_devices
+		SET last_seen_at = NOW()
+		WHERE token_hash = @tokenHash AND revoked_at IS NULL
+		RETURNING` + kioskDeviceColumns
+
+	device, err := scanKioskDevice(r.DB.QueryRow(ctx, query, pgx.StrictNamedArgs{"tokenHash": tokenHash}))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "terminal não autorizado"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao autenticar terminal"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: device}, nil
+}
+
+// FindMemberByCredential returns the ID of the member whose PIN hash or badge
+// code matches. A badge match wins over a PIN match.
+func (r *KioskRepository) FindMemberByCredential(ctx context.Context, orgID uuid.UUID, pinHash, badgeCode string) (domain.DBResponse, error) {
+	const query = `
+		SELECT user_id
+		FROM organization_users
+		WHERE organization_id = @orgID
+			AND (badge_code = @badgeCode OR kiosk_pin_hash = @pinHash)
+		ORDER BY badge_code IS NOT DISTINCT FROM @badgeCode DESC
+		LIMIT 1
+	`
+	args := pgx.StrictNamedArgs{
+		"orgID":     orgID,
+		"pinHash":   pinHash,
+		"badgeCode": badgeCode,
+	}
+
+	var userID uuid.UUID
+	err := r.DB.QueryRow(ctx, query, args).Scan(&userID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "PIN ou crachá não reconhecido"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao identificar membro"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: userID}, nil
+}
+
+// SetMemberCredentials changes the member PIN hash and badge code. A nil value
+// keeps the current one and an empty string removes it. It joins the context
+// transaction if there is one.
+func (r *KioskRepository) SetMemberCredentials(ctx context.Context, orgID, userID uuid.UUID, pinHash, badgeCode *string) (domain.DBResponse, error) {
+	const query = `
+		UPDATE organization_users
+		SET kiosk_pin_hash = CASE WHEN @setPIN THEN NULLIF(@pinHash::text, '') ELSE kiosk_pin_hash END,
+			badge_code = CASE WHEN @setBadge THEN NULLIF(@badgeCode::text, '') ELSE badge_code END
+		WHERE organization_id = @orgID AND user_id = @userID
+	`
+	args := pgx.StrictNamedArgs{
+		"orgID":     orgID,
+		"userID":    userID,
+		"setPIN":    pinHash != nil,
+		"pinHash":   pinHash,
+		"setBadge":  badgeCode != nil,
+		"badgeCode": badgeCode,
+	}
+
+	tag, err := conn(ctx, r.DB).Exec(ctx, query, args)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.DBResponse{Success: false, Message: "PIN ou crachá já está em uso nesta organização"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao salvar credenciais do terminal"}, err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.DBResponse{Success: false, Message: "membro não encontrado"}, nil
+	}
+
+	return domain.DBResponse{Success: true, Message: "credenciais salvas com sucesso"}, nil
+}
+
+// ListMemberCredentials retrieves the organization members with their kiosk credentials
+func (r *KioskRepository) ListMemberCredentials(ctx context.Context, orgID uuid.UUID) (domain.DBResponse, error) {
+	const query = `
+		SELECT u.id, u.name, u.email, ou.kiosk_pin_hash IS NOT NULL, COALESCE(ou.badge_code, '')
+		FROM organization_users ou
+		JOIN users u ON u.id = ou.user_id
+		WHERE ou.organization_id = @orgID
+		ORDER BY u.name
+	`
+
+	rows, err := r.DB.Query(ctx, query, pgx.StrictNamedArgs{"orgID": orgID})
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar credenciais dos membros"}, err
+	}
+	defer rows.Close()
+
+	members := []domain.KioskMember{}
+	for rows.Next() {
+		var m domain.KioskMember
+		if err := rows.Scan(&m.UserID, &m.Name, &m.Email, &m.HasPIN, &m.BadgeCode); err != nil {
+			return domain.DBResponse{Message: "erro ao ler credenciais do membro"}, err
+		}
+		members = append(members, m)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar credenciais dos membros"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: members}, nil
+}
+
+func scanKioskDevice(row pgx.Row) (domain.KioskDevice, error) {
+	var d domain.KioskDevice
+	err := row.Scan(
+		&d.ID,
+		&d.OrganizationID,
+		&d.Name,
+		&d.CreatedAt,
+		&d.LastSeenAt,
+		&d.RevokedAt,
+	)
+	return d, err
+}
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO organization_roles(id, name) VALUES (3, 'manager');
+
+CREATE TABLE teams (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+
+  UNIQUE(organization_id, name)
+);
+
+CREATE TABLE team_members (
+  team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  joined_at TIMESTAMPTZ DEFAULT NOW(),
+
+  PRIMARY KEY(team_id, user_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE team_members;
+DROP TABLE teams;
+UPDATE organization_users SET organization_role_id = 1 WHERE organization_role_id = 3;
+DELETE FROM organization_roles WHERE id = 3;
+-- +goose StatementEnd
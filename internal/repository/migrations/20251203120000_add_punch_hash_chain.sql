@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE timesheet_entries
+  ADD COLUMN sequence BIGINT,
+  ADD COLUMN prev_hash TEXT,
+  ADD COLUMN hash TEXT,
+  ADD CONSTRAINT timesheet_entries_organization_sequence_unique UNIQUE (organization_id, sequence);
+
+-- Head of each organization's chain. Locked FOR UPDATE on every punch so
+-- sequence numbers and hashes are assigned one at a time.
+CREATE TABLE punch_chain_heads (
+  organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+  last_sequence BIGINT NOT NULL DEFAULT 0,
+  last_hash TEXT NOT NULL DEFAULT repeat('0', 64)
+);
+
+CREATE TABLE organization_signing_keys (
+  organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+  algorithm TEXT NOT NULL DEFAULT 'ed25519',
+  public_key BYTEA NOT NULL,
+  private_key BYTEA NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE organization_signing_keys;
+DROP TABLE punch_chain_heads;
+ALTER TABLE timesheet_entries
+  DROP CONSTRAINT timesheet_entries_organization_sequence_unique,
+  DROP COLUMN hash,
+  DROP COLUMN prev_hash,
+  DROP COLUMN sequence;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE punch_receipts (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  entry_id UUID NOT NULL UNIQUE REFERENCES timesheet_entries(id) ON DELETE CASCADE,
+  organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id),
+  nsr BIGINT NOT NULL,
+  employee_name TEXT NOT NULL,
+  organization_name TEXT NOT NULL,
+  organization_address TEXT NOT NULL,
+  type_id SMALLINT NOT NULL REFERENCES entry_types(id),
+  timestamp TIMESTAMPTZ NOT NULL,
+  verification_code TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (organization_id, nsr)
+);
+
+CREATE INDEX punch_receipts_user_timestamp_idx ON punch_receipts (user_id, timestamp DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE punch_receipts;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN cpf TEXT NOT NULL DEFAULT '';
+ALTER TABLE organizations ADD COLUMN cnpj TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE organizations DROP COLUMN cnpj;
+ALTER TABLE users DROP COLUMN cpf;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE work_schedules (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  code TEXT NOT NULL,
+  daily_minutes INT NOT NULL CHECK (daily_minutes > 0),
+  entry1 TEXT NOT NULL,
+  exit1 TEXT NOT NULL,
+  entry2 TEXT NOT NULL DEFAULT '',
+  exit2 TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (organization_id, code)
+);
+
+ALTER TABLE organization_users
+  ADD COLUMN work_schedule_id UUID REFERENCES work_schedules(id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE organization_users DROP COLUMN work_schedule_id;
+DROP TABLE work_schedules;
+-- +goose StatementEnd
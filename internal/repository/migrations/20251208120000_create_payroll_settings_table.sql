@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE payroll_settings (
+  organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+  format TEXT NOT NULL,
+  company_code TEXT NOT NULL DEFAULT '',
+  regular_code TEXT NOT NULL DEFAULT '',
+  overtime_code TEXT NOT NULL DEFAULT '',
+  night_code TEXT NOT NULL DEFAULT '',
+  absence_code TEXT NOT NULL DEFAULT '',
+  csv_columns TEXT[] NOT NULL,
+  csv_delimiter TEXT NOT NULL DEFAULT ';',
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE payroll_settings;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX timesheet_entries_timesheet_timestamp_idx ON timesheet_entries (timesheet_id, timestamp);
+CREATE INDEX daily_timesheets_organization_date_idx ON daily_timesheets (organization_id, date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX daily_timesheets_organization_date_idx;
+DROP INDEX timesheet_entries_timesheet_timestamp_idx;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE kiosk_devices (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  token_hash TEXT NOT NULL UNIQUE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  last_seen_at TIMESTAMPTZ,
+  revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX kiosk_devices_organization_created_at_idx ON kiosk_devices (organization_id, created_at);
+
+ALTER TABLE organization_users
+  ADD COLUMN kiosk_pin_hash TEXT,
+  ADD COLUMN badge_code TEXT;
+
+CREATE UNIQUE INDEX organization_users_organization_kiosk_pin_hash_idx ON organization_users (organization_id, kiosk_pin_hash);
+CREATE UNIQUE INDEX organization_users_organization_badge_code_idx ON organization_users (organization_id, badge_code);
+
+ALTER TABLE timesheet_entries ADD COLUMN kiosk_device_id UUID REFERENCES kiosk_devices(id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE timesheet_entries DROP COLUMN kiosk_device_id;
+DROP INDEX organization_users_organization_badge_code_idx;
+DROP INDEX organization_users_organization_kiosk_pin_hash_idx;
+ALTER TABLE organization_users
+  DROP COLUMN badge_code,
+  DROP COLUMN kiosk_pin_hash;
+DROP TABLE kiosk_devices;
+-- +goose StatementEnd
@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE addresses
+  ADD COLUMN latitude DOUBLE PRECISION,
+  ADD COLUMN longitude DOUBLE PRECISION;
+
+CREATE TABLE geofences (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  kind TEXT NOT NULL CHECK (kind IN ('circle', 'polygon')),
+  center_latitude DOUBLE PRECISION,
+  center_longitude DOUBLE PRECISION,
+  radius_meters DOUBLE PRECISION,
+  polygon JSONB,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CHECK (
+    (kind = 'circle' AND center_latitude IS NOT NULL AND center_longitude IS NOT NULL AND radius_meters > 0)
+    OR (kind = 'polygon' AND polygon IS NOT NULL)
+  )
+);
+
+CREATE INDEX geofences_organization_created_at_idx ON geofences (organization_id, created_at);
+
+ALTER TABLE punch_policies
+  ADD COLUMN geofence_mode TEXT NOT NULL DEFAULT 'record' CHECK (geofence_mode IN ('record', 'warn', 'enforce'));
+
+ALTER TABLE timesheet_entries
+  ADD COLUMN latitude DOUBLE PRECISION,
+  ADD COLUMN longitude DOUBLE PRECISION,
+  ADD COLUMN accuracy_meters DOUBLE PRECISION,
+  ADD COLUMN geofence_id UUID REFERENCES geofences(id) ON DELETE SET NULL,
+  ADD COLUMN outside_geofence BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX timesheet_entries_organization_outside_geofence_idx ON timesheet_entries (organization_id, timestamp DESC, id DESC) WHERE outside_geofence;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX timesheet_entries_organization_outside_geofence_idx;
+ALTER TABLE timesheet_entries
+  DROP COLUMN outside_geofence,
+  DROP COLUMN geofence_id,
+  DROP COLUMN accuracy_meters,
+  DROP COLUMN longitude,
+  DROP COLUMN latitude;
+ALTER TABLE punch_policies DROP COLUMN geofence_mode;
+DROP TABLE geofences;
+ALTER TABLE addresses
+  DROP COLUMN longitude,
+  DROP COLUMN latitude;
+-- +goose StatementEnd
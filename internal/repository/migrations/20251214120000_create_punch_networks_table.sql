@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE punch_networks (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  cidr TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (organization_id, cidr)
+);
+
+ALTER TABLE punch_policies
+  ADD COLUMN network_mode TEXT NOT NULL DEFAULT 'flag' CHECK (network_mode IN ('flag', 'block'));
+
+ALTER TABLE timesheet_entries
+  ADD COLUMN client_ip TEXT NOT NULL DEFAULT '',
+  ADD COLUMN outside_network BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX timesheet_entries_organization_outside_network_idx ON timesheet_entries (organization_id, timestamp DESC, id DESC) WHERE outside_network;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX timesheet_entries_organization_outside_network_idx;
+ALTER TABLE timesheet_entries
+  DROP COLUMN outside_network,
+  DROP COLUMN client_ip;
+ALTER TABLE punch_policies DROP COLUMN network_mode;
+DROP TABLE punch_networks;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE timesheet_entries
+  ADD COLUMN offline_captured BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD COLUMN client_punch_id UUID,
+  ADD COLUMN synced_at TIMESTAMPTZ;
+
+CREATE UNIQUE INDEX timesheet_entries_client_punch_id_idx ON timesheet_entries (client_punch_id) WHERE client_punch_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX timesheet_entries_client_punch_id_idx;
+ALTER TABLE timesheet_entries
+  DROP COLUMN synced_at,
+  DROP COLUMN client_punch_id,
+  DROP COLUMN offline_captured;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO entry_types VALUES (3, 'break_start'), (4, 'break_end');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Breaks become the exit and entry they were recorded as before break types
+-- existed. Their chain hashes were computed with the break types, so the punch
+-- chain of organizations with breaks no longer verifies after this rollback.
+UPDATE timesheet_entries SET type_id = CASE type_id WHEN 3 THEN 2 ELSE 1 END WHERE type_id IN (3, 4);
+UPDATE punch_receipts SET type_id = CASE type_id WHEN 3 THEN 2 ELSE 1 END WHERE type_id IN (3, 4);
+DELETE FROM entry_types WHERE id IN (3, 4);
+-- +goose StatementEnd
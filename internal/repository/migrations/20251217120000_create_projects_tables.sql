@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE projects (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  client_name TEXT NOT NULL DEFAULT '',
+  billable BOOLEAN NOT NULL DEFAULT FALSE,
+  hourly_rate_cents BIGINT NOT NULL DEFAULT 0 CHECK (hourly_rate_cents >= 0),
+  archived BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (organization_id, name)
+);
+
+CREATE TABLE project_tasks (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (project_id, name)
+);
+
+CREATE TABLE time_allocations (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  timesheet_id UUID NOT NULL REFERENCES daily_timesheets(id) ON DELETE CASCADE,
+  project_id UUID NOT NULL REFERENCES projects(id),
+  task_id UUID REFERENCES project_tasks(id) ON DELETE SET NULL,
+  starts_at TIMESTAMPTZ,
+  ends_at TIMESTAMPTZ,
+  minutes INT NOT NULL CHECK (minutes > 0),
+  billable BOOLEAN NOT NULL,
+  note TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CHECK ((starts_at IS NULL) = (ends_at IS NULL))
+);
+
+CREATE INDEX time_allocations_timesheet_id_idx ON time_allocations (timesheet_id);
+CREATE INDEX time_allocations_project_id_idx ON time_allocations (project_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE time_allocations;
+DROP TABLE project_tasks;
+DROP TABLE projects;
+-- +goose StatementEnd
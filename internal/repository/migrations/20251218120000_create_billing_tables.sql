@@ -0,0 +1,86 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE clients (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  document TEXT NOT NULL DEFAULT '',
+  email TEXT NOT NULL DEFAULT '',
+  rounding_minutes INT NOT NULL DEFAULT 0 CHECK (rounding_minutes IN (0, 5, 6, 10, 15, 30, 60)),
+  rounding_mode TEXT NOT NULL DEFAULT 'nearest' CHECK (rounding_mode IN ('up', 'nearest', 'down')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (organization_id, name),
+  UNIQUE (organization_id, id)
+);
+
+-- Projects now point to a client of the organization instead of naming it
+INSERT INTO clients (organization_id, name)
+SELECT DISTINCT organization_id, client_name FROM projects WHERE client_name <> '';
+
+ALTER TABLE projects ADD COLUMN client_id UUID;
+UPDATE projects p SET client_id = c.id
+FROM clients c
+WHERE c.organization_id = p.organization_id AND c.name = p.client_name;
+ALTER TABLE projects DROP COLUMN client_name;
+ALTER TABLE projects ADD FOREIGN KEY (organization_id, client_id) REFERENCES clients (organization_id, id);
+
+CREATE TABLE member_billing_rates (
+  organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  hourly_rate_cents BIGINT NOT NULL CHECK (hourly_rate_cents > 0),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (organization_id, user_id)
+);
+
+-- Invoices keep a copy of everything they show, so corrections made to the
+-- timesheets, rates or client after generation do not change them
+CREATE TABLE invoices (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  client_id UUID NOT NULL,
+  number INT,
+  status TEXT NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'issued')),
+  organization_name TEXT NOT NULL,
+  client_name TEXT NOT NULL,
+  client_document TEXT NOT NULL,
+  period_start DATE NOT NULL,
+  period_end DATE NOT NULL,
+  rounding_minutes INT NOT NULL,
+  rounding_mode TEXT NOT NULL,
+  total_minutes BIGINT NOT NULL,
+  total_cents BIGINT NOT NULL,
+  created_by UUID NOT NULL REFERENCES users(id),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  issued_at TIMESTAMPTZ,
+  FOREIGN KEY (organization_id, client_id) REFERENCES clients (organization_id, id),
+  UNIQUE (organization_id, number),
+  CHECK ((status = 'issued') = (number IS NOT NULL AND issued_at IS NOT NULL))
+);
+
+CREATE TABLE invoice_lines (
+  invoice_id UUID NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+  position INT NOT NULL,
+  user_name TEXT NOT NULL,
+  project_name TEXT NOT NULL,
+  minutes BIGINT NOT NULL,
+  billed_minutes BIGINT NOT NULL,
+  hourly_rate_cents BIGINT NOT NULL,
+  amount_cents BIGINT NOT NULL,
+  PRIMARY KEY (invoice_id, position)
+);
+
+CREATE INDEX invoices_organization_id_idx ON invoices (organization_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE invoice_lines;
+DROP TABLE invoices;
+DROP TABLE member_billing_rates;
+
+ALTER TABLE projects ADD COLUMN client_name TEXT NOT NULL DEFAULT '';
+UPDATE projects p SET client_name = c.name FROM clients c WHERE c.id = p.client_id;
+ALTER TABLE projects DROP COLUMN client_id;
+
+DROP TABLE clients;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A pay period is a month of the organization payroll. While closed, the
+-- timesheets of its days can not change; reopening keeps who did it and why.
+CREATE TABLE pay_periods (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  month DATE NOT NULL CHECK (EXTRACT(DAY FROM month) = 1),
+  closed BOOLEAN NOT NULL DEFAULT TRUE,
+  closed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+  closed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  reopened_by UUID REFERENCES users(id) ON DELETE SET NULL,
+  reopened_at TIMESTAMPTZ,
+  reopen_reason TEXT NOT NULL DEFAULT '',
+  UNIQUE (organization_id, month),
+  CHECK (closed OR reopened_at IS NOT NULL)
+);
+
+-- Totals of each member when the period was last closed, kept as a copy so
+-- later changes to members or schedules do not alter them
+CREATE TABLE pay_period_totals (
+  pay_period_id UUID NOT NULL REFERENCES pay_periods(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL,
+  name TEXT NOT NULL,
+  cpf TEXT NOT NULL DEFAULT '',
+  regular_minutes BIGINT NOT NULL,
+  overtime_minutes BIGINT NOT NULL,
+  night_minutes BIGINT NOT NULL,
+  absence_days BIGINT NOT NULL,
+  PRIMARY KEY (pay_period_id, user_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE pay_period_totals;
+DROP TABLE pay_periods;
+-- +goose StatementEnd
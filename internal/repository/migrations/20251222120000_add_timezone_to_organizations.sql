@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- IANA zone the organization works in. Punches are stored as instants; the
+-- zone tells their local time, e.g. whether they fall in the night period.
+ALTER TABLE organizations ADD COLUMN timezone TEXT NOT NULL DEFAULT 'America/Sao_Paulo';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE organizations DROP COLUMN timezone;
+-- +goose StatementEnd
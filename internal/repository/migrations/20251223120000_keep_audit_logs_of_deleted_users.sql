@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Audit entries outlive their actors: the actor name is stored with the entry
+-- and deleting the user only clears actor_id, the one update the append-only
+-- trigger lets through.
+ALTER TABLE audit_logs ADD COLUMN actor_name TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE audit_logs DISABLE TRIGGER audit_logs_no_update_delete;
+UPDATE audit_logs a SET actor_name = u.name FROM users u WHERE u.id = a.actor_id;
+ALTER TABLE audit_logs ENABLE TRIGGER audit_logs_no_update_delete;
+
+ALTER TABLE audit_logs
+  ALTER COLUMN actor_id DROP NOT NULL,
+  DROP CONSTRAINT audit_logs_actor_id_fkey,
+  ADD CONSTRAINT audit_logs_actor_id_fkey FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+BEGIN
+  IF TG_OP = 'UPDATE' AND NEW.actor_id IS NULL
+    AND to_jsonb(NEW) - 'actor_id' = to_jsonb(OLD) - 'actor_id' THEN
+    RETURN NEW;
+  END IF;
+  RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Fails while there are entries of deleted users, which can not point back to them
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE audit_logs
+  DROP CONSTRAINT audit_logs_actor_id_fkey,
+  ADD CONSTRAINT audit_logs_actor_id_fkey FOREIGN KEY (actor_id) REFERENCES users(id),
+  ALTER COLUMN actor_id SET NOT NULL;
+
+ALTER TABLE audit_logs DROP COLUMN actor_name;
+-- +goose StatementEnd
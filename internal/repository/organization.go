@@ -4,6 +4,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -86,6 +87,7 @@ func (r *OrganizationRepository) GetOrganizationByUserID(ctx context.Context, us
 		SELECT
 			o.id,
 			o.name,
+			o.cnpj,
 			o.created_by,
 			o.created_at,
 			a.id,
@@ -94,7 +96,9 @@ func (r *OrganizationRepository) GetOrganizationByUserID(ctx context.Context, us
 			a.complement,
 			a.public_place,
 			a.city,
-			a.state
+			a.state,
+			a.latitude,
+			a.longitude
 		FROM
 			organizations o
 		JOIN
@@ -113,10 +117,11 @@ func (r *OrganizationRepository) GetOrganizationByUserID(ctx context.Context, us
 	var addr domain.Address
 	var addrID, addrOrgID *uuid.UUID
 	var zipCode, complement, publicPlace, city, state *string
+	var latitude, longitude *float64
 
 	err := r.DB.QueryRow(ctx, query, args).Scan(
-		&org.ID, &org.Name, &org.CreatedBy, &org.CreatedAt,
-		&addrID, &addrOrgID, &zipCode, &complement, &publicPlace, &city, &state,
+		&org.ID, &org.Name, &org.CNPJ, &org.CreatedBy, &org.CreatedAt, &org.Timezone,
+		&addrID, &addrOrgID, &zipCode, &complement, &publicPlace, &city, &state, &latitude, &longitude,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -143,6 +148,8 @@ func (r *OrganizationRepository) GetOrganizationByUserID(ctx context.Context, us
 		if state != nil {
 			addr.State = *state
 		}
+		addr.Latitude = latitude
+		addr.Longitude = longitude
 		org.Address = &addr
 	}
 
@@ -154,15 +161,19 @@ func (r *OrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (dom
 		SELECT
 			o.id,
 			o.name,
+			o.cnpj,
 			o.created_by,
 			o.created_at,
+			o.timezone,
 			a.id,
 			a.organization_id,
 			a.zip_code,
 			a.complement,
 			a.public_place,
 			a.city,
-			a.state
+			a.state,
+			a.latitude,
+			a.longitude
 		FROM
 			organizations o
 		LEFT JOIN
@@ -178,10 +189,11 @@ func (r *OrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (dom
 	var addr domain.Address
 	var addrID, addrOrgID *uuid.UUID
 	var zipCode, complement, publicPlace, city, state *string
+	var latitude, longitude *float64
 
 	err := r.DB.QueryRow(ctx, query, args).Scan(
-		&org.ID, &org.Name, &org.CreatedBy, &org.CreatedAt,
-		&addrID, &addrOrgID, &zipCode, &complement, &publicPlace, &city, &state,
+		&org.ID, &org.Name, &org.CNPJ, &org.CreatedBy, &org.CreatedAt,
+		&addrID, &addrOrgID, &zipCode, &complement, &publicPlace, &city, &state, &latitude, &longitude,
 	)
 
 	if err != nil {
@@ -209,22 +221,27 @@ func (r *OrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (dom
 		if state != nil {
 			addr.State = *state
 		}
+		addr.Latitude = latitude
+		addr.Longitude = longitude
 		org.Address = &addr
 	}
 
 	return domain.DBResponse{Success: true, Data: org}, nil
 }
 
-func (r *OrganizationRepository) Update(ctx context.Context, orgID uuid.UUID, uo domain.UpdateOrganization) (domain.DBResponse, error) {
-	err := pgx.BeginFunc(ctx, r.DB, func(tx pgx.Tx) error {
+// Update changes the organization and its address. coordinates is the
+// address position, nil when it was not informed.
+func (r *OrganizationRepository) Update(ctx context.Context, orgID uuid.UUID, uo domain.UpdateOrganization, coordinates *domain.GeoPoint) (domain.DBResponse, error) {
+	err := pgx.BeginFunc(ctx, conn(ctx, r.DB), func(tx pgx.Tx) error {
 		const updateOrgQuery = `
 			UPDATE organizations
-			SET name = @name
+			SET name = @name, cnpj = @cnpj
 			WHERE id = @id
 		`
 		args := pgx.StrictNamedArgs{
 			"id":   orgID,
 			"name": uo.Name,
+			"cnpj": uo.CNPJ,
 		}
 
 		_, err := tx.Exec(ctx, updateOrgQuery, args)
@@ -234,16 +251,22 @@ func (r *OrganizationRepository) Update(ctx context.Context, orgID uuid.UUID, uo
 
 		// Upsert address
 		const upsertAddressQuery = `
-			INSERT INTO addresses (organization_id, zip_code, complement, public_place, city, state)
-			VALUES (@orgID, @zipCode, @complement, @publicPlace, @city, @state)
+			INSERT INTO addresses (organization_id, zip_code, complement, public_place, city, state, latitude, longitude)
+			VALUES (@orgID, @zipCode, @complement, @publicPlace, @city, @state, @latitude, @longitude)
 			ON CONFLICT (organization_id) DO UPDATE
 			SET
 				zip_code = EXCLUDED.zip_code,
 				complement = EXCLUDED.complement,
 				public_place = EXCLUDED.public_place,
 				city = EXCLUDED.city,
-				state = EXCLUDED.state
+				state = EXCLUDED.state,
+				latitude = EXCLUDED.latitude,
+				longitude = EXCLUDED.longitude
 		`
+		var latitude, longitude *float64
+		if coordinates != nil {
+			latitude, longitude = &coordinates.Latitude, &coordinates.Longitude
+		}
 		addrArgs := pgx.StrictNamedArgs{
 			"orgID":       orgID,
 			"zipCode":     uo.ZipCode,
@@ -251,6 +274,8 @@ func (r *OrganizationRepository) Update(ctx context.Context, orgID uuid.UUID, uo
 			"publicPlace": uo.PublicPlace,
 			"city":        uo.City,
 			"state":       uo.State,
+			"latitude":    latitude,
+			"longitude":   longitude,
 		}
 
 		_, err = tx.Exec(ctx, upsertAddressQuery, addrArgs)
@@ -277,7 +302,7 @@ func (r *OrganizationRepository) Delete(ctx context.Context, id uuid.UUID) (doma
 		"id": id,
 	}
 
-	res, err := r.DB.Exec(ctx, query, args)
+	res, err := conn(ctx, r.DB).Exec(ctx, query, args)
 	if err != nil {
 		return domain.DBResponse{Message: "erro ao deletar organização"}, err
 	}
@@ -347,7 +372,7 @@ func (r *OrganizationRepository) AddUserToOrganization(ctx context.Context, user
 		"role":           role,
 	}
 
-	res, err := r.DB.Exec(ctx, query, args)
+	res, err := conn(ctx, r.DB).Exec(ctx, query, args)
 	if err != nil {
 		return domain.DBResponse{Message: "erro ao adicionar usuário à organização"}, err
 	}
@@ -360,8 +385,9 @@ func (r *OrganizationRepository) AddUserToOrganization(ctx context.Context, user
 	return domain.DBResponse{Success: true}, nil
 }
 
-// GetOrganizationMembers retrieves all members of an organization with their details
-func (r *OrganizationRepository) GetOrganizationMembers(ctx context.Context, organizationID uuid.UUID) (domain.DBResponse, error) {
+// GetOrganizationMembers retrieves a page of the organization members with their
+// details, in the order they joined
+func (r *OrganizationRepository) GetOrganizationMembers(ctx context.Context, organizationID uuid.UUID, page domain.PageRequest) (domain.DBResponse, error) {
 	const query = `
 		SELECT 
 			u.id, 
@@ -373,11 +399,13 @@ func (r *OrganizationRepository) GetOrganizationMembers(ctx context.Context, org
 		JOIN organization_users ou ON u.id = ou.user_id
 		JOIN organization_roles r ON ou.organization_role_id = r.id
 		WHERE ou.organization_id = @organizationID
-		ORDER BY ou.joined_at
+			AND (@cursorKey::timestamptz IS NULL OR (ou.joined_at, u.id) > (@cursorKey::timestamptz, @cursorID::uuid))
+		ORDER BY ou.joined_at, u.id
+		LIMIT NULLIF(@limit::int, 0)
 	`
-	args := pgx.StrictNamedArgs{
+	args := withPage(pgx.StrictNamedArgs{
 		"organizationID": organizationID,
-	}
+	}, page)
 
 	rows, err := r.DB.Query(ctx, query, args)
 	if err != nil {
@@ -414,33 +442,75 @@ func (r *OrganizationRepository) GetOrganizationMembers(ctx context.Context, org
 		return domain.DBResponse{Success: false, Message: "erro ao processar membros"}, rows.Err()
 	}
 
-	// Return empty slice if no members, not nil
-	if members == nil {
-		members = []domain.OrganizationUser{}
+	return domain.DBResponse{Success: true, Data: domain.NewCursorPage(members, page, func(m domain.OrganizationUser) domain.Cursor {
+		return domain.Cursor{Key: m.JoinedAt.Format(time.RFC3339Nano), ID: m.UserID.String()}
+	})}, nil
+}
+
+func (r *OrganizationRepository) RemoveUserFromOrganization(ctx context.Context, organizationID, userID uuid.UUID) (domain.DBResponse, error) {
+	var rows int64
+	err := pgx.BeginFunc(ctx, conn(ctx, r.DB), func(tx pgx.Tx) error {
+		const query = `
+			DELETE FROM organization_users
+			WHERE organization_id = @organizationID AND user_id = @userID
+		`
+		args := pgx.StrictNamedArgs{
+			"organizationID": organizationID,
+			"userID":         userID,
+		}
+
+		res, err := tx.Exec(ctx, query, args)
+		if err != nil {
+			return err
+		}
+		rows = res.RowsAffected()
+
+		// Drop the user's team assignments in this organization
+		const teamsQuery = `
+			DELETE FROM team_members tm
+			USING teams t
+			WHERE tm.team_id = t.id AND t.organization_id = @organizationID AND tm.user_id = @userID
+		`
+		_, err = tx.Exec(ctx, teamsQuery, args)
+		return err
+	})
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao remover usuário da organização"}, err
+	}
+
+	if rows != 1 {
+		return domain.DBResponse{Message: "usuário não encontrado na organização"}, nil
 	}
 
-	return domain.DBResponse{Success: true, Data: members}, nil
+	return domain.DBResponse{Success: true}, nil
 }
 
-func (r *OrganizationRepository) RemoveUserFromOrganization(ctx context.Context, organizationID, userID uuid.UUID) (domain.DBResponse, error) {
+// GetUserRole returns the role of the user within the organization
+func (r *OrganizationRepository) GetUserRole(ctx context.Context, userID, organizationID uuid.UUID) (domain.DBResponse, error) {
 	const query = `
-		DELETE FROM organization_users
-		WHERE organization_id = @organizationID AND user_id = @userID
+		SELECT r.name
+		FROM organization_users ou
+		JOIN organization_roles r ON ou.organization_role_id = r.id
+		WHERE ou.user_id = @userID AND ou.organization_id = @organizationID
 	`
 	args := pgx.StrictNamedArgs{
-		"organizationID": organizationID,
 		"userID":         userID,
+		"organizationID": organizationID,
 	}
 
-	res, err := r.DB.Exec(ctx, query, args)
+	var roleStr string
+	err := r.DB.QueryRow(ctx, query, args).Scan(&roleStr)
 	if err != nil {
-		return domain.DBResponse{Message: "erro ao remover usuário da organização"}, err
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Message: "usuário não é membro desta organização"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao buscar função do usuário"}, err
 	}
 
-	rows := res.RowsAffected()
-	if rows != 1 {
-		return domain.DBResponse{Message: "usuário não encontrado na organização"}, nil
+	role, err := domain.ParseRole(roleStr)
+	if err != nil {
+		return domain.DBResponse{Message: "função inválida"}, err
 	}
 
-	return domain.DBResponse{Success: true}, nil
+	return domain.DBResponse{Success: true, Data: role}, nil
 }
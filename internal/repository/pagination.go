@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"github.com/jackc/pgx/v5"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+// withPage adds the keyset pagination arguments to args. Queries compare
+// (sort column, id) with (@cursorKey, @cursorID), skipping the comparison when
+// @cursorKey is NULL, and end with LIMIT NULLIF(@limit::int, 0) so a zero
+// limit returns every row.
+func withPage(args pgx.StrictNamedArgs, page domain.PageRequest) pgx.StrictNamedArgs {
+	var key, id *string
+	if page.Cursor != nil {
+		key = &page.Cursor.Key
+		id = &page.Cursor.ID
+	}
+
+	args["cursorKey"] = key
+	args["cursorID"] = id
+	args["limit"] = page.FetchLimit()
+	return args
+}
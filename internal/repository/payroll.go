@@ -0,0 +1,364 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+type PayrollRepository struct {
+	DB *pgxpool.Pool
+}
+
+func NewPayrollRepository(db *pgxpool.Pool) *PayrollRepository {
+	return &PayrollRepository{db}
+}
+
+const payrollSettingsColumns = `
+	organization_id,
+	format,
+	company_code,
+	regular_code,
+	overtime_code,
+	night_code,
+	absence_code,
+	csv_columns,
+	csv_delimiter,
+	updated_at
+`
+
+// GetSettings retrieves the organization payroll settings. Data is nil when
+// the organization never configured the payroll.
+func (r *PayrollRepository) GetSettings(ctx context.Context, orgID uuid.UUID) (domain.DBResponse, error) {
+	query := `SELECT` + payrollSettingsColumns + `FROM payroll_settings WHERE organization_id = @orgID`
+
+	settings, err := scanPayrollSettings(r.DB.QueryRow(ctx, query, pgx.StrictNamedArgs{"orgID": orgID}))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: true, Data: (*domain.PayrollSettings)(nil)}, nil
+		}
+		return domain.DBResponse{Message: "erro ao buscar configuração da folha de pagamento"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: &settings}, nil
+}
+
+// SaveSettings creates or replaces the organization payroll settings. It joins
+// the context transaction if there is one.
+func (r *PayrollRepository) SaveSettings(ctx context.Context, s domain.PayrollSettings) (domain.DBResponse, error) {
+	query := `
+		INSERT INTO payroll_settings (
+			organization_id, format, company_code, regular_code, overtime_code,
+			night_code, absence_code, csv_columns, csv_delimiter
+		)
+		VALUES (@orgID, @format, @companyCode, @regularCode, @overtimeCode, @nightCode, @absenceCode, @csvColumns, @csvDelimiter)
+		ON CONFLICT (organization_id) DO UPDATE SET
+			format = EXCLUDED.format,
+			company_code = EXCLUDED.company_code,
+			regular_code = EXCLUDED.regular_code,
+			overtime_code = EXCLUDED.overtime_code,
+			night_code = EXCLUDED.night_code,
+			absence_code = EXCLUDED.absence_code,
+			csv_columns = EXCLUDED.csv_columns,
+			csv_delimiter = EXCLUDED.csv_delimiter,
+			updated_at = NOW()
+		RETURNING` + payrollSettingsColumns
+	args := pgx.StrictNamedArgs{
+		"orgID":        s.OrganizationID,
+		"format":       s.Format,
+		"companyCode":  s.CompanyCode,
+		"regularCode":  s.RegularCode,
+		"overtimeCode": s.OvertimeCode,
+		"nightCode":    s.NightCode,
+		"absenceCode":  s.AbsenceCode,
+		"csvColumns":   s.CSVColumns,
+		"csvDelimiter": s.CSVDelimiter,
+	}
+
+	saved, err := scanPayrollSettings(conn(ctx, r.DB).QueryRow(ctx, query, args))
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao salvar configuração da folha de pagamento"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: saved}, nil
+}
+
+func scanPayrollSettings(row pgx.Row) (domain.PayrollSettings, error) {
+	var s domain.PayrollSettings
+	err := row.Scan(
+		&s.OrganizationID,
+		&s.Format,
+		&s.CompanyCode,
+		&s.RegularCode,
+		&s.OvertimeCode,
+		&s.NightCode,
+		&s.AbsenceCode,
+		&s.CSVColumns,
+		&s.CSVDelimiter,
+		&s.UpdatedAt,
+	)
+	return s, err
+}
+
+const payPeriodColumns = `
+	pp.id,
+	pp.organization_id,
+	pp.month,
+	pp.closed,
+	pp.closed_by,
+	COALESCE(cb.name, ''),
+	pp.closed_at,
+	pp.reopened_by,
+	COALESCE(rb.name, ''),
+	pp.reopened_at,
+	pp.reopen_reason
+`
+
+const payPeriodJoins = `
+	LEFT JOIN users cb ON cb.id = pp.closed_by
+	LEFT JOIN users rb ON rb.id = pp.reopened_by
+`
+
+// ClosePeriod closes the month of the organization, creating the period if
+// needed. Periods already closed are refused. It must run in the context
+// transaction: it takes the exclusive pay period lock, so the totals read and
+// saved in that transaction see every committed change and no new ones.
+func (r *PayrollRepository) ClosePeriod(ctx context.Context, orgID uuid.UUID, month time.Time, closedBy uuid.UUID) (domain.DBResponse, error) {
+	if err := lockPayPeriods(ctx, conn(ctx, r.DB), orgID, true); err != nil {
+		return domain.DBResponse{Message: "erro ao fechar período de folha"}, err
+	}
+
+	query := `
+		WITH pp AS (
+			INSERT INTO pay_periods (organization_id, month, closed_by)
+			VALUES (@orgID, @month, @closedBy)
+			ON CONFLICT (organization_id, month) DO UPDATE SET
+				closed = TRUE,
+				closed_by = EXCLUDED.closed_by,
+				closed_at = NOW()
+			WHERE NOT pay_periods.closed
+			RETURNING *
+		)
+		SELECT` + payPeriodColumns + `FROM pp` + payPeriodJoins
+	args := pgx.StrictNamedArgs{
+		"orgID":    orgID,
+		"month":    month,
+		"closedBy": closedBy,
+	}
+
+	period, err := scanPayPeriod(conn(ctx, r.DB).QueryRow(ctx, query, args))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "o período já está fechado"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao fechar período de folha"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: period}, nil
+}
+
+// SavePeriodTotals replaces the member totals kept for the period. It joins
+// the context transaction if there is one.
+func (r *PayrollRepository) SavePeriodTotals(ctx context.Context, periodID uuid.UUID, totals []domain.PayrollTotals) (domain.DBResponse, error) {
+	const deleteQuery = `DELETE FROM pay_period_totals WHERE pay_period_id = @periodID`
+	if _, err := conn(ctx, r.DB).Exec(ctx, deleteQuery, pgx.StrictNamedArgs{"periodID": periodID}); err != nil {
+		return domain.DBResponse{Message: "erro ao salvar totais do período"}, err
+	}
+
+	const insertQuery = `
+		INSERT INTO pay_period_totals (pay_period_id, user_id, name, cpf, regular_minutes, overtime_minutes, night_minutes, absence_days)
+		VALUES (@periodID, @userID, @name, @cpf, @regular, @overtime, @night, @absences)
+	`
+	for _, t := range totals {
+		args := pgx.StrictNamedArgs{
+			"periodID": periodID,
+			"userID":   t.UserID,
+			"name":     t.Name,
+			"cpf":      t.CPF,
+			"regular":  t.RegularMinutes,
+			"overtime": t.OvertimeMinutes,
+			"night":    t.NightMinutes,
+			"absences": t.AbsenceDays,
+		}
+		if _, err := conn(ctx, r.DB).Exec(ctx, insertQuery, args); err != nil {
+			return domain.DBResponse{Message: "erro ao salvar totais do período"}, err
+		}
+	}
+
+	return domain.DBResponse{Success: true}, nil
+}
+
+// ReopenPeriod reopens a closed month of the organization with the reason
+// given. It joins the context transaction if there is one.
+func (r *PayrollRepository) ReopenPeriod(ctx context.Context, orgID uuid.UUID, month time.Time, reopenedBy uuid.UUID, reason string) (domain.DBResponse, error) {
+	query := `
+		WITH pp AS (
+			UPDATE pay_periods
+			SET closed = FALSE, reopened_by = @reopenedBy, reopened_at = NOW(), reopen_reason = @reason
+			WHERE organization_id = @orgID AND month = @month AND closed
+			RETURNING *
+		)
+		SELECT` + payPeriodColumns + `FROM pp` + payPeriodJoins
+	args := pgx.StrictNamedArgs{
+		"orgID":      orgID,
+		"month":      month,
+		"reopenedBy": reopenedBy,
+		"reason":     reason,
+	}
+
+	period, err := scanPayPeriod(conn(ctx, r.DB).QueryRow(ctx, query, args))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "o período não está fechado"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao reabrir período de folha"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: period}, nil
+}
+
+// GetPeriod retrieves the month of the organization with the totals kept
+// when it was last closed. Data is nil when the month was never closed.
+func (r *PayrollRepository) GetPeriod(ctx context.Context, orgID uuid.UUID, month time.Time) (domain.DBResponse, error) {
+	query := `SELECT` + payPeriodColumns + `FROM pay_periods pp` + payPeriodJoins + `
+		WHERE pp.organization_id = @orgID AND pp.month = @month`
+
+	period, err := scanPayPeriod(conn(ctx, r.DB).QueryRow(ctx, query, pgx.StrictNamedArgs{"orgID": orgID, "month": month}))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: true, Data: (*domain.PayPeriod)(nil)}, nil
+		}
+		return domain.DBResponse{Message: "erro ao buscar período de folha"}, err
+	}
+
+	const totalsQuery = `
+		SELECT user_id, name, cpf, regular_minutes, overtime_minutes, night_minutes, absence_days
+		FROM pay_period_totals
+		WHERE pay_period_id = @periodID
+		ORDER BY name, user_id
+	`
+	rows, err := conn(ctx, r.DB).Query(ctx, totalsQuery, pgx.StrictNamedArgs{"periodID": period.ID})
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar totais do período"}, err
+	}
+	defer rows.Close()
+
+	period.Totals = []domain.PayrollTotals{}
+	for rows.Next() {
+		var t domain.PayrollTotals
+		if err := rows.Scan(&t.UserID, &t.Name, &t.CPF, &t.RegularMinutes, &t.OvertimeMinutes, &t.NightMinutes, &t.AbsenceDays); err != nil {
+			return domain.DBResponse{Message: "erro ao ler totais do período"}, err
+		}
+		period.Totals = append(period.Totals, t)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar totais do período"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: &period}, nil
+}
+
+// ListPeriods retrieves the closed and reopened months of the organization,
+// newest first, without their totals
+func (r *PayrollRepository) ListPeriods(ctx context.Context, orgID uuid.UUID) (domain.DBResponse, error) {
+	query := `SELECT` + payPeriodColumns + `FROM pay_periods pp` + payPeriodJoins + `
+		WHERE pp.organization_id = @orgID
+		ORDER BY pp.month DESC`
+
+	rows, err := conn(ctx, r.DB).Query(ctx, query, pgx.StrictNamedArgs{"orgID": orgID})
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar períodos de folha"}, err
+	}
+	defer rows.Close()
+
+	periods := []domain.PayPeriod{}
+	for rows.Next() {
+		p, err := scanPayPeriod(rows)
+		if err != nil {
+			return domain.DBResponse{Message: "erro ao ler período de folha"}, err
+		}
+		periods = append(periods, p)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar períodos de folha"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: periods}, nil
+}
+
+func scanPayPeriod(row pgx.Row) (domain.PayPeriod, error) {
+	var p domain.PayPeriod
+	err := row.Scan(
+		&p.ID,
+		&p.OrganizationID,
+		&p.Month,
+		&p.Closed,
+		&p.ClosedBy,
+		&p.ClosedByName,
+		&p.ClosedAt,
+		&p.ReopenedBy,
+		&p.ReopenedByName,
+		&p.ReopenedAt,
+		&p.ReopenReason,
+	)
+	return p, err
+}
+
+// payPeriodLock is the advisory lock key of the organization pay periods
+const payPeriodLock = `hashtextextended('pay_periods:' || @orgID::text, 0)`
+
+// lockPayPeriods takes the organization pay period lock until the end of the
+// transaction: exclusive to close a period, shared to change timesheets. Closing
+// then waits for every change already past ensurePeriodOpen to commit, and
+// changes wait for the closing totals to be saved. db must be a transaction.
+func lockPayPeriods(ctx context.Context, db DBTX, orgID uuid.UUID, exclusive bool) error {
+	lock := "pg_advisory_xact_lock_shared"
+	if exclusive {
+		lock = "pg_advisory_xact_lock"
+	}
+
+	_, err := db.Exec(ctx, `SELECT `+lock+`(`+payPeriodLock+`)`, pgx.StrictNamedArgs{"orgID": orgID})
+	return err
+}
+
+// ensurePeriodOpen returns domain.ErrPayPeriodClosed when a day between start
+// and end (inclusive) is in a closed pay period of the organization. Every
+// change to daily_timesheets and timesheet_entries goes through it, inside the
+// transaction of the change: it holds the shared pay period lock, so a period
+// can not be closed until the change commits.
+func ensurePeriodOpen(ctx context.Context, db DBTX, orgID uuid.UUID, start, end time.Time) error {
+	if err := lockPayPeriods(ctx, db, orgID, false); err != nil {
+		return err
+	}
+
+	const query = `
+		SELECT EXISTS (
+			SELECT 1 FROM pay_periods
+			WHERE organization_id = @orgID
+				AND month >= date_trunc('month', @start::date)::date
+				AND month <= @end::date
+				AND closed
+		)
+	`
+	args := pgx.StrictNamedArgs{
+		"orgID": orgID,
+		"start": start,
+		"end":   end,
+	}
+
+	var closed bool
+	if err := db.QueryRow(ctx, query, args).Scan(&closed); err != nil {
+		return err
+	}
+
+	if closed {
+		return domain.ErrPayPeriodClosed
+	}
+	return nil
+}
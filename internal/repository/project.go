@@ -0,0 +1,406 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+type ProjectRepository struct {
+	DB *pgxpool.Pool
+}
+
+func NewProjectRepository(db *pgxpool.Pool) *ProjectRepository {
+	return &ProjectRepository{db}
+}
+
+const projectColumns = `
+	id,
+	organization_id,
+	name,
+	client_id,
+	COALESCE((SELECT c.name FROM clients c WHERE c.id = projects.client_id), ''),
+	billable,
+	hourly_rate_cents,
+	archived,
+	created_at
+`
+
+// CreateProject stores a project of the organization. It joins the context
+// transaction if there is one.
+func (r *ProjectRepository) CreateProject(ctx context.Context, p domain.Project) (domain.DBResponse, error) {
+	query := `
+		INSERT INTO projects (organization_id, name, client_id, billable, hourly_rate_cents)
+		VALUES (@orgID, @name, @clientID, @billable, @hourlyRate)
+		RETURNING` + projectColumns
+
+	args := pgx.StrictNamedArgs{
+		"orgID":      p.OrganizationID,
+		"name":       p.Name,
+		"clientID":   p.ClientID,
+		"billable":   p.Billable,
+		"hourlyRate": p.HourlyRate,
+	}
+
+	saved, err := scanProject(conn(ctx, r.DB).QueryRow(ctx, query, args))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.DBResponse{Success: false, Message: "já existe um projeto com este nome"}, nil
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return domain.DBResponse{Success: false, Message: "cliente não encontrado"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao criar projeto"}, err
+	}
+	saved.Tasks = []domain.ProjectTask{}
+
+	return domain.DBResponse{Success: true, Data: saved}, nil
+}
+
+// UpdateProject saves the changes of a project. It joins the context
+// transaction if there is one.
+func (r *ProjectRepository) UpdateProject(ctx context.Context, p domain.Project) (domain.DBResponse, error) {
+	query := `
+		UPDATE projects
+		SET name = @name, client_id = @clientID, billable = @billable, hourly_rate_cents = @hourlyRate, archived = @archived
+		WHERE id = @id AND organization_id = @orgID
+		RETURNING` + projectColumns
+
+	args := pgx.StrictNamedArgs{
+		"id":         p.ID,
+		"orgID":      p.OrganizationID,
+		"name":       p.Name,
+		"clientID":   p.ClientID,
+		"billable":   p.Billable,
+		"hourlyRate": p.HourlyRate,
+		"archived":   p.Archived,
+	}
+
+	saved, err := scanProject(conn(ctx, r.DB).QueryRow(ctx, query, args))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "projeto não encontrado"}, nil
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.DBResponse{Success: false, Message: "já existe um projeto com este nome"}, nil
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return domain.DBResponse{Success: false, Message: "cliente não encontrado"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao atualizar projeto"}, err
+	}
+	saved.Tasks = p.Tasks
+
+	return domain.DBResponse{Success: true, Data: saved}, nil
+}
+
+// GetProject retrieves a project of the organization with its tasks
+func (r *ProjectRepository) GetProject(ctx context.Context, orgID, projectID uuid.UUID) (domain.DBResponse, error) {
+	query := `SELECT` + projectColumns + `FROM projects WHERE id = @id AND organization_id = @orgID`
+
+	p, err := scanProject(conn(ctx, r.DB).QueryRow(ctx, query, pgx.StrictNamedArgs{"id": projectID, "orgID": orgID}))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "projeto não encontrado"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao buscar projeto"}, err
+	}
+
+	projects := []domain.Project{p}
+	if err := r.loadTasks(ctx, projects); err != nil {
+		return domain.DBResponse{Message: "erro ao buscar tarefas do projeto"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: projects[0]}, nil
+}
+
+// ListProjects retrieves the organization projects with their tasks ordered
+// by name. Archived projects are only listed when includeArchived is set.
+func (r *ProjectRepository) ListProjects(ctx context.Context, orgID uuid.UUID, includeArchived bool) (domain.DBResponse, error) {
+	query := `SELECT` + projectColumns + `
+		FROM projects
+		WHERE organization_id = @orgID AND (@includeArchived OR NOT archived)
+		ORDER BY name, id
+	`
+
+	rows, err := conn(ctx, r.DB).Query(ctx, query, pgx.StrictNamedArgs{"orgID": orgID, "includeArchived": includeArchived})
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar projetos"}, err
+	}
+
+	projects := []domain.Project{}
+	for rows.Next() {
+		p, err := scanProject(rows)
+		if err != nil {
+			rows.Close()
+			return domain.DBResponse{Message: "erro ao ler projeto"}, err
+		}
+		projects = append(projects, p)
+	}
+	rows.Close()
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar projetos"}, rows.Err()
+	}
+
+	if err := r.loadTasks(ctx, projects); err != nil {
+		return domain.DBResponse{Message: "erro ao buscar tarefas dos projetos"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: projects}, nil
+}
+
+// loadTasks fills the tasks of the projects with a single query
+func (r *ProjectRepository) loadTasks(ctx context.Context, projects []domain.Project) error {
+	ids := make([]uuid.UUID, len(projects))
+	byID := make(map[uuid.UUID]int, len(projects))
+	for i := range projects {
+		ids[i] = projects[i].ID
+		byID[projects[i].ID] = i
+		projects[i].Tasks = []domain.ProjectTask{}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	const query = `
+		SELECT id, project_id, name, created_at
+		FROM project_tasks
+		WHERE project_id = ANY(@ids)
+		ORDER BY name, id
+	`
+
+	rows, err := conn(ctx, r.DB).Query(ctx, query, pgx.StrictNamedArgs{"ids": ids})
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t domain.ProjectTask
+		if err := rows.Scan(&t.ID, &t.ProjectID, &t.Name, &t.CreatedAt); err != nil {
+			return err
+		}
+		i := byID[t.ProjectID]
+		projects[i].Tasks = append(projects[i].Tasks, t)
+	}
+
+	return rows.Err()
+}
+
+// CreateTask adds a task to a project. It joins the context transaction if
+// there is one.
+func (r *ProjectRepository) CreateTask(ctx context.Context, projectID uuid.UUID, name string) (domain.DBResponse, error) {
+	const query = `
+		INSERT INTO project_tasks (project_id, name)
+		VALUES (@projectID, @name)
+		RETURNING id, project_id, name, created_at
+	`
+
+	var t domain.ProjectTask
+	err := conn(ctx, r.DB).QueryRow(ctx, query, pgx.StrictNamedArgs{"projectID": projectID, "name": name}).Scan(&t.ID, &t.ProjectID, &t.Name, &t.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.DBResponse{Success: false, Message: "já existe uma tarefa com este nome no projeto"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao criar tarefa"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: t}, nil
+}
+
+// DeleteTask removes a task of a project and returns it. Allocations of the
+// task stay on the project. It joins the context transaction if there is one.
+func (r *ProjectRepository) DeleteTask(ctx context.Context, projectID, taskID uuid.UUID) (domain.DBResponse, error) {
+	const query = `
+		DELETE FROM project_tasks
+		WHERE id = @id AND project_id = @projectID
+		RETURNING id, project_id, name, created_at
+	`
+
+	var t domain.ProjectTask
+	err := conn(ctx, r.DB).QueryRow(ctx, query, pgx.StrictNamedArgs{"id": taskID, "projectID": projectID}).Scan(&t.ID, &t.ProjectID, &t.Name, &t.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "tarefa não encontrada"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao remover tarefa"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: t}, nil
+}
+
+// CreateAllocation stores an allocation of a member workday. It joins the
+// context transaction if there is one.
+func (r *ProjectRepository) CreateAllocation(ctx context.Context, a domain.TimeAllocation) (domain.DBResponse, error) {
+	const query = `
+		INSERT INTO time_allocations (
+			organization_id, user_id, timesheet_id, project_id, task_id, starts_at, ends_at, minutes, billable, note
+		)
+		VALUES (@orgID, @userID, @timesheetID, @projectID, @taskID, @startsAt, @endsAt, @minutes, @billable, @note)
+		RETURNING id, created_at
+	`
+	args := pgx.StrictNamedArgs{
+		"orgID":       a.OrganizationID,
+		"userID":      a.UserID,
+		"timesheetID": a.TimesheetID,
+		"projectID":   a.ProjectID,
+		"taskID":      a.TaskID,
+		"startsAt":    a.StartsAt,
+		"endsAt":      a.EndsAt,
+		"minutes":     a.Minutes,
+		"billable":    a.Billable,
+		"note":        a.Note,
+	}
+
+	err := conn(ctx, r.DB).QueryRow(ctx, query, args).Scan(&a.ID, &a.CreatedAt)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao salvar apontamento de horas"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: a}, nil
+}
+
+// LockTimesheet locks the timesheet until the context transaction ends, so
+// allocations checked against the workday are not raced by another one
+func (r *ProjectRepository) LockTimesheet(ctx context.Context, timesheetID uuid.UUID) error {
+	const query = `SELECT id FROM daily_timesheets WHERE id = @id FOR UPDATE`
+
+	var id uuid.UUID
+	return conn(ctx, r.DB).QueryRow(ctx, query, pgx.StrictNamedArgs{"id": timesheetID}).Scan(&id)
+}
+
+// ListAllocations retrieves the allocations of a timesheet in the order they
+// were made. It joins the context transaction if there is one.
+func (r *ProjectRepository) ListAllocations(ctx context.Context, timesheetID uuid.UUID) (domain.DBResponse, error) {
+	const query = `
+		SELECT
+			a.id, a.organization_id, a.user_id, a.timesheet_id, dt.date, a.project_id, p.name,
+			a.task_id, COALESCE(t.name, ''), a.starts_at, a.ends_at, a.minutes, a.billable, a.note, a.created_at
+		FROM time_allocations a
+		JOIN daily_timesheets dt ON dt.id = a.timesheet_id
+		JOIN projects p ON p.id = a.project_id
+		LEFT JOIN project_tasks t ON t.id = a.task_id
+		WHERE a.timesheet_id = @timesheetID
+		ORDER BY a.created_at, a.id
+	`
+
+	rows, err := conn(ctx, r.DB).Query(ctx, query, pgx.StrictNamedArgs{"timesheetID": timesheetID})
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar apontamentos de horas"}, err
+	}
+	defer rows.Close()
+
+	allocations := []domain.TimeAllocation{}
+	for rows.Next() {
+		var a domain.TimeAllocation
+		err := rows.Scan(
+			&a.ID, &a.OrganizationID, &a.UserID, &a.TimesheetID, &a.Date, &a.ProjectID, &a.ProjectName,
+			&a.TaskID, &a.TaskName, &a.StartsAt, &a.EndsAt, &a.Minutes, &a.Billable, &a.Note, &a.CreatedAt,
+		)
+		if err != nil {
+			return domain.DBResponse{Message: "erro ao ler apontamento de horas"}, err
+		}
+		allocations = append(allocations, a)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar apontamentos de horas"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: allocations}, nil
+}
+
+// DeleteAllocation removes an allocation of the member
+func (r *ProjectRepository) DeleteAllocation(ctx context.Context, orgID, userID, allocationID uuid.UUID) (domain.DBResponse, error) {
+	const query = `DELETE FROM time_allocations WHERE id = @id AND organization_id = @orgID AND user_id = @userID`
+
+	tag, err := conn(ctx, r.DB).Exec(ctx, query, pgx.StrictNamedArgs{"id": allocationID, "orgID": orgID, "userID": userID})
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao remover apontamento de horas"}, err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.DBResponse{Success: false, Message: "apontamento de horas não encontrado"}, nil
+	}
+
+	return domain.DBResponse{Success: true, Message: "apontamento de horas removido com sucesso"}, nil
+}
+
+// Report sums the allocations of the organization between start and end
+// (inclusive) by project and member, optionally for a single project
+func (r *ProjectRepository) Report(ctx context.Context, orgID uuid.UUID, start, end time.Time, projectID *uuid.UUID) (domain.DBResponse, error) {
+	const query = `
+		SELECT
+			p.id, p.name, COALESCE(c.name, ''), u.id, u.name,
+			SUM(a.minutes)::bigint,
+			COALESCE(SUM(a.minutes) FILTER (WHERE a.billable), 0)::bigint,
+			p.hourly_rate_cents
+		FROM time_allocations a
+		JOIN daily_timesheets dt ON dt.id = a.timesheet_id
+		JOIN projects p ON p.id = a.project_id
+		LEFT JOIN clients c ON c.id = p.client_id
+		JOIN users u ON u.id = a.user_id
+		WHERE a.organization_id = @orgID
+			AND dt.date >= @start
+			AND dt.date <= @end
+			AND (@projectID::uuid IS NULL OR a.project_id = @projectID::uuid)
+		GROUP BY p.id, c.id, u.id
+		ORDER BY p.name, p.id, u.name, u.id
+	`
+	args := pgx.StrictNamedArgs{
+		"orgID":     orgID,
+		"start":     start,
+		"end":       end,
+		"projectID": projectID,
+	}
+
+	rows, err := conn(ctx, r.DB).Query(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao calcular horas por projeto"}, err
+	}
+	defer rows.Close()
+
+	hours := []domain.ProjectHours{}
+	for rows.Next() {
+		var h domain.ProjectHours
+		err := rows.Scan(&h.ProjectID, &h.ProjectName, &h.Client, &h.UserID, &h.UserName, &h.Minutes, &h.BillableMinutes, &h.HourlyRate)
+		if err != nil {
+			return domain.DBResponse{Message: "erro ao ler horas por projeto"}, err
+		}
+		h.Amount = domain.BillableAmount(h.BillableMinutes, h.HourlyRate)
+		hours = append(hours, h)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar horas por projeto"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: hours}, nil
+}
+
+func scanProject(row pgx.Row) (domain.Project, error) {
+	var p domain.Project
+	err := row.Scan(
+		&p.ID,
+		&p.OrganizationID,
+		&p.Name,
+		&p.ClientID,
+		&p.Client,
+		&p.Billable,
+		&p.HourlyRate,
+		&p.Archived,
+		&p.CreatedAt,
+	)
+	return p, err
+}
@@ -0,0 +1,378 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+type PunchPolicyRepository struct {
+	DB *pgxpool.Pool
+}
+
+func NewPunchPolicyRepository(db *pgxpool.Pool) *PunchPolicyRepository {
+	return &PunchPolicyRepository{db}
+}
+
+const punchPolicyColumns = `
+	organization_id,
+	require_qr,
+	qr_secret,
+	geofence_mode,
+	network_mode,
+	updated_at
+`
+
+// Get retrieves the organization punch policy. Data is nil when the
+// organization never configured one.
+func (r *PunchPolicyRepository) Get(ctx context.Context, orgID uuid.UUID) (domain.DBResponse, error) {
+	query := `SELECT` + punchPolicyColumns + `FROM punch_policies WHERE organization_id = @orgID`
+
+	policy, err := scanPunchPolicy(conn(ctx, r.DB).QueryRow(ctx, query, pgx.StrictNamedArgs{"orgID": orgID}))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: true, Data: (*domain.PunchPolicy)(nil)}, nil
+		}
+		return domain.DBResponse{Message: "erro ao buscar política de registro de ponto"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: &policy}, nil
+}
+
+// Save creates or replaces the organization punch policy. It joins the
+// context transaction if there is one.
+func (r *PunchPolicyRepository) Save(ctx context.Context, p domain.PunchPolicy) (domain.DBResponse, error) {
+	query := `
+		INSERT INTO punch_policies (organization_id, require_qr, qr_secret, geofence_mode, network_mode)
+		VALUES (@orgID, @requireQR, @qrSecret, @geofenceMode, @networkMode)
+		ON CONFLICT (organization_id) DO UPDATE SET
+			require_qr = EXCLUDED.require_qr,
+			qr_secret = EXCLUDED.qr_secret,
+			geofence_mode = EXCLUDED.geofence_mode,
+			network_mode = EXCLUDED.network_mode,
+			updated_at = NOW()
+		RETURNING` + punchPolicyColumns
+	args := pgx.StrictNamedArgs{
+		"orgID":        p.OrganizationID,
+		"requireQR":    p.RequireQR,
+		"qrSecret":     p.QRSecret,
+		"geofenceMode": p.GeofenceMode,
+		"networkMode":  p.NetworkMode,
+	}
+
+	saved, err := scanPunchPolicy(conn(ctx, r.DB).QueryRow(ctx, query, args))
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao salvar política de registro de ponto"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: saved}, nil
+}
+
+func scanPunchPolicy(row pgx.Row) (domain.PunchPolicy, error) {
+	var p domain.PunchPolicy
+	err := row.Scan(
+		&p.OrganizationID,
+		&p.RequireQR,
+		&p.QRSecret,
+		&p.GeofenceMode,
+		&p.NetworkMode,
+		&p.UpdatedAt,
+	)
+	return p, err
+}
+
+const geofenceColumns = `
+	id,
+	organization_id,
+	name,
+	kind,
+	center_latitude,
+	center_longitude,
+	radius_meters,
+	polygon,
+	created_at
+`
+
+// CreateGeofence stores a fence of the organization. It joins the context
+// transaction if there is one.
+func (r *PunchPolicyRepository) CreateGeofence(ctx context.Context, g domain.Geofence) (domain.DBResponse, error) {
+	query := `
+		INSERT INTO geofences (organization_id, name, kind, center_latitude, center_longitude, radius_meters, polygon)
+		VALUES (@orgID, @name, @kind, @centerLatitude, @centerLongitude, @radiusMeters, @polygon)
+		RETURNING` + geofenceColumns
+
+	args := pgx.StrictNamedArgs{
+		"orgID":           g.OrganizationID,
+		"name":            g.Name,
+		"kind":            g.Kind,
+		"centerLatitude":  nil,
+		"centerLongitude": nil,
+		"radiusMeters":    nil,
+		"polygon":         nil,
+	}
+	if g.Center != nil {
+		args["centerLatitude"] = g.Center.Latitude
+		args["centerLongitude"] = g.Center.Longitude
+		args["radiusMeters"] = g.RadiusMeters
+	}
+	if g.Polygon != nil {
+		polygon, err := json.Marshal(g.Polygon)
+		if err != nil {
+			return domain.DBResponse{Message: "erro ao salvar área de registro de ponto"}, err
+		}
+		args["polygon"] = polygon
+	}
+
+	saved, err := scanGeofence(conn(ctx, r.DB).QueryRow(ctx, query, args))
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao salvar área de registro de ponto"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: saved}, nil
+}
+
+// ListGeofences retrieves every fence of the organization, oldest first
+func (r *PunchPolicyRepository) ListGeofences(ctx context.Context, orgID uuid.UUID) (domain.DBResponse, error) {
+	query := `SELECT` + geofenceColumns + `
+		FROM geofences
+		WHERE organization_id = @orgID
+		ORDER BY created_at, id
+	`
+
+	rows, err := conn(ctx, r.DB).Query(ctx, query, pgx.StrictNamedArgs{"orgID": orgID})
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar áreas de registro de ponto"}, err
+	}
+	defer rows.Close()
+
+	fences := []domain.Geofence{}
+	for rows.Next() {
+		g, err := scanGeofence(rows)
+		if err != nil {
+			return domain.DBResponse{Message: "erro ao ler área de registro de ponto"}, err
+		}
+		fences = append(fences, g)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar áreas de registro de ponto"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: fences}, nil
+}
+
+// DeleteGeofence removes a fence of the organization and returns it. Punches
+// keep their location and flag but lose the reference to the fence.
+func (r *PunchPolicyRepository) DeleteGeofence(ctx context.Context, orgID, geofenceID uuid.UUID) (domain.DBResponse, error) {
+	query := `
+		DELETE FROM geofences
+		WHERE id = @id AND organization_id = @orgID
+		RETURNING` + geofenceColumns
+
+	g, err := scanGeofence(conn(ctx, r.DB).QueryRow(ctx, query, pgx.StrictNamedArgs{"id": geofenceID, "orgID": orgID}))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "área de registro de ponto não encontrada"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao remover área de registro de ponto"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: g}, nil
+}
+
+const punchNetworkColumns = `
+	id,
+	organization_id,
+	cidr,
+	description,
+	created_at
+`
+
+// CreateNetwork stores an IP range of the organization. It joins the context
+// transaction if there is one.
+func (r *PunchPolicyRepository) CreateNetwork(ctx context.Context, n domain.PunchNetwork) (domain.DBResponse, error) {
+	query := `
+		INSERT INTO punch_networks (organization_id, cidr, description)
+		VALUES (@orgID, @cidr, @description)
+		RETURNING` + punchNetworkColumns
+	args := pgx.StrictNamedArgs{
+		"orgID":       n.OrganizationID,
+		"cidr":        n.CIDR,
+		"description": n.Description,
+	}
+
+	saved, err := scanPunchNetwork(conn(ctx, r.DB).QueryRow(ctx, query, args))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.DBResponse{Success: false, Message: "faixa de IP já cadastrada"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao salvar rede de registro de ponto"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: saved}, nil
+}
+
+// ListNetworks retrieves every IP range of the organization, oldest first
+func (r *PunchPolicyRepository) ListNetworks(ctx context.Context, orgID uuid.UUID) (domain.DBResponse, error) {
+	query := `SELECT` + punchNetworkColumns + `
+		FROM punch_networks
+		WHERE organization_id = @orgID
+		ORDER BY created_at, id
+	`
+
+	rows, err := conn(ctx, r.DB).Query(ctx, query, pgx.StrictNamedArgs{"orgID": orgID})
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar redes de registro de ponto"}, err
+	}
+	defer rows.Close()
+
+	networks := []domain.PunchNetwork{}
+	for rows.Next() {
+		n, err := scanPunchNetwork(rows)
+		if err != nil {
+			return domain.DBResponse{Message: "erro ao ler rede de registro de ponto"}, err
+		}
+		networks = append(networks, n)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar redes de registro de ponto"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: networks}, nil
+}
+
+// DeleteNetwork removes an IP range of the organization and returns it
+func (r *PunchPolicyRepository) DeleteNetwork(ctx context.Context, orgID, networkID uuid.UUID) (domain.DBResponse, error) {
+	query := `
+		DELETE FROM punch_networks
+		WHERE id = @id AND organization_id = @orgID
+		RETURNING` + punchNetworkColumns
+
+	n, err := scanPunchNetwork(conn(ctx, r.DB).QueryRow(ctx, query, pgx.StrictNamedArgs{"id": networkID, "orgID": orgID}))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "rede de registro de ponto não encontrada"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao remover rede de registro de ponto"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: n}, nil
+}
+
+func scanPunchNetwork(row pgx.Row) (domain.PunchNetwork, error) {
+	var n domain.PunchNetwork
+	err := row.Scan(
+		&n.ID,
+		&n.OrganizationID,
+		&n.CIDR,
+		&n.Description,
+		&n.CreatedAt,
+	)
+	return n, err
+}
+
+// ListFlaggedPunches retrieves the punches of the organization flagged for
+// review, newest first. An empty flag lists punches flagged for any reason.
+func (r *PunchPolicyRepository) ListFlaggedPunches(ctx context.Context, orgID uuid.UUID, flag domain.PunchFlag, page domain.PageRequest) (domain.DBResponse, error) {
+	query := `
+		SELECT
+			te.id,
+			dt.user_id,
+			u.name,
+			u.email,
+			te.type_id,
+			te.timestamp,
+			te.latitude,
+			te.longitude,
+			te.accuracy_meters,
+			te.client_ip,
+			te.outside_geofence,
+			te.outside_network
+		FROM timesheet_entries te
+		JOIN daily_timesheets dt ON dt.id = te.timesheet_id
+		JOIN users u ON u.id = dt.user_id
+		WHERE te.organization_id = @orgID
+			AND (
+				(@flag::text IN ('', 'geofence') AND te.outside_geofence)
+				OR (@flag::text IN ('', 'network') AND te.outside_network)
+			)
+			AND (@cursorKey::timestamptz IS NULL OR (te.timestamp, te.id) < (@cursorKey::timestamptz, @cursorID::uuid))
+		ORDER BY te.timestamp DESC, te.id DESC
+		LIMIT NULLIF(@limit::int, 0)
+	`
+	args := withPage(pgx.StrictNamedArgs{"orgID": orgID, "flag": string(flag)}, page)
+
+	rows, err := r.DB.Query(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar registros para revisão"}, err
+	}
+	defer rows.Close()
+
+	punches := []domain.FlaggedPunch{}
+	for rows.Next() {
+		var p domain.FlaggedPunch
+		var latitude, longitude, accuracy *float64
+		err := rows.Scan(
+			&p.EntryID, &p.UserID, &p.UserName, &p.UserEmail, &p.TypeID, &p.Timestamp,
+			&latitude, &longitude, &accuracy, &p.ClientIP, &p.OutsideGeofence, &p.OutsideNetwork,
+		)
+		if err != nil {
+			return domain.DBResponse{Message: "erro ao ler registro para revisão"}, err
+		}
+		if latitude != nil && longitude != nil {
+			p.Location = &domain.PunchLocation{GeoPoint: domain.GeoPoint{Latitude: *latitude, Longitude: *longitude}, Accuracy: accuracy}
+		}
+		punches = append(punches, p)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar registros para revisão"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: domain.NewCursorPage(punches, page, func(p domain.FlaggedPunch) domain.Cursor {
+		return domain.Cursor{Key: p.Timestamp.Format(time.RFC3339Nano), ID: p.EntryID.String()}
+	})}, nil
+}
+
+func scanGeofence(row pgx.Row) (domain.Geofence, error) {
+	var g domain.Geofence
+	var centerLatitude, centerLongitude, radiusMeters *float64
+	var polygon []byte
+	err := row.Scan(
+		&g.ID,
+		&g.OrganizationID,
+		&g.Name,
+		&g.Kind,
+		&centerLatitude,
+		&centerLongitude,
+		&radiusMeters,
+		&polygon,
+		&g.CreatedAt,
+	)
+	if err != nil {
+		return g, err
+	}
+
+	if centerLatitude != nil && centerLongitude != nil {
+		g.Center = &domain.GeoPoint{Latitude: *centerLatitude, Longitude: *centerLongitude}
+	}
+	if radiusMeters != nil {
+		g.RadiusMeters = *radiusMeters
+	}
+	if polygon != nil {
+		if err := json.Unmarshal(polygon, &g.Polygon); err != nil {
+			return g, err
+		}
+	}
+
+	return g, nil
+}
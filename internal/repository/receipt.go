@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+type ReceiptRepository struct {
+	DB *pgxpool.Pool
+}
+
+func NewReceiptRepository(db *pgxpool.Pool) *ReceiptRepository {
+	return &ReceiptRepository{db}
+}
+
+const receiptColumns = `
+	id,
+	entry_id,
+	organization_id,
+	user_id,
+	nsr,
+	employee_name,
+	organization_name,
+	organization_address,
+	type_id,
+	timestamp,
+	verification_code,
+	created_at
+`
+
+// Create issues the receipt of a chained punch, snapshotting the employee name
+// and the organization name and address. It joins the context transaction if there is one.
+func (r *ReceiptRepository) Create(ctx context.Context, record domain.PunchRecord) (domain.DBResponse, error) {
+	const query = `
+		INSERT INTO punch_receipts (
+			entry_id, organization_id, user_id, nsr, employee_name, organization_name,
+			organization_address, type_id, timestamp, verification_code
+		)
+		SELECT
+			@entryID,
+			o.id,
+			u.id,
+			@nsr,
+			u.name,
+			o.name,
+			concat_ws(', ', a.public_place, a.complement, a.city, a.state, a.zip_code),
+			@type,
+			@timestamp,
+			@code
+		FROM organizations o
+		JOIN users u ON u.id = @userID
+		LEFT JOIN addresses a ON a.organization_id = o.id
+		WHERE o.id = @orgID
+		RETURNING` + receiptColumns
+	args := pgx.StrictNamedArgs{
+		"entryID":   record.EntryID,
+		"orgID":     record.OrganizationID,
+		"userID":    record.UserID,
+		"nsr":       record.Sequence,
+		"type":      int(record.TypeID),
+		"timestamp": record.Timestamp,
+		"code":      domain.ReceiptVerificationCode(record.Hash),
+	}
+
+	receipt, err := scanReceipt(conn(ctx, r.DB).QueryRow(ctx, query, args))
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao emitir comprovante"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: receipt}, nil
+}
+
+// GetByID retrieves a receipt by its ID
+func (r *ReceiptRepository) GetByID(ctx context.Context, id uuid.UUID) (domain.DBResponse, error) {
+	query := `SELECT` + receiptColumns + `FROM punch_receipts WHERE id = @id`
+	return r.getOne(ctx, query, pgx.StrictNamedArgs{"id": id})
+}
+
+// GetByEntryID retrieves the receipt issued for a timesheet entry
+func (r *ReceiptRepository) GetByEntryID(ctx context.Context, entryID uuid.UUID) (domain.DBResponse, error) {
+	query := `SELECT` + receiptColumns + `FROM punch_receipts WHERE entry_id = @entryID`
+	return r.getOne(ctx, query, pgx.StrictNamedArgs{"entryID": entryID})
+}
+
+// ListByUser retrieves a page of the user receipts in an organization, newest first
+func (r *ReceiptRepository) ListByUser(ctx context.Context, orgID, userID uuid.UUID, page domain.PageRequest) (domain.DBResponse, error) {
+	query := `SELECT` + receiptColumns + `
+		FROM punch_receipts
+		WHERE organization_id = @orgID AND user_id = @userID
+			AND (@cursorKey::bigint IS NULL OR (nsr, id) < (@cursorKey::bigint, @cursorID::uuid))
+		ORDER BY nsr DESC, id DESC
+		LIMIT NULLIF(@limit::int, 0)
+	`
+	args := withPage(pgx.StrictNamedArgs{
+		"orgID":  orgID,
+		"userID": userID,
+	}, page)
+
+	rows, err := r.DB.Query(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar comprovantes"}, err
+	}
+	defer rows.Close()
+
+	receipts := []domain.PunchReceipt{}
+	for rows.Next() {
+		receipt, err := scanReceipt(rows)
+		if err != nil {
+			return domain.DBResponse{Message: "erro ao ler comprovante"}, err
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar comprovantes"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: domain.NewCursorPage(receipts, page, func(rc domain.PunchReceipt) domain.Cursor {
+		return domain.Cursor{Key: strconv.FormatInt(rc.NSR, 10), ID: rc.ID.String()}
+	})}, nil
+}
+
+func (r *ReceiptRepository) getOne(ctx context.Context, query string, args pgx.StrictNamedArgs) (domain.DBResponse, error) {
+	receipt, err := scanReceipt(r.DB.QueryRow(ctx, query, args))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "comprovante não encontrado"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao buscar comprovante"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: receipt}, nil
+}
+
+func scanReceipt(row pgx.Row) (domain.PunchReceipt, error) {
+	var receipt domain.PunchReceipt
+	err := row.Scan(
+		&receipt.ID,
+		&receipt.EntryID,
+		&receipt.OrganizationID,
+		&receipt.UserID,
+		&receipt.NSR,
+		&receipt.EmployeeName,
+		&receipt.OrganizationName,
+		&receipt.OrganizationAddress,
+		&receipt.TypeID,
+		&receipt.Timestamp,
+		&receipt.VerificationCode,
+		&receipt.CreatedAt,
+	)
+	return receipt, err
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+type ScheduleRepository struct {
+	DB *pgxpool.Pool
+}
+
+func NewScheduleRepository(db *pgxpool.Pool) *ScheduleRepository {
+	return &ScheduleRepository{db}
+}
+
+func (r *ScheduleRepository) Create(ctx context.Context, orgID uuid.UUID, cs domain.CreateWorkSchedule) (domain.DBResponse, error) {
+	const query = `
+		INSERT INTO work_schedules (organization_id, code, daily_minutes, entry1, exit1, entry2, exit2)
+		VALUES (@orgID, @code, @dailyMinutes, @entry1, @exit1, @entry2, @exit2)
+		RETURNING id, organization_id, code, daily_minutes, entry1, exit1, entry2, exit2, created_at
+	`
+	args := pgx.StrictNamedArgs{
+		"orgID":        orgID,
+		"code":         cs.Code,
+		"dailyMinutes": cs.DailyMinutes,
+		"entry1":       cs.Entry1,
+		"exit1":        cs.Exit1,
+		"entry2":       cs.Entry2,
+		"exit2":        cs.Exit2,
+	}
+
+	var s domain.WorkSchedule
+	err := r.DB.QueryRow(ctx, query, args).Scan(
+		&s.ID, &s.OrganizationID, &s.Code, &s.DailyMinutes, &s.Entry1, &s.Exit1, &s.Entry2, &s.Exit2, &s.CreatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.DBResponse{Success: false, Message: "já existe um horário com este código"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao criar horário contratual"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: s}, nil
+}
+
+// ListByOrganization retrieves a page of the organization schedules ordered by
+// code. It joins the context transaction if there is one.
+func (r *ScheduleRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID, page domain.PageRequest) (domain.DBResponse, error) {
+	const query = `
+		SELECT id, organization_id, code, daily_minutes, entry1, exit1, entry2, exit2, created_at
+		FROM work_schedules
+		WHERE organization_id = @orgID
+			AND (@cursorKey::text IS NULL OR (code, id) > (@cursorKey::text, @cursorID::uuid))
+		ORDER BY code, id
+		LIMIT NULLIF(@limit::int, 0)
+	`
+
+	rows, err := conn(ctx, r.DB).Query(ctx, query, withPage(pgx.StrictNamedArgs{"orgID": orgID}, page))
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar horários contratuais"}, err
+	}
+	defer rows.Close()
+
+	schedules := []domain.WorkSchedule{}
+	for rows.Next() {
+		var s domain.WorkSchedule
+		err := rows.Scan(&s.ID, &s.OrganizationID, &s.Code, &s.DailyMinutes, &s.Entry1, &s.Exit1, &s.Entry2, &s.Exit2, &s.CreatedAt)
+		if err != nil {
+			return domain.DBResponse{Message: "erro ao ler horário contratual"}, err
+		}
+		schedules = append(schedules, s)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar horários contratuais"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: domain.NewCursorPage(schedules, page, func(s domain.WorkSchedule) domain.Cursor {
+		return domain.Cursor{Key: s.Code, ID: s.ID.String()}
+	})}, nil
+}
+
+func (r *ScheduleRepository) Delete(ctx context.Context, orgID, scheduleID uuid.UUID) (domain.DBResponse, error) {
+	const query = `DELETE FROM work_schedules WHERE id = @id AND organization_id = @orgID`
+
+	tag, err := r.DB.Exec(ctx, query, pgx.StrictNamedArgs{"id": scheduleID, "orgID": orgID})
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao remover horário contratual"}, err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.DBResponse{Success: false, Message: "horário contratual não encontrado"}, nil
+	}
+
+	return domain.DBResponse{Success: true, Message: "horário contratual removido com sucesso"}, nil
+}
+
+// AssignToMember sets the contracted schedule of an organization member
+func (r *ScheduleRepository) AssignToMember(ctx context.Context, orgID, userID uuid.UUID, scheduleID *uuid.UUID) (domain.DBResponse, error) {
+	const query = `
+		UPDATE organization_users
+		SET work_schedule_id = @scheduleID
+		WHERE organization_id = @orgID AND user_id = @userID
+			AND (@scheduleID::uuid IS NULL OR EXISTS (
+				SELECT 1 FROM work_schedules WHERE id = @scheduleID::uuid AND organization_id = @orgID
+			))
+	`
+	args := pgx.StrictNamedArgs{
+		"orgID":      orgID,
+		"userID":     userID,
+		"scheduleID": scheduleID,
+	}
+
+	tag, err := r.DB.Exec(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao atribuir horário contratual"}, err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.DBResponse{Success: false, Message: "membro ou horário contratual não encontrado"}, nil
+	}
+
+	return domain.DBResponse{Success: true, Message: "horário contratual atribuído com sucesso"}, nil
+}
+
+// ListMemberSchedules retrieves the organization members with CPF and contracted
+// schedule. It joins the context transaction if there is one.
+func (r *ScheduleRepository) ListMemberSchedules(ctx context.Context, orgID uuid.UUID) (domain.DBResponse, error) {
+	const query = `
+		SELECT u.id, u.name, u.cpf, ou.work_schedule_id
+		FROM organization_users ou
+		JOIN users u ON u.id = ou.user_id
+		WHERE ou.organization_id = @orgID
+		ORDER BY u.name
+	`
+
+	rows, err := conn(ctx, r.DB).Query(ctx, query, pgx.StrictNamedArgs{"orgID": orgID})
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar membros"}, err
+	}
+	defer rows.Close()
+
+	members := []domain.MemberSchedule{}
+	for rows.Next() {
+		var m domain.MemberSchedule
+		if err := rows.Scan(&m.UserID, &m.Name, &m.CPF, &m.ScheduleID); err != nil {
+			return domain.DBResponse{Message: "erro ao ler membro"}, err
+		}
+		members = append(members, m)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar membros"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: members}, nil
+}
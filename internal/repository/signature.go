@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+type SignatureRepository struct {
+	DB *pgxpool.Pool
+}
+
+func NewSignatureRepository(db *pgxpool.Pool) *SignatureRepository {
+	return &SignatureRepository{db}
+}
+
+const signatureColumns = `
+	id,
+	organization_id,
+	user_id,
+	month,
+	document_hash,
+	ip,
+	user_agent,
+	signed_at
+`
+
+func (r *SignatureRepository) Create(ctx context.Context, sig domain.TimesheetSignature) (domain.DBResponse, error) {
+	query := `
+		INSERT INTO timesheet_signatures (organization_id, user_id, month, document_hash, ip, user_agent)
+		VALUES (@orgID, @userID, @month, @hash, @ip, @userAgent)
+		RETURNING` + signatureColumns
+	args := pgx.StrictNamedArgs{
+		"orgID":     sig.OrganizationID,
+		"userID":    sig.UserID,
+		"month":     sig.Month,
+		"hash":      sig.DocumentHash,
+		"ip":        sig.IP,
+		"userAgent": sig.UserAgent,
+	}
+
+	created, err := scanSignature(r.DB.QueryRow(ctx, query, args))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.DBResponse{Success: false, Message: "o espelho deste mês já foi assinado"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao registrar assinatura"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: created}, nil
+}
+
+// Get retrieves the user signature of a month. Data is nil when the espelho was not signed.
+func (r *SignatureRepository) Get(ctx context.Context, orgID, userID uuid.UUID, month time.Time) (domain.DBResponse, error) {
+	query := `SELECT` + signatureColumns + `
+		FROM timesheet_signatures
+		WHERE organization_id = @orgID AND user_id = @userID AND month = @month
+	`
+	args := pgx.StrictNamedArgs{
+		"orgID":  orgID,
+		"userID": userID,
+		"month":  month,
+	}
+
+	sig, err := scanSignature(r.DB.QueryRow(ctx, query, args))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: true, Data: (*domain.TimesheetSignature)(nil)}, nil
+		}
+		return domain.DBResponse{Message: "erro ao buscar assinatura"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: &sig}, nil
+}
+
+// ListByMonth retrieves the signatures of a month in the organization
+func (r *SignatureRepository) ListByMonth(ctx context.Context, orgID uuid.UUID, month time.Time) (domain.DBResponse, error) {
+	query := `SELECT` + signatureColumns + `
+		FROM timesheet_signatures
+		WHERE organization_id = @orgID AND month = @month
+	`
+	args := pgx.StrictNamedArgs{
+		"orgID": orgID,
+		"month": month,
+	}
+
+	rows, err := r.DB.Query(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar assinaturas"}, err
+	}
+	defer rows.Close()
+
+	signatures := []domain.TimesheetSignature{}
+	for rows.Next() {
+		sig, err := scanSignature(rows)
+		if err != nil {
+			return domain.DBResponse{Message: "erro ao ler assinatura"}, err
+		}
+		signatures = append(signatures, sig)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar assinaturas"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: signatures}, nil
+}
+
+func scanSignature(row pgx.Row) (domain.TimesheetSignature, error) {
+	var sig domain.TimesheetSignature
+	err := row.Scan(
+		&sig.ID,
+		&sig.OrganizationID,
+		&sig.UserID,
+		&sig.Month,
+		&sig.DocumentHash,
+		&sig.IP,
+		&sig.UserAgent,
+		&sig.SignedAt,
+	)
+	return sig, err
+}
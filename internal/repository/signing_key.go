@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+type SigningKeyRepository struct {
+	DB *pgxpool.Pool
+}
+
+func NewSigningKeyRepository(db *pgxpool.Pool) *SigningKeyRepository {
+	return &SigningKeyRepository{db}
+}
+
+// Get retrieves the organization signing key
+func (r *SigningKeyRepository) Get(ctx context.Context, orgID uuid.UUID) (domain.DBResponse, error) {
+	const query = `
+		SELECT organization_id, algorithm, public_key, private_key, created_at
+		FROM organization_signing_keys
+		WHERE organization_id = @orgID
+	`
+
+	var key domain.SigningKey
+	var publicKey, privateKey []byte
+	err := r.DB.QueryRow(ctx, query, pgx.StrictNamedArgs{"orgID": orgID}).Scan(
+		&key.OrganizationID,
+		&key.Algorithm,
+		&publicKey,
+		&privateKey,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "chave de assinatura não encontrada"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao buscar chave de assinatura"}, err
+	}
+
+	key.PublicKey = publicKey
+	key.PrivateKey = privateKey
+
+	return domain.DBResponse{Success: true, Data: key}, nil
+}
+
+// Create stores the organization signing key. When a key already exists it is
+// kept and returned, so concurrent callers all end up with the same key.
+func (r *SigningKeyRepository) Create(ctx context.Context, key domain.SigningKey) (domain.DBResponse, error) {
+	const query = `
+		INSERT INTO organization_signing_keys (organization_id, algorithm, public_key, private_key)
+		VALUES (@orgID, @algorithm, @publicKey, @privateKey)
+		ON CONFLICT (organization_id) DO NOTHING
+	`
+	args := pgx.StrictNamedArgs{
+		"orgID":      key.OrganizationID,
+		"algorithm":  key.Algorithm,
+		"publicKey":  []byte(key.PublicKey),
+		"privateKey": []byte(key.PrivateKey),
+	}
+
+	_, err := r.DB.Exec(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao criar chave de assinatura"}, err
+	}
+
+	return r.Get(ctx, key.OrganizationID)
+}
@@ -0,0 +1,296 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+type TeamRepository struct {
+	DB *pgxpool.Pool
+}
+
+func NewTeamRepository(db *pgxpool.Pool) *TeamRepository {
+	return &TeamRepository{db}
+}
+
+func (r *TeamRepository) Create(ctx context.Context, orgID uuid.UUID, ct domain.CreateTeam) (domain.DBResponse, error) {
+	const query = `
+		INSERT INTO teams (organization_id, name)
+		VALUES (@orgID, @name)
+		RETURNING id
+	`
+	args := pgx.StrictNamedArgs{
+		"orgID": orgID,
+		"name":  ct.Name,
+	}
+
+	var teamID uuid.UUID
+	err := r.DB.QueryRow(ctx, query, args).Scan(&teamID)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao criar equipe"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: teamID}, nil
+}
+
+func (r *TeamRepository) Delete(ctx context.Context, orgID, teamID uuid.UUID) (domain.DBResponse, error) {
+	const query = `
+		DELETE FROM teams
+		WHERE id = @teamID AND organization_id = @orgID
+	`
+	args := pgx.StrictNamedArgs{
+		"teamID": teamID,
+		"orgID":  orgID,
+	}
+
+	res, err := r.DB.Exec(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao deletar equipe"}, err
+	}
+
+	if res.RowsAffected() != 1 {
+		return domain.DBResponse{Message: "equipe não encontrada"}, nil
+	}
+
+	return domain.DBResponse{Success: true}, nil
+}
+
+// GetByID retrieves a team of the organization with its members
+func (r *TeamRepository) GetByID(ctx context.Context, orgID, teamID uuid.UUID) (domain.DBResponse, error) {
+	const query = `
+		SELECT id, organization_id, name, created_at
+		FROM teams
+		WHERE id = @teamID AND organization_id = @orgID
+	`
+	var team domain.Team
+	err := r.DB.QueryRow(ctx, query, pgx.StrictNamedArgs{"teamID": teamID, "orgID": orgID}).Scan(&team.ID, &team.OrganizationID, &team.Name, &team.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return domain.DBResponse{Message: "equipe não encontrada"}, nil
+	}
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar equipe"}, err
+	}
+
+	teams := []domain.Team{team}
+	if res, err := r.loadMembers(ctx, orgID, teams); err != nil {
+		return res, err
+	}
+
+	return domain.DBResponse{Success: true, Data: teams[0]}, nil
+}
+
+// ListByOrganization retrieves a page of the organization teams, ordered by
+// name, with their members. Members are loaded in a single query and grouped in memory.
+func (r *TeamRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID, page domain.PageRequest) (domain.DBResponse, error) {
+	const teamsQuery = `
+		SELECT id, organization_id, name, created_at
+		FROM teams
+		WHERE organization_id = @orgID
+			AND (@cursorKey::text IS NULL OR (name, id) > (@cursorKey::text, @cursorID::uuid))
+		ORDER BY name, id
+		LIMIT NULLIF(@limit::int, 0)
+	`
+	rows, err := r.DB.Query(ctx, teamsQuery, withPage(pgx.StrictNamedArgs{"orgID": orgID}, page))
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar equipes"}, err
+	}
+	defer rows.Close()
+
+	teams := []domain.Team{}
+	for rows.Next() {
+		var team domain.Team
+		if err := rows.Scan(&team.ID, &team.OrganizationID, &team.Name, &team.CreatedAt); err != nil {
+			return domain.DBResponse{Message: "erro ao ler equipe"}, err
+		}
+		teams = append(teams, team)
+	}
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar equipes"}, rows.Err()
+	}
+
+	if res, err := r.loadMembers(ctx, orgID, teams); err != nil {
+		return res, err
+	}
+
+	return domain.DBResponse{Success: true, Data: domain.NewCursorPage(teams, page, func(t domain.Team) domain.Cursor {
+		return domain.Cursor{Key: t.Name, ID: t.ID.String()}
+	})}, nil
+}
+
+// loadMembers fills the members of the organization teams in a single query
+func (r *TeamRepository) loadMembers(ctx context.Context, orgID uuid.UUID, teams []domain.Team) (domain.DBResponse, error) {
+	teamIDs := make([]uuid.UUID, len(teams))
+	index := map[uuid.UUID]int{}
+	for i := range teams {
+		teams[i].Members = []domain.TeamMember{}
+		teamIDs[i] = teams[i].ID
+		index[teams[i].ID] = i
+	}
+
+	const membersQuery = `
+		SELECT
+			tm.team_id,
+			u.id,
+			u.name,
+			u.email,
+			r.name,
+			tm.joined_at
+		FROM team_members tm
+		JOIN teams t ON t.id = tm.team_id
+		JOIN users u ON u.id = tm.user_id
+		JOIN organization_users ou ON ou.user_id = u.id AND ou.organization_id = t.organization_id
+		JOIN organization_roles r ON r.id = ou.organization_role_id
+		WHERE t.organization_id = @orgID AND tm.team_id = ANY(@teamIDs::uuid[])
+		ORDER BY u.name
+	`
+	memberRows, err := r.DB.Query(ctx, membersQuery, pgx.StrictNamedArgs{"orgID": orgID, "teamIDs": teamIDs})
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar membros das equipes"}, err
+	}
+	defer memberRows.Close()
+
+	for memberRows.Next() {
+		var teamID uuid.UUID
+		var member domain.TeamMember
+		var roleStr string
+		if err := memberRows.Scan(&teamID, &member.UserID, &member.Name, &member.Email, &roleStr, &member.JoinedAt); err != nil {
+			return domain.DBResponse{Message: "erro ao ler membro da equipe"}, err
+		}
+
+		role, err := domain.ParseRole(roleStr)
+		if err != nil {
+			continue // Skip invalid roles
+		}
+		member.Role = role
+
+		i, ok := index[teamID]
+		if !ok {
+			continue
+		}
+		teams[i].Members = append(teams[i].Members, member)
+	}
+	if memberRows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar membros das equipes"}, memberRows.Err()
+	}
+
+	return domain.DBResponse{Success: true}, nil
+}
+
+// AddMember assigns an organization member to a team of the same organization
+func (r *TeamRepository) AddMember(ctx context.Context, orgID, teamID, userID uuid.UUID) (domain.DBResponse, error) {
+	const query = `
+		INSERT INTO team_members (team_id, user_id)
+		SELECT t.id, ou.user_id
+		FROM teams t
+		JOIN organization_users ou ON ou.organization_id = t.organization_id
+		WHERE t.id = @teamID AND t.organization_id = @orgID AND ou.user_id = @userID
+		ON CONFLICT (team_id, user_id) DO NOTHING
+	`
+	args := pgx.StrictNamedArgs{
+		"teamID": teamID,
+		"orgID":  orgID,
+		"userID": userID,
+	}
+
+	res, err := r.DB.Exec(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao adicionar membro à equipe"}, err
+	}
+
+	if res.RowsAffected() != 1 {
+		return domain.DBResponse{Message: "usuário já pertence à equipe ou não é membro da organização"}, nil
+	}
+
+	return domain.DBResponse{Success: true}, nil
+}
+
+func (r *TeamRepository) RemoveMember(ctx context.Context, orgID, teamID, userID uuid.UUID) (domain.DBResponse, error) {
+	const query = `
+		DELETE FROM team_members tm
+		USING teams t
+		WHERE tm.team_id = t.id
+			AND t.id = @teamID
+			AND t.organization_id = @orgID
+			AND tm.user_id = @userID
+	`
+	args := pgx.StrictNamedArgs{
+		"teamID": teamID,
+		"orgID":  orgID,
+		"userID": userID,
+	}
+
+	res, err := r.DB.Exec(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao remover membro da equipe"}, err
+	}
+
+	if res.RowsAffected() != 1 {
+		return domain.DBResponse{Message: "usuário não encontrado na equipe"}, nil
+	}
+
+	return domain.DBResponse{Success: true}, nil
+}
+
+// GetUserTeamIDs returns the IDs of the organization teams the user belongs to
+func (r *TeamRepository) GetUserTeamIDs(ctx context.Context, userID, orgID uuid.UUID) (domain.DBResponse, error) {
+	const query = `
+		SELECT t.id
+		FROM teams t
+		JOIN team_members tm ON tm.team_id = t.id
+		WHERE tm.user_id = @userID AND t.organization_id = @orgID
+	`
+	args := pgx.StrictNamedArgs{
+		"userID": userID,
+		"orgID":  orgID,
+	}
+
+	rows, err := r.DB.Query(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar equipes do usuário"}, err
+	}
+	defer rows.Close()
+
+	teamIDs := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return domain.DBResponse{Message: "erro ao ler equipe do usuário"}, err
+		}
+		teamIDs = append(teamIDs, id)
+	}
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar equipes do usuário"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: teamIDs}, nil
+}
+
+// SharesTeam reports whether two users are assigned to at least one common team of the organization
+func (r *TeamRepository) SharesTeam(ctx context.Context, orgID, userID, otherUserID uuid.UUID) (domain.DBResponse, error) {
+	const query = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM team_members a
+			JOIN team_members b ON a.team_id = b.team_id
+			JOIN teams t ON t.id = a.team_id
+			WHERE t.organization_id = @orgID AND a.user_id = @userID AND b.user_id = @otherUserID
+		)
+	`
+	args := pgx.StrictNamedArgs{
+		"orgID":       orgID,
+		"userID":      userID,
+		"otherUserID": otherUserID,
+	}
+
+	var exists bool
+	err := r.DB.QueryRow(ctx, query, args).Scan(&exists)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao verificar equipes"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: exists}, nil
+}
@@ -2,10 +2,14 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/marcelorc13/timesheet-pro/internal/domain"
 )
@@ -18,10 +22,31 @@ func NewTimesheetRepository(db *pgxpool.Pool) *TimesheetRepository {
 	return &TimesheetRepository{db}
 }
 
-func (r TimesheetRepository) ClockIn(ctx context.Context, orgID, userID uuid.UUID) (domain.DBResponse, error) {
-	err := pgx.BeginFunc(ctx, r.DB, func(tx pgx.Tx) error {
-		now := time.Now()
-		today := now.Truncate(24 * time.Hour) 
+// ClockIn records the next punch of the user along with where it was made:
+// the client IP and kiosk device, or the location and fence of a punch from
+// the member session. The punch type follows the last entry of the day, so a
+// member on a break always comes back from it. Offline punches keep the
+// device time and take their place among the day entries, as long as the
+// punches after them keep their types. Refused punches return the domain
+// error: ErrBreakWithoutEntry, ErrPayPeriodClosed, ErrOfflinePunchSynced,
+// ErrOfflinePunchSequence or ErrOfflinePunchRetypes.
+func (r TimesheetRepository) ClockIn(ctx context.Context, orgID, userID uuid.UUID, origin domain.PunchOrigin) (domain.DBResponse, error) {
+	var record domain.PunchRecord
+
+	err := pgx.BeginFunc(ctx, conn(ctx, r.DB), func(tx pgx.Tx) error {
+		now := time.Now().Truncate(time.Microsecond)
+		timestamp := now
+		var syncedAt *time.Time
+		var clientPunchID *uuid.UUID
+		if origin.Offline != nil {
+			timestamp = origin.Offline.CapturedAt.Truncate(time.Microsecond)
+			syncedAt, clientPunchID = &now, &origin.Offline.ClientID
+		}
+		today := timestamp.Truncate(24 * time.Hour) 
+
+		if err := ensurePeriodOpen(ctx, tx, orgID, today, today); err != nil {
+			return err
+		}
 
 		var timesheetID uuid.UUID
 
@@ -50,37 +75,235 @@ func (r TimesheetRepository) ClockIn(ctx context.Context, orgID, userID uuid.UUI
 			return err
 		}
 
-		var count int
-		const countQuery = `SELECT count(*) FROM timesheet_entries WHERE timesheet_id = @id`
-		err = tx.QueryRow(ctx, countQuery, pgx.NamedArgs{"id": timesheetID}).Scan(&count)
+		var nextType domain.EntryType
+		if origin.Offline != nil {
+			const dayQuery = `
+				SELECT id, type_id, timestamp FROM timesheet_entries
+				WHERE timesheet_id = @id
+				ORDER BY timestamp
+			`
+			rows, err := tx.Query(ctx, dayQuery, pgx.NamedArgs{"id": timesheetID})
+			if err != nil {
+				return err
+			}
+			entries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.TimesheetEntry, error) {
+				var e domain.TimesheetEntry
+				err := row.Scan(&e.ID, &e.TypeID, &e.Timestamp)
+				return e, err
+			})
+			if err != nil {
+				return err
+			}
+
+			nextType, err = domain.PlaceOfflinePunch(entries, timestamp, origin.Break)
+			if err != nil {
+				return err
+			}
+		} else {
+			var last *domain.EntryType
+			const lastQuery = `
+				SELECT type_id FROM timesheet_entries
+				WHERE timesheet_id = @id
+				ORDER BY timestamp DESC
+				LIMIT 1
+			`
+			err = tx.QueryRow(ctx, lastQuery, pgx.NamedArgs{"id": timesheetID}).Scan(&last)
+			if err != nil && err != pgx.ErrNoRows {
+				return err
+			}
+
+			nextType, err = domain.NextEntryType(last, origin.Break)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Trava o topo da cadeia da organização para encadear a batida
+		const headQuery = `
+			INSERT INTO punch_chain_heads (organization_id) VALUES (@orgID)
+			ON CONFLICT (organization_id) DO UPDATE SET organization_id = EXCLUDED.organization_id
+			RETURNING last_sequence, last_hash
+		`
+		var lastSequence int64
+		var lastHash string
+		err = tx.QueryRow(ctx, headQuery, pgx.NamedArgs{"orgID": orgID}).Scan(&lastSequence, &lastHash)
 		if err != nil {
 			return err
 		}
 
-		nextType := 1 // "in"
-		if count%2 != 0 {
-			nextType = 2 // "out"
+		record = domain.PunchRecord{
+			Sequence:       lastSequence + 1,
+			EntryID:        uuid.New(),
+			OrganizationID: orgID,
+			UserID:         userID,
+			TimesheetID:    timesheetID,
+			TypeID:         nextType,
+			Timestamp:      timestamp,
+			PrevHash:       lastHash,
 		}
+		record.Hash = record.ComputeHash()
 
 		// 5. Inserir a Batida (Entry)
 		const insertEntryQuery = `
-			INSERT INTO timesheet_entries (timesheet_id, organization_id, timestamp, type_id)
-			VALUES (@sheetID, @orgID, @timestamp, @type)
+			INSERT INTO timesheet_entries (
+				id, timesheet_id, organization_id, timestamp, type_id, sequence, prev_hash, hash,
+				client_ip, kiosk_device_id, latitude, longitude, accuracy_meters, geofence_id, outside_geofence,
+				outside_network, offline_captured, client_punch_id, synced_at
+			)
+			VALUES (
+				@id, @sheetID, @orgID, @timestamp, @type, @sequence, @prevHash, @hash,
+				@clientIP, @deviceID, @latitude, @longitude, @accuracy, @geofenceID, @outsideGeofence,
+				@outsideNetwork, @offlineCaptured, @clientPunchID, @syncedAt
+			)
 		`
+		var latitude, longitude, accuracy *float64
+		if origin.Location != nil {
+			latitude, longitude = &origin.Location.Latitude, &origin.Location.Longitude
+			accuracy = origin.Location.Accuracy
+		}
 		args := pgx.StrictNamedArgs{
-			"sheetID":   timesheetID,
-			"orgID":   orgID,
-			"timestamp": now,
-			"type":      nextType,
+			"id":              record.EntryID,
+			"sheetID":         timesheetID,
+			"orgID":           orgID,
+			"timestamp":       timestamp,
+			"type":            nextType,
+			"sequence":        record.Sequence,
+			"prevHash":        record.PrevHash,
+			"hash":            record.Hash,
+			"clientIP":        origin.ClientIP,
+			"deviceID":        origin.KioskDeviceID,
+			"latitude":        latitude,
+			"longitude":       longitude,
+			"accuracy":        accuracy,
+			"geofenceID":      origin.GeofenceID,
+			"outsideGeofence": origin.OutsideGeofence,
+			"outsideNetwork":  origin.OutsideNetwork,
+			"offlineCaptured": origin.Offline != nil,
+			"clientPunchID":   clientPunchID,
+			"syncedAt":        syncedAt,
 		}
 		_, err = tx.Exec(ctx, insertEntryQuery, args)
+		if err != nil {
+			// Another sync of the same queue recorded the punch meanwhile
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "timesheet_entries_client_punch_id_idx" {
+				return domain.ErrOfflinePunchSynced
+			}
+			return err
+		}
+
+		const updateHeadQuery = `
+			UPDATE punch_chain_heads
+			SET last_sequence = @sequence, last_hash = @hash
+			WHERE organization_id = @orgID
+		`
+		_, err = tx.Exec(ctx, updateHeadQuery, pgx.StrictNamedArgs{
+			"orgID":    orgID,
+			"sequence": record.Sequence,
+			"hash":     record.Hash,
+		})
 		return err
 	})
 	if err != nil {
+		// Refusals keep the domain error, so callers can tell them apart with errors.Is
 		return domain.DBResponse{Message: err.Error()}, err
 	}
 
-	return domain.DBResponse{Success: true}, nil
+	return domain.DBResponse{Success: true, Data: record}, nil
+}
+
+// CheckOfflinePunch tells whether a queued punch was already recorded
+func (r *TimesheetRepository) CheckOfflinePunch(ctx context.Context, p domain.OfflinePunch) (domain.DBResponse, error) {
+	const query = `SELECT EXISTS (SELECT 1 FROM timesheet_entries WHERE client_punch_id = @clientID)`
+
+	var check domain.OfflinePunchCheck
+	err := r.DB.QueryRow(ctx, query, pgx.StrictNamedArgs{"clientID": p.ClientID}).Scan(&check.Synced)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao verificar registro offline"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: check}, nil
+}
+
+// GetPunchChain retrieves the organization hash chain ordered by sequence and
+// the head stored for it. Both are read in the same snapshot, so punches
+// recorded meanwhile do not show up as a mismatch between them.
+func (r *TimesheetRepository) GetPunchChain(ctx context.Context, orgID uuid.UUID) (domain.DBResponse, error) {
+	const query = `
+		SELECT
+			te.sequence,
+			te.id,
+			te.organization_id,
+			dt.user_id,
+			te.timesheet_id,
+			te.type_id,
+			te.timestamp,
+			te.prev_hash,
+			te.hash
+		FROM timesheet_entries te
+		JOIN daily_timesheets dt ON dt.id = te.timesheet_id
+		WHERE te.organization_id = @orgID AND te.sequence IS NOT NULL
+		ORDER BY te.sequence
+	`
+	const headQuery = `SELECT last_sequence, last_hash FROM punch_chain_heads WHERE organization_id = @orgID`
+
+	chain := domain.PunchChain{
+		Records: []domain.PunchRecord{},
+		Head:    domain.ChainHead{Hash: domain.GenesisHash},
+	}
+
+	txOptions := pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}
+	err := pgx.BeginTxFunc(ctx, r.DB, txOptions, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, headQuery, pgx.StrictNamedArgs{"orgID": orgID}).Scan(&chain.Head.Sequence, &chain.Head.Hash)
+		if err != nil && err != pgx.ErrNoRows {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, query, pgx.StrictNamedArgs{"orgID": orgID})
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var rec domain.PunchRecord
+			err := rows.Scan(
+				&rec.Sequence,
+				&rec.EntryID,
+				&rec.OrganizationID,
+				&rec.UserID,
+				&rec.TimesheetID,
+				&rec.TypeID,
+				&rec.Timestamp,
+				&rec.PrevHash,
+				&rec.Hash,
+			)
+			if err != nil {
+				return err
+			}
+			chain.Records = append(chain.Records, rec)
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar cadeia de registros"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: chain}, nil
+}
+
+// CountUnchainedEntries counts entries recorded before the hash chain existed
+func (r *TimesheetRepository) CountUnchainedEntries(ctx context.Context, orgID uuid.UUID) (domain.DBResponse, error) {
+	const query = `SELECT count(*) FROM timesheet_entries WHERE organization_id = @orgID AND sequence IS NULL`
+
+	var count int
+	err := r.DB.QueryRow(ctx, query, pgx.StrictNamedArgs{"orgID": orgID}).Scan(&count)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao contar registros"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: count}, nil
 }
 
 func (r *TimesheetRepository) GetUserTimesheet(ctx context.Context, userID, orgID uuid.UUID, date time.Time) (domain.DBResponse, error) {
@@ -135,7 +358,9 @@ func (r *TimesheetRepository) GetUserTimesheet(ctx context.Context, userID, orgI
 			timesheet_id,
 			organization_id,
 			type_id,
-			timestamp
+			timestamp,
+			kiosk_device_id,
+			offline_captured
 		FROM timesheet_entries
 		WHERE timesheet_id = @timesheetID
 		ORDER BY timestamp ASC
@@ -159,6 +384,8 @@ func (r *TimesheetRepository) GetUserTimesheet(ctx context.Context, userID, orgI
 			&entry.OrganizationID,
 			&entry.TypeID,
 			&entry.Timestamp,
+			&entry.KioskDeviceID,
+			&entry.OfflineCaptured,
 		)
 		if err != nil {
 			return domain.DBResponse{Success: false, Message: "erro ao ler entrada do timesheet"}, err
@@ -222,7 +449,9 @@ func (r *TimesheetRepository) GetTimesheetByID(ctx context.Context, timesheetID
 			timesheet_id,
 			organization_id,
 			type_id,
-			timestamp
+			timestamp,
+			kiosk_device_id,
+			offline_captured
 		FROM timesheet_entries
 		WHERE timesheet_id = @timesheetID
 		ORDER BY timestamp ASC
@@ -246,6 +475,8 @@ func (r *TimesheetRepository) GetTimesheetByID(ctx context.Context, timesheetID
 			&entry.OrganizationID,
 			&entry.TypeID,
 			&entry.Timestamp,
+			&entry.KioskDeviceID,
+			&entry.OfflineCaptured,
 		)
 		if err != nil {
 			return domain.DBResponse{Success: false, Message: "erro ao ler entrada do timesheet"}, err
@@ -262,8 +493,9 @@ func (r *TimesheetRepository) GetTimesheetByID(ctx context.Context, timesheetID
 	return domain.DBResponse{Success: true, Data: timesheet}, nil
 }
 
-// GetUserTimesheets retrieves all timesheets for a specific user within a date range
-func (r *TimesheetRepository) GetUserTimesheets(ctx context.Context, userID, orgID uuid.UUID, startDate, endDate time.Time) (domain.DBResponse, error) {
+// GetUserTimesheets retrieves a page of the timesheets of a user within a date range,
+// most recent first
+func (r *TimesheetRepository) GetUserTimesheets(ctx context.Context, userID, orgID uuid.UUID, startDate, endDate time.Time, page domain.PageRequest) (domain.DBResponse, error) {
 	start := startDate.Truncate(24 * time.Hour)
 	end := endDate.Truncate(24 * time.Hour)
 
@@ -284,22 +516,23 @@ func (r *TimesheetRepository) GetUserTimesheets(ctx context.Context, userID, org
 			AND dt.organization_id = @orgID 
 			AND dt.date >= @startDate
 			AND dt.date <= @endDate
-		ORDER BY dt.date DESC
+			AND (@cursorKey::date IS NULL OR (dt.date, dt.id) < (@cursorKey::date, @cursorID::uuid))
+		ORDER BY dt.date DESC, dt.id DESC
+		LIMIT NULLIF(@limit::int, 0)
 	`
-	args := pgx.NamedArgs{
+	args := withPage(pgx.StrictNamedArgs{
 		"userID":    userID,
 		"orgID":     orgID,
 		"startDate": start,
 		"endDate":   end,
-	}
+	}, page)
 
 	rows, err := r.DB.Query(ctx, query, args)
 	if err != nil {
 		return domain.DBResponse{Success: false, Message: "erro ao buscar timesheets"}, err
 	}
-	defer rows.Close()
 
-	var timesheets []domain.UserTimesheet
+	timesheets := []domain.UserTimesheet{}
 	for rows.Next() {
 		var ts domain.UserTimesheet
 		err := rows.Scan(
@@ -314,66 +547,74 @@ func (r *TimesheetRepository) GetUserTimesheets(ctx context.Context, userID, org
 			&ts.UserEmail,
 		)
 		if err != nil {
+			rows.Close()
 			return domain.DBResponse{Success: false, Message: "erro ao ler timesheet"}, err
 		}
-		
-		// Get entries for this timesheet
-		const entriesQuery = `
-			SELECT 
-				id,
-				timesheet_id,
-				organization_id,
-				type_id,
-				timestamp
-			FROM timesheet_entries
-			WHERE timesheet_id = @timesheetID
-			ORDER BY timestamp ASC
-		`
-		entriesRows, err := r.DB.Query(ctx, entriesQuery, pgx.NamedArgs{"timesheetID": ts.ID})
-		if err != nil {
-			return domain.DBResponse{Success: false, Message: "erro ao buscar entradas"}, err
-		}
-
-		var entries []domain.TimesheetEntry
-		for entriesRows.Next() {
-			var entry domain.TimesheetEntry
-			err := entriesRows.Scan(
-				&entry.ID,
-				&entry.TimesheetID,
-				&entry.OrganizationID,
-				&entry.TypeID,
-				&entry.Timestamp,
-			)
-			if err != nil {
-				entriesRows.Close()
-				return domain.DBResponse{Success: false, Message: "erro ao ler entrada"}, err
-			}
-			entries = append(entries, entry)
-		}
-		entriesRows.Close()
-
-		ts.Entries = entries
 		timesheets = append(timesheets, ts)
 	}
+	rows.Close()
 
 	if rows.Err() != nil {
 		return domain.DBResponse{Success: false, Message: "erro ao processar timesheets"}, rows.Err()
 	}
 
-	// Return empty slice if no timesheets found
-	if timesheets == nil {
-		timesheets = []domain.UserTimesheet{}
+	if err := r.loadEntries(ctx, timesheets); err != nil {
+		return domain.DBResponse{Success: false, Message: "erro ao buscar entradas"}, err
 	}
 
-	return domain.DBResponse{Success: true, Data: timesheets}, nil
+	return domain.DBResponse{Success: true, Data: domain.NewCursorPage(timesheets, page, timesheetCursor)}, nil
 }
 
-// GetOrganizationTimesheets retrieves all timesheets for an organization on a specific date
-func (r *TimesheetRepository) GetOrganizationTimesheets(ctx context.Context, orgID uuid.UUID, date time.Time) (domain.DBResponse, error) {
-	dateOnly := date.Truncate(24 * time.Hour)
+// organizationTimesheetsFilter restricts daily_timesheets dt to a TimesheetQuery.
+// When @teamIDs is not empty only members of those teams are returned.
+const organizationTimesheetsFilter = `
+	dt.organization_id = @orgID
+	AND dt.date >= @start
+	AND dt.date <= @end
+	AND (@userID::uuid IS NULL OR dt.user_id = @userID::uuid)
+	AND (@status::smallint = 0 OR dt.status_id = @status::smallint)
+	AND (
+		cardinality(@teamIDs::uuid[]) = 0
+		OR dt.user_id IN (SELECT user_id FROM team_members WHERE team_id = ANY(@teamIDs::uuid[]))
+	)
+`
 
-	const query = `
-		SELECT 
+// timesheetOrderBy maps the sortable columns to their ORDER BY clause
+var timesheetOrderBy = map[string]string{
+	"date":   "dt.date %s, u.name ASC",
+	"name":   "u.name %s, dt.date DESC",
+	"status": "dt.status_id %s, dt.date DESC, u.name ASC",
+}
+
+func organizationTimesheetsArgs(orgID uuid.UUID, q domain.TimesheetQuery, teamIDs []uuid.UUID) pgx.StrictNamedArgs {
+	if teamIDs == nil {
+		teamIDs = []uuid.UUID{}
+	}
+	return pgx.StrictNamedArgs{
+		"orgID":   orgID,
+		"start":   q.Start,
+		"end":     q.End,
+		"userID":  q.MemberID,
+		"status":  int16(q.Status),
+		"teamIDs": teamIDs,
+	}
+}
+
+// GetOrganizationTimesheets retrieves a page of the organization timesheets
+// matching q, with their entries. When teamIDs is not empty only members of
+// those teams are returned.
+func (r *TimesheetRepository) GetOrganizationTimesheets(ctx context.Context, orgID uuid.UUID, q domain.TimesheetQuery, teamIDs []uuid.UUID) (domain.DBResponse, error) {
+	orderBy, ok := timesheetOrderBy[q.Sort]
+	if !ok {
+		orderBy = timesheetOrderBy["date"]
+	}
+	direction := "ASC"
+	if q.Desc {
+		direction = "DESC"
+	}
+
+	query := `
+		SELECT
 			dt.id,
 			dt.user_id,
 			dt.organization_id,
@@ -381,26 +622,25 @@ func (r *TimesheetRepository) GetOrganizationTimesheets(ctx context.Context, org
 			dt.status_id,
 			dt.total_minutes,
 			dt.created_at,
-			u.name as user_name,
-			u.email as user_email
+			u.name,
+			u.email,
+			COUNT(*) OVER ()
 		FROM daily_timesheets dt
 		JOIN users u ON dt.user_id = u.id
-		WHERE dt.organization_id = @orgID 
-			AND dt.date = @date
-		ORDER BY u.name ASC
+		WHERE` + organizationTimesheetsFilter + `
+		ORDER BY ` + fmt.Sprintf(orderBy, direction) + `, dt.id
+		LIMIT @limit OFFSET @offset
 	`
-	args := pgx.NamedArgs{
-		"orgID": orgID,
-		"date":  dateOnly,
-	}
+	args := organizationTimesheetsArgs(orgID, q, teamIDs)
+	args["limit"] = q.PageSize
+	args["offset"] = (q.Page - 1) * q.PageSize
 
 	rows, err := r.DB.Query(ctx, query, args)
 	if err != nil {
 		return domain.DBResponse{Success: false, Message: "erro ao buscar timesheets da organização"}, err
 	}
-	defer rows.Close()
 
-	var timesheets []domain.UserTimesheet
+	page := domain.TimesheetPage{Timesheets: []domain.UserTimesheet{}, Page: q.Page, PageSize: q.PageSize}
 	for rows.Next() {
 		var ts domain.UserTimesheet
 		err := rows.Scan(
@@ -413,58 +653,540 @@ func (r *TimesheetRepository) GetOrganizationTimesheets(ctx context.Context, org
 			&ts.CreatedAt,
 			&ts.UserName,
 			&ts.UserEmail,
+			&page.Total,
 		)
 		if err != nil {
+			rows.Close()
 			return domain.DBResponse{Success: false, Message: "erro ao ler timesheet"}, err
 		}
+		page.Timesheets = append(page.Timesheets, ts)
+	}
+	rows.Close()
 
-		// Get entries for this timesheet
-		const entriesQuery = `
-			SELECT 
-				id,
-				timesheet_id,
-				organization_id,
-				type_id,
-				timestamp
-			FROM timesheet_entries
-			WHERE timesheet_id = @timesheetID
-			ORDER BY timestamp ASC
-		`
-		entriesRows, err := r.DB.Query(ctx, entriesQuery, pgx.NamedArgs{"timesheetID": ts.ID})
+	if rows.Err() != nil {
+		return domain.DBResponse{Success: false, Message: "erro ao processar timesheets"}, rows.Err()
+	}
+
+	if err := r.loadEntries(ctx, page.Timesheets); err != nil {
+		return domain.DBResponse{Success: false, Message: "erro ao buscar entradas"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: page}, nil
+}
+
+// timesheetKeyset describes how a sortable column is compared by the keyset
+// pagination and how a timesheet formats its value in a cursor
+type timesheetKeyset struct {
+	column string
+	cast   string
+	key    func(domain.UserTimesheet) string
+}
+
+var timesheetKeysets = map[string]timesheetKeyset{
+	"date": {"dt.date", "date", func(ts domain.UserTimesheet) string { return ts.Date.Format("2006-01-02") }},
+	"name": {"u.name", "text", func(ts domain.UserTimesheet) string { return ts.UserName }},
+	"status": {"dt.status_id", "smallint", func(ts domain.UserTimesheet) string {
+		return strconv.Itoa(int(ts.StatusID))
+	}},
+}
+
+// ListOrganizationTimesheets retrieves a keyset paginated page of the
+// organization timesheets matching q, with their entries. Rows are ordered by
+// the sort column of q and then by ID, so the cursor is only valid for the
+// sort and order it was issued with.
+func (r *TimesheetRepository) ListOrganizationTimesheets(ctx context.Context, orgID uuid.UUID, q domain.TimesheetQuery, teamIDs []uuid.UUID, page domain.PageRequest) (domain.DBResponse, error) {
+	keyset, ok := timesheetKeysets[q.Sort]
+	if !ok {
+		keyset = timesheetKeysets["date"]
+	}
+	direction, comparison := "ASC", ">"
+	if q.Desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	query := `
+		SELECT
+			dt.id,
+			dt.user_id,
+			dt.organization_id,
+			dt.date,
+			dt.status_id,
+			dt.total_minutes,
+			dt.created_at,
+			u.name,
+			u.email
+		FROM daily_timesheets dt
+		JOIN users u ON dt.user_id = u.id
+		WHERE` + organizationTimesheetsFilter + fmt.Sprintf(`
+			AND (@cursorKey::%[2]s IS NULL OR (%[1]s, dt.id) %[3]s (@cursorKey::%[2]s, @cursorID::uuid))
+		ORDER BY %[1]s %[4]s, dt.id %[4]s
+		LIMIT NULLIF(@limit::int, 0)
+	`, keyset.column, keyset.cast, comparison, direction)
+
+	rows, err := r.DB.Query(ctx, query, withPage(organizationTimesheetsArgs(orgID, q, teamIDs), page))
+	if err != nil {
+		return domain.DBResponse{Success: false, Message: "erro ao buscar timesheets da organização"}, err
+	}
+
+	timesheets := []domain.UserTimesheet{}
+	for rows.Next() {
+		var ts domain.UserTimesheet
+		err := rows.Scan(
+			&ts.ID,
+			&ts.UserID,
+			&ts.OrganizationID,
+			&ts.Date,
+			&ts.StatusID,
+			&ts.TotalMinutes,
+			&ts.CreatedAt,
+			&ts.UserName,
+			&ts.UserEmail,
+		)
 		if err != nil {
-			return domain.DBResponse{Success: false, Message: "erro ao buscar entradas"}, err
-		}
-
-		var entries []domain.TimesheetEntry
-		for entriesRows.Next() {
-			var entry domain.TimesheetEntry
-			err := entriesRows.Scan(
-				&entry.ID,
-				&entry.TimesheetID,
-				&entry.OrganizationID,
-				&entry.TypeID,
-				&entry.Timestamp,
-			)
-			if err != nil {
-				entriesRows.Close()
-				return domain.DBResponse{Success: false, Message: "erro ao ler entrada"}, err
-			}
-			entries = append(entries, entry)
+			rows.Close()
+			return domain.DBResponse{Success: false, Message: "erro ao ler timesheet"}, err
 		}
-		entriesRows.Close()
-
-		ts.Entries = entries
 		timesheets = append(timesheets, ts)
 	}
+	rows.Close()
 
 	if rows.Err() != nil {
 		return domain.DBResponse{Success: false, Message: "erro ao processar timesheets"}, rows.Err()
 	}
 
-	// Return empty slice if no timesheets found
-	if timesheets == nil {
-		timesheets = []domain.UserTimesheet{}
+	if err := r.loadEntries(ctx, timesheets); err != nil {
+		return domain.DBResponse{Success: false, Message: "erro ao buscar entradas"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: domain.NewCursorPage(timesheets, page, func(ts domain.UserTimesheet) domain.Cursor {
+		return domain.Cursor{Key: keyset.key(ts), ID: ts.ID.String()}
+	})}, nil
+}
+
+// SummarizeTeams computes, for each team in teamIDs, how many timesheets
+// matching q its members have and their worked minutes. Worked minutes follow
+// DailyTimesheet.WorkedMinutes: each entry or break return immediately
+// followed by an exit or break, plus the breaks up to PaidBreakLimit.
+func (r *TimesheetRepository) SummarizeTeams(ctx context.Context, orgID uuid.UUID, q domain.TimesheetQuery, teamIDs []uuid.UUID) (domain.DBResponse, error) {
+	query := `
+		WITH filtered AS (
+			SELECT dt.id, dt.user_id
+			FROM daily_timesheets dt
+			WHERE` + organizationTimesheetsFilter + `
+		),
+		pairs AS (
+			SELECT
+				te.timesheet_id,
+				te.type_id,
+				te.timestamp,
+				LEAD(te.type_id) OVER w AS next_type,
+				LEAD(te.timestamp) OVER w AS next_timestamp
+			FROM timesheet_entries te
+			JOIN filtered f ON f.id = te.timesheet_id
+			WINDOW w AS (PARTITION BY te.timesheet_id ORDER BY te.timestamp)
+		),
+		worked AS (
+			SELECT timesheet_id, FLOOR(SUM(EXTRACT(EPOCH FROM next_timestamp - timestamp)) / 60)::bigint AS minutes
+			FROM pairs
+			WHERE (type_id IN (1, 4) AND next_type IN (2, 3))
+				OR (type_id = 3 AND next_type = 4 AND next_timestamp - timestamp <= @paidBreakLimit::interval)
+			GROUP BY timesheet_id
+		)
+		SELECT tm.team_id, COUNT(f.id), COALESCE(SUM(w.minutes), 0)::bigint
+		FROM filtered f
+		JOIN team_members tm ON tm.user_id = f.user_id
+		LEFT JOIN worked w ON w.timesheet_id = f.id
+		WHERE tm.team_id = ANY(@summaryTeamIDs::uuid[])
+		GROUP BY tm.team_id
+	`
+	args := organizationTimesheetsArgs(orgID, q, nil)
+	args["summaryTeamIDs"] = teamIDs
+	args["paidBreakLimit"] = domain.PaidBreakLimit
+
+	rows, err := r.DB.Query(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao calcular totais das equipes"}, err
+	}
+	defer rows.Close()
+
+	totals := map[uuid.UUID]domain.TeamTotal{}
+	for rows.Next() {
+		var t domain.TeamTotal
+		if err := rows.Scan(&t.TeamID, &t.Timesheets, &t.TotalMinutes); err != nil {
+			return domain.DBResponse{Message: "erro ao ler totais das equipes"}, err
+		}
+		totals[t.TeamID] = t
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar totais das equipes"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: totals}, nil
+}
+
+// timesheetCursor points at a timesheet of a listing ordered by date
+func timesheetCursor(ts domain.UserTimesheet) domain.Cursor {
+	return domain.Cursor{Key: ts.Date.Format("2006-01-02"), ID: ts.ID.String()}
+}
+
+// loadEntries fills the entries of the timesheets with a single query, in the
+// context transaction if there is one
+func (r *TimesheetRepository) loadEntries(ctx context.Context, timesheets []domain.UserTimesheet) error {
+	if len(timesheets) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(timesheets))
+	for i, ts := range timesheets {
+		ids[i] = ts.ID
+	}
+
+	const query = `
+		SELECT id, timesheet_id, organization_id, type_id, timestamp, kiosk_device_id, offline_captured
+		FROM timesheet_entries
+		WHERE timesheet_id = ANY(@ids::uuid[])
+		ORDER BY timestamp ASC
+	`
+	rows, err := conn(ctx, r.DB).Query(ctx, query, pgx.StrictNamedArgs{"ids": ids})
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	entries := map[uuid.UUID][]domain.TimesheetEntry{}
+	for rows.Next() {
+		var entry domain.TimesheetEntry
+		err := rows.Scan(&entry.ID, &entry.TimesheetID, &entry.OrganizationID, &entry.TypeID, &entry.Timestamp, &entry.KioskDeviceID, &entry.OfflineCaptured)
+		if err != nil {
+			return err
+		}
+		entries[entry.TimesheetID] = append(entries[entry.TimesheetID], entry)
+	}
+
+	if rows.Err() != nil {
+		return rows.Err()
+	}
+
+	for i := range timesheets {
+		timesheets[i].Entries = entries[timesheets[i].ID]
+	}
+
+	return nil
+}
+
+// UpdateStatus changes the status of a daily timesheet, refused while its
+// day is in a closed pay period. The check and the change run in one
+// transaction, joining the context transaction if there is one.
+func (r *TimesheetRepository) UpdateStatus(ctx context.Context, timesheetID uuid.UUID, status domain.TimesheetStatus) (domain.DBResponse, error) {
+	const findQuery = `SELECT organization_id, date FROM daily_timesheets WHERE id = @timesheetID`
+	const query = `
+		UPDATE daily_timesheets
+		SET status_id = @statusID
+		WHERE id = @timesheetID
+	`
+
+	var orgID uuid.UUID
+	var date time.Time
+	err := pgx.BeginFunc(ctx, conn(ctx, r.DB), func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, findQuery, pgx.StrictNamedArgs{"timesheetID": timesheetID}).Scan(&orgID, &date)
+		if err != nil {
+			return err
+		}
+
+		if err := ensurePeriodOpen(ctx, tx, orgID, date, date); err != nil {
+			return err
+		}
+
+		args := pgx.StrictNamedArgs{
+			"timesheetID": timesheetID,
+			"statusID":    status,
+		}
+		_, err = tx.Exec(ctx, query, args)
+		return err
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Message: "timesheet não encontrado"}, nil
+		}
+		if errors.Is(err, domain.ErrPayPeriodClosed) {
+			return domain.DBResponse{Success: false, Message: err.Error()}, nil
+		}
+		return domain.DBResponse{Message: "erro ao atualizar status do timesheet"}, err
+	}
+
+	return domain.DBResponse{Success: true}, nil
+}
+
+// GetEmployeePunches retrieves the chained punches of an organization between
+// start (inclusive) and end (exclusive), ordered by NSR
+func (r *TimesheetRepository) GetEmployeePunches(ctx context.Context, orgID uuid.UUID, start, end time.Time) (domain.DBResponse, error) {
+	const query = `
+		SELECT
+			te.sequence,
+			te.id,
+			te.timesheet_id,
+			u.id,
+			u.name,
+			u.cpf,
+			te.type_id,
+			te.timestamp,
+			te.hash
+		FROM timesheet_entries te
+		JOIN daily_timesheets dt ON dt.id = te.timesheet_id
+		JOIN users u ON u.id = dt.user_id
+		WHERE te.organization_id = @orgID
+			AND te.sequence IS NOT NULL
+			AND te.timestamp >= @start
+			AND te.timestamp < @end
+		ORDER BY te.sequence
+	`
+	args := pgx.StrictNamedArgs{
+		"orgID": orgID,
+		"start": start,
+		"end":   end,
+	}
+
+	rows, err := r.DB.Query(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar registros de ponto"}, err
+	}
+	defer rows.Close()
+
+	punches := []domain.EmployeePunch{}
+	for rows.Next() {
+		var p domain.EmployeePunch
+		err := rows.Scan(
+			&p.NSR,
+			&p.EntryID,
+			&p.TimesheetID,
+			&p.UserID,
+			&p.UserName,
+			&p.CPF,
+			&p.TypeID,
+			&p.Timestamp,
+			&p.Hash,
+		)
+		if err != nil {
+			return domain.DBResponse{Message: "erro ao ler registros de ponto"}, err
+		}
+		punches = append(punches, p)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar registros de ponto"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: punches}, nil
+}
+
+// GetOrganizationTimesheetsForPeriod retrieves the organization timesheets between
+// start and end (inclusive) with their entries, optionally for a single user.
+// It joins the context transaction if there is one.
+func (r *TimesheetRepository) GetOrganizationTimesheetsForPeriod(ctx context.Context, orgID uuid.UUID, start, end time.Time, userID *uuid.UUID) (domain.DBResponse, error) {
+	const query = `
+		SELECT
+			dt.id,
+			dt.user_id,
+			dt.organization_id,
+			dt.date,
+			dt.status_id,
+			dt.total_minutes,
+			dt.created_at,
+			u.name,
+			u.email
+		FROM daily_timesheets dt
+		JOIN users u ON dt.user_id = u.id
+		WHERE dt.organization_id = @orgID
+			AND dt.date >= @start
+			AND dt.date <= @end
+			AND (@userID::uuid IS NULL OR dt.user_id = @userID::uuid)
+		ORDER BY u.name, dt.date
+	`
+	args := pgx.StrictNamedArgs{
+		"orgID":  orgID,
+		"start":  start,
+		"end":    end,
+		"userID": userID,
+	}
+
+	rows, err := conn(ctx, r.DB).Query(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar timesheets"}, err
+	}
+
+	timesheets := []domain.UserTimesheet{}
+	for rows.Next() {
+		var ts domain.UserTimesheet
+		err := rows.Scan(
+			&ts.ID,
+			&ts.UserID,
+			&ts.OrganizationID,
+			&ts.Date,
+			&ts.StatusID,
+			&ts.TotalMinutes,
+			&ts.CreatedAt,
+			&ts.UserName,
+			&ts.UserEmail,
+		)
+		if err != nil {
+			rows.Close()
+			return domain.DBResponse{Message: "erro ao ler timesheet"}, err
+		}
+		timesheets = append(timesheets, ts)
+	}
+	rows.Close()
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar timesheets"}, rows.Err()
+	}
+
+	if err := r.loadEntries(ctx, timesheets); err != nil {
+		return domain.DBResponse{Message: "erro ao buscar entradas"}, err
 	}
 
 	return domain.DBResponse{Success: true, Data: timesheets}, nil
 }
+
+// StreamOrganizationTimesheets calls fn for each organization timesheet between
+// start and end (inclusive), ordered by member and date, without loading the whole
+// range in memory. Entries are aggregated in the same query.
+func (r *TimesheetRepository) StreamOrganizationTimesheets(ctx context.Context, orgID uuid.UUID, start, end time.Time, userID *uuid.UUID, fn func(domain.UserTimesheet) error) error {
+	const query = `
+		SELECT
+			dt.id,
+			dt.user_id,
+			dt.organization_id,
+			dt.date,
+			dt.status_id,
+			dt.total_minutes,
+			dt.created_at,
+			u.name,
+			u.email,
+			COALESCE(array_agg(te.id ORDER BY te.timestamp) FILTER (WHERE te.id IS NOT NULL), '{}'),
+			COALESCE(array_agg(te.type_id ORDER BY te.timestamp) FILTER (WHERE te.id IS NOT NULL), '{}'),
+			COALESCE(array_agg(te.timestamp ORDER BY te.timestamp) FILTER (WHERE te.id IS NOT NULL), '{}')
+		FROM daily_timesheets dt
+		JOIN users u ON dt.user_id = u.id
+		LEFT JOIN timesheet_entries te ON te.timesheet_id = dt.id
+		WHERE dt.organization_id = @orgID
+			AND dt.date >= @start
+			AND dt.date <= @end
+			AND (@userID::uuid IS NULL OR dt.user_id = @userID::uuid)
+		GROUP BY dt.id, u.id
+		ORDER BY u.name, u.id, dt.date
+	`
+	args := pgx.StrictNamedArgs{
+		"orgID":  orgID,
+		"start":  start,
+		"end":    end,
+		"userID": userID,
+	}
+
+	rows, err := r.DB.Query(ctx, query, args)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ts domain.UserTimesheet
+		var entryIDs []uuid.UUID
+		var entryTypes []int16
+		var entryTimes []time.Time
+		err := rows.Scan(
+			&ts.ID,
+			&ts.UserID,
+			&ts.OrganizationID,
+			&ts.Date,
+			&ts.StatusID,
+			&ts.TotalMinutes,
+			&ts.CreatedAt,
+			&ts.UserName,
+			&ts.UserEmail,
+			&entryIDs,
+			&entryTypes,
+			&entryTimes,
+		)
+		if err != nil {
+			return err
+		}
+
+		for i := range entryIDs {
+			ts.Entries = append(ts.Entries, domain.TimesheetEntry{
+				ID:             entryIDs[i],
+				TimesheetID:    ts.ID,
+				OrganizationID: ts.OrganizationID,
+				TypeID:         domain.EntryType(entryTypes[i]),
+				Timestamp:      entryTimes[i],
+			})
+		}
+
+		if err := fn(ts); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// GetPresence retrieves the current status of the organization members: their
+// last punch and whether it starts work or a break in today's timesheet. When teamIDs is
+// not empty only members of those teams are returned.
+func (r *TimesheetRepository) GetPresence(ctx context.Context, orgID uuid.UUID, teamIDs []uuid.UUID, today time.Time) (domain.DBResponse, error) {
+	const query = `
+		SELECT
+			u.id,
+			u.name,
+			u.email,
+			COALESCE(last.type_id IN (1, 4) AND last.date = @today, false),
+			COALESCE(last.type_id = 3 AND last.date = @today, false),
+			last.type_id,
+			last.timestamp
+		FROM organization_users ou
+		JOIN users u ON u.id = ou.user_id
+		LEFT JOIN LATERAL (
+			SELECT e.type_id, e.timestamp, dt.date
+			FROM daily_timesheets dt
+			JOIN timesheet_entries e ON e.timesheet_id = dt.id
+			WHERE dt.organization_id = ou.organization_id AND dt.user_id = ou.user_id
+			ORDER BY e.timestamp DESC
+			LIMIT 1
+		) last ON true
+		WHERE ou.organization_id = @orgID
+			AND (
+				cardinality(@teamIDs::uuid[]) = 0
+				OR ou.user_id IN (SELECT user_id FROM team_members WHERE team_id = ANY(@teamIDs::uuid[]))
+			)
+		ORDER BY u.name, u.id
+	`
+	if teamIDs == nil {
+		teamIDs = []uuid.UUID{}
+	}
+	args := pgx.StrictNamedArgs{
+		"orgID":   orgID,
+		"teamIDs": teamIDs,
+		"today":   today.Truncate(24 * time.Hour),
+	}
+
+	rows, err := r.DB.Query(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar presença dos membros"}, err
+	}
+	defer rows.Close()
+
+	presence := []domain.MemberPresence{}
+	for rows.Next() {
+		var p domain.MemberPresence
+		if err := rows.Scan(&p.UserID, &p.Name, &p.Email, &p.Working, &p.OnBreak, &p.LastType, &p.LastPunch); err != nil {
+			return domain.DBResponse{Message: "erro ao ler presença do membro"}, err
+		}
+		presence = append(presence, p)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar presença dos membros"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: presence}, nil
+}
@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+// The benchmarks run against a migrated database given by TEST_POSTGRES_URL
+// and are skipped without it:
+//
+//	TEST_POSTGRES_URL=postgres://... go test ./internal/repository -run '^$' -bench .
+
+const (
+	benchMembers = 200
+	benchMonth   = "2025-11-01"
+)
+
+// seedBenchOrganization creates an organization with benchMembers members, a
+// timesheet for each of them on every weekday of benchMonth and four entries
+// per timesheet. Everything is removed when the benchmark ends.
+func seedBenchOrganization(b *testing.B, db *pgxpool.Pool) (orgID uuid.UUID, start, end time.Time) {
+	b.Helper()
+	ctx := context.Background()
+
+	run := uuid.NewString()
+	start, _ = time.Parse(time.DateOnly, benchMonth)
+	end = start.AddDate(0, 1, -1)
+
+	const seed = `
+		WITH members AS (
+			INSERT INTO users (name, email, password)
+			SELECT 'Membro ' || lpad(i::text, 3, '0'), @run || '-' || i || '@bench.local', ''
+			FROM generate_series(1, @members::int) i
+			RETURNING id
+		),
+		org AS (
+			INSERT INTO organizations (name, created_by)
+			SELECT 'bench ' || @run, id FROM members LIMIT 1
+			RETURNING id
+		),
+		joined AS (
+			INSERT INTO organization_users (user_id, organization_id, organization_role_id)
+			SELECT m.id, org.id, 1 FROM members m, org
+		),
+		sheets AS (
+			INSERT INTO daily_timesheets (user_id, organization_id, date, status_id)
+			SELECT m.id, org.id, d::date, 1
+			FROM members m, org, generate_series(@start::date, @end::date, '1 day') d
+			WHERE extract(isodow FROM d) < 6
+			RETURNING id, organization_id, date
+		),
+		entries AS (
+			INSERT INTO timesheet_entries (timesheet_id, organization_id, type_id, timestamp)
+			SELECT s.id, s.organization_id, p.type_id, s.date + p.at
+			FROM sheets s, (VALUES
+				(1, interval '8 hours'),
+				(2, interval '12 hours'),
+				(1, interval '13 hours'),
+				(2, interval '17 hours')
+			) AS p(type_id, at)
+		)
+		SELECT id FROM org
+	`
+	err := db.QueryRow(ctx, seed, pgx.StrictNamedArgs{
+		"run":     run,
+		"members": benchMembers,
+		"start":   start,
+		"end":     end,
+	}).Scan(&orgID)
+	if err != nil {
+		b.Fatalf("erro ao popular organização: %v", err)
+	}
+
+	b.Cleanup(func() {
+		args := pgx.StrictNamedArgs{"orgID": orgID}
+		if _, err := db.Exec(ctx, `DELETE FROM organization_users WHERE organization_id = @orgID`, args); err != nil {
+			b.Errorf("erro ao remover membros da organização: %v", err)
+		}
+		if _, err := db.Exec(ctx, `DELETE FROM organizations WHERE id = @orgID`, args); err != nil {
+			b.Errorf("erro ao remover organização: %v", err)
+		}
+		if _, err := db.Exec(ctx, `DELETE FROM users WHERE email LIKE @run || '-%'`, pgx.StrictNamedArgs{"run": run}); err != nil {
+			b.Errorf("erro ao remover membros: %v", err)
+		}
+	})
+
+	return orgID, start, end
+}
+
+func benchRepository(b *testing.B) (*TimesheetRepository, uuid.UUID, time.Time, time.Time) {
+	b.Helper()
+
+	url := os.Getenv("TEST_POSTGRES_URL")
+	if url == "" {
+		b.Skip("TEST_POSTGRES_URL não definida")
+	}
+
+	db := NewPool(context.Background(), url)
+	b.Cleanup(db.Close)
+
+	orgID, start, end := seedBenchOrganization(b, db)
+	return NewTimesheetRepository(db), orgID, start, end
+}
+
+// loadEntriesPerRow is the entry loading GetUserTimesheets used before the
+// batched loadEntries: one timesheet_entries query per timesheet
+func (r *TimesheetRepository) loadEntriesPerRow(ctx context.Context, timesheets []domain.UserTimesheet) error {
+	const query = `
+		SELECT id, timesheet_id, organization_id, type_id, timestamp
+		FROM timesheet_entries
+		WHERE timesheet_id = @timesheetID
+		ORDER BY timestamp ASC
+	`
+	for i := range timesheets {
+		rows, err := r.DB.Query(ctx, query, pgx.NamedArgs{"timesheetID": timesheets[i].ID})
+		if err != nil {
+			return err
+		}
+
+		var entries []domain.TimesheetEntry
+		for rows.Next() {
+			var entry domain.TimesheetEntry
+			if err := rows.Scan(&entry.ID, &entry.TimesheetID, &entry.OrganizationID, &entry.TypeID, &entry.Timestamp); err != nil {
+				rows.Close()
+				return err
+			}
+			entries = append(entries, entry)
+		}
+		rows.Close()
+
+		if rows.Err() != nil {
+			return rows.Err()
+		}
+		timesheets[i].Entries = entries
+	}
+
+	return nil
+}
+
+// BenchmarkLoadTimesheetEntries compares loading the entries of a month of a
+// 200 member organization one timesheet at a time and in a single query
+func BenchmarkLoadTimesheetEntries(b *testing.B) {
+	r, orgID, start, end := benchRepository(b)
+	ctx := context.Background()
+
+	res, err := r.GetOrganizationTimesheetsForPeriod(ctx, orgID, start, end, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	timesheets := res.Data.([]domain.UserTimesheet)
+	b.Logf("%d timesheets", len(timesheets))
+
+	loaders := []struct {
+		name    string
+		load    func(context.Context, []domain.UserTimesheet) error
+		queries int
+	}{
+		{"per_row", r.loadEntriesPerRow, len(timesheets)},
+		{"batched", r.loadEntries, 1},
+	}
+
+	for _, l := range loaders {
+		b.Run(l.name, func(b *testing.B) {
+			for b.Loop() {
+				if err := l.load(ctx, timesheets); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(l.queries), "queries/op")
+		})
+	}
+}
+
+// BenchmarkGetOrganizationTimesheetsForPeriod measures the whole monthly
+// listing of a 200 member organization, timesheets and entries
+func BenchmarkGetOrganizationTimesheetsForPeriod(b *testing.B) {
+	r, orgID, start, end := benchRepository(b)
+	ctx := context.Background()
+
+	for b.Loop() {
+		if _, err := r.GetOrganizationTimesheetsForPeriod(ctx, orgID, start, end, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGetUserTimesheets measures a member month listing, which used to
+// run one entries query per day
+func BenchmarkGetUserTimesheets(b *testing.B) {
+	r, orgID, start, end := benchRepository(b)
+	ctx := context.Background()
+
+	var userID uuid.UUID
+	err := r.DB.QueryRow(ctx, `SELECT user_id FROM organization_users WHERE organization_id = $1 LIMIT 1`, orgID).Scan(&userID)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for b.Loop() {
+		if _, err := r.GetUserTimesheets(ctx, userID, orgID, start, end, domain.PageRequest{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
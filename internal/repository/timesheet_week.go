@@ -0,0 +1,394 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+type TimesheetWeekRepository struct {
+	DB *pgxpool.Pool
+}
+
+func NewTimesheetWeekRepository(db *pgxpool.Pool) *TimesheetWeekRepository {
+	return &TimesheetWeekRepository{db}
+}
+
+// GetSettings retrieves whether the organization asks for weekly
+// submissions. Organizations that never configured it have it disabled.
+func (r *TimesheetWeekRepository) GetSettings(ctx context.Context, orgID uuid.UUID) (domain.DBResponse, error) {
+	const query = `SELECT organization_id, enabled, updated_at FROM week_submission_settings WHERE organization_id = @orgID`
+
+	var s domain.WeekSettings
+	err := conn(ctx, r.DB).QueryRow(ctx, query, pgx.StrictNamedArgs{"orgID": orgID}).Scan(&s.OrganizationID, &s.Enabled, &s.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: true, Data: domain.WeekSettings{OrganizationID: orgID}}, nil
+		}
+		return domain.DBResponse{Message: "erro ao buscar configuração do envio semanal"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: s}, nil
+}
+
+// SaveSettings creates or replaces the organization weekly submission
+// settings. It joins the context transaction if there is one.
+func (r *TimesheetWeekRepository) SaveSettings(ctx context.Context, s domain.WeekSettings) (domain.DBResponse, error) {
+	const query = `
+		INSERT INTO week_submission_settings (organization_id, enabled)
+		VALUES (@orgID, @enabled)
+		ON CONFLICT (organization_id) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			updated_at = NOW()
+		RETURNING organization_id, enabled, updated_at
+	`
+	args := pgx.StrictNamedArgs{
+		"orgID":   s.OrganizationID,
+		"enabled": s.Enabled,
+	}
+
+	var saved domain.WeekSettings
+	err := conn(ctx, r.DB).QueryRow(ctx, query, args).Scan(&saved.OrganizationID, &saved.Enabled, &saved.UpdatedAt)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao salvar configuração do envio semanal"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: saved}, nil
+}
+
+const timesheetWeekColumns = `
+	tw.id,
+	tw.organization_id,
+	tw.user_id,
+	u.name,
+	u.email,
+	tw.week_start,
+	tw.status,
+	tw.worked_minutes,
+	tw.note,
+	tw.submitted_at,
+	tw.reviewed_by,
+	COALESCE(rv.name, ''),
+	tw.reviewed_at,
+	tw.review_note,
+	tw.reminded_at
+`
+
+const timesheetWeekJoins = `
+	JOIN users u ON u.id = tw.user_id
+	LEFT JOIN users rv ON rv.id = tw.reviewed_by
+`
+
+// GetWeek retrieves the week of the member starting at weekStart. Data is
+// nil when the week was never submitted nor reminded.
+func (r *TimesheetWeekRepository) GetWeek(ctx context.Context, orgID, userID uuid.UUID, weekStart time.Time) (domain.DBResponse, error) {
+	query := `SELECT` + timesheetWeekColumns + `FROM timesheet_weeks tw` + timesheetWeekJoins + `
+		WHERE tw.organization_id = @orgID AND tw.user_id = @userID AND tw.week_start = @weekStart`
+	args := pgx.StrictNamedArgs{
+		"orgID":     orgID,
+		"userID":    userID,
+		"weekStart": weekStart,
+	}
+
+	week, err := scanTimesheetWeek(conn(ctx, r.DB).QueryRow(ctx, query, args))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: true, Data: (*domain.TimesheetWeek)(nil)}, nil
+		}
+		return domain.DBResponse{Message: "erro ao buscar semana"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: &week}, nil
+}
+
+// GetWeekByID retrieves a week of the organization
+func (r *TimesheetWeekRepository) GetWeekByID(ctx context.Context, orgID, weekID uuid.UUID) (domain.DBResponse, error) {
+	query := `SELECT` + timesheetWeekColumns + `FROM timesheet_weeks tw` + timesheetWeekJoins + `
+		WHERE tw.id = @id AND tw.organization_id = @orgID`
+
+	week, err := scanTimesheetWeek(conn(ctx, r.DB).QueryRow(ctx, query, pgx.StrictNamedArgs{"id": weekID, "orgID": orgID}))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "semana não encontrada"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao buscar semana"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: week}, nil
+}
+
+// ListUserWeeks retrieves the latest weeks of the member, newest first
+func (r *TimesheetWeekRepository) ListUserWeeks(ctx context.Context, orgID, userID uuid.UUID, limit int) (domain.DBResponse, error) {
+	query := `SELECT` + timesheetWeekColumns + `FROM timesheet_weeks tw` + timesheetWeekJoins + `
+		WHERE tw.organization_id = @orgID AND tw.user_id = @userID
+		ORDER BY tw.week_start DESC
+		LIMIT @limit`
+	args := pgx.StrictNamedArgs{
+		"orgID":  orgID,
+		"userID": userID,
+		"limit":  limit,
+	}
+
+	return r.listWeeks(ctx, query, args)
+}
+
+// ReviewQueue retrieves the submitted weeks of the organization, oldest
+// submission first. When teamIDs is not empty only members of those teams are
+// returned; excludeUserID leaves out the weeks of a member.
+func (r *TimesheetWeekRepository) ReviewQueue(ctx context.Context, orgID uuid.UUID, teamIDs []uuid.UUID, excludeUserID *uuid.UUID) (domain.DBResponse, error) {
+	query := `SELECT` + timesheetWeekColumns + `FROM timesheet_weeks tw` + timesheetWeekJoins + `
+		WHERE tw.organization_id = @orgID
+			AND tw.status = 'submitted'
+			AND (@excludeUserID::uuid IS NULL OR tw.user_id <> @excludeUserID::uuid)
+			AND (
+				cardinality(@teamIDs::uuid[]) = 0
+				OR tw.user_id IN (SELECT user_id FROM team_members WHERE team_id = ANY(@teamIDs::uuid[]))
+			)
+		ORDER BY tw.submitted_at, u.name`
+	if teamIDs == nil {
+		teamIDs = []uuid.UUID{}
+	}
+	args := pgx.StrictNamedArgs{
+		"orgID":         orgID,
+		"teamIDs":       teamIDs,
+		"excludeUserID": excludeUserID,
+	}
+
+	return r.listWeeks(ctx, query, args)
+}
+
+func (r *TimesheetWeekRepository) listWeeks(ctx context.Context, query string, args pgx.StrictNamedArgs) (domain.DBResponse, error) {
+	rows, err := conn(ctx, r.DB).Query(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar semanas"}, err
+	}
+	defer rows.Close()
+
+	weeks := []domain.TimesheetWeek{}
+	for rows.Next() {
+		w, err := scanTimesheetWeek(rows)
+		if err != nil {
+			return domain.DBResponse{Message: "erro ao ler semana"}, err
+		}
+		weeks = append(weeks, w)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar semanas"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: weeks}, nil
+}
+
+// SubmitWeek sends the week of the member for review, creating it if needed.
+// Only drafts and rejected weeks can be submitted; the previous review is
+// cleared. It joins the context transaction if there is one.
+func (r *TimesheetWeekRepository) SubmitWeek(ctx context.Context, w domain.TimesheetWeek) (domain.DBResponse, error) {
+	query := `
+		WITH tw AS (
+			INSERT INTO timesheet_weeks (organization_id, user_id, week_start, status, worked_minutes, note, submitted_at)
+			VALUES (@orgID, @userID, @weekStart, 'submitted', @workedMinutes, @note, NOW())
+			ON CONFLICT (organization_id, user_id, week_start) DO UPDATE SET
+				status = EXCLUDED.status,
+				worked_minutes = EXCLUDED.worked_minutes,
+				note = EXCLUDED.note,
+				submitted_at = EXCLUDED.submitted_at,
+				reviewed_by = NULL,
+				reviewed_at = NULL,
+				review_note = ''
+			WHERE timesheet_weeks.status IN ('draft', 'rejected')
+			RETURNING *
+		)
+		SELECT` + timesheetWeekColumns + `FROM tw` + timesheetWeekJoins
+	args := pgx.StrictNamedArgs{
+		"orgID":         w.OrganizationID,
+		"userID":        w.UserID,
+		"weekStart":     w.WeekStart,
+		"workedMinutes": w.WorkedMinutes,
+		"note":          w.Note,
+	}
+
+	saved, err := scanTimesheetWeek(conn(ctx, r.DB).QueryRow(ctx, query, args))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "esta semana já foi enviada"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao enviar semana"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: saved}, nil
+}
+
+// ReviewWeek approves or rejects a submitted week. It joins the context
+// transaction if there is one.
+func (r *TimesheetWeekRepository) ReviewWeek(ctx context.Context, orgID, weekID, reviewerID uuid.UUID, status domain.WeekStatus, note string) (domain.DBResponse, error) {
+	query := `
+		WITH tw AS (
+			UPDATE timesheet_weeks
+			SET status = @status, reviewed_by = @reviewerID, reviewed_at = NOW(), review_note = @note
+			WHERE id = @id AND organization_id = @orgID AND status = 'submitted'
+			RETURNING *
+		)
+		SELECT` + timesheetWeekColumns + `FROM tw` + timesheetWeekJoins
+	args := pgx.StrictNamedArgs{
+		"id":         weekID,
+		"orgID":      orgID,
+		"status":     status,
+		"reviewerID": reviewerID,
+		"note":       note,
+	}
+
+	saved, err := scanTimesheetWeek(conn(ctx, r.DB).QueryRow(ctx, query, args))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "apenas semanas enviadas podem ser revisadas"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao revisar semana"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: saved}, nil
+}
+
+// ApproveDays marks the timesheets of the member between start and end
+// (inclusive) as approved, keeping absences. Weeks with days in a closed pay
+// period are refused. The check and the change run in one transaction,
+// joining the context transaction if there is one.
+func (r *TimesheetWeekRepository) ApproveDays(ctx context.Context, orgID, userID uuid.UUID, start, end time.Time) (domain.DBResponse, error) {
+	const query = `
+		UPDATE daily_timesheets
+		SET status_id = @approved
+		WHERE organization_id = @orgID
+			AND user_id = @userID
+			AND date >= @start
+			AND date <= @end
+			AND status_id <> @absent
+	`
+	args := pgx.StrictNamedArgs{
+		"orgID":    orgID,
+		"userID":   userID,
+		"start":    start,
+		"end":      end,
+		"approved": domain.StatusApproved,
+		"absent":   domain.StatusAbsent,
+	}
+
+	err := pgx.BeginFunc(ctx, conn(ctx, r.DB), func(tx pgx.Tx) error {
+		if err := ensurePeriodOpen(ctx, tx, orgID, start, end); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec(ctx, query, args)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrPayPeriodClosed) {
+			return domain.DBResponse{Success: false, Message: err.Error()}, nil
+		}
+		return domain.DBResponse{Message: "erro ao aprovar dias da semana"}, err
+	}
+
+	return domain.DBResponse{Success: true}, nil
+}
+
+// UnsubmittedWeeks retrieves the Mondays of the weeks from since (inclusive)
+// to before (exclusive) in which the member has timesheets but that were not
+// submitted or are rejected, oldest first
+func (r *TimesheetWeekRepository) UnsubmittedWeeks(ctx context.Context, orgID, userID uuid.UUID, since, before time.Time) (domain.DBResponse, error) {
+	const query = `
+		SELECT DISTINCT date_trunc('week', dt.date)::date AS week_start
+		FROM daily_timesheets dt
+		WHERE dt.organization_id = @orgID
+			AND dt.user_id = @userID
+			AND dt.date >= @since
+			AND dt.date < @before
+			AND NOT EXISTS (
+				SELECT 1 FROM timesheet_weeks tw
+				WHERE tw.organization_id = dt.organization_id
+					AND tw.user_id = dt.user_id
+					AND tw.week_start = date_trunc('week', dt.date)::date
+					AND tw.status IN ('submitted', 'approved')
+			)
+		ORDER BY week_start
+	`
+	args := pgx.StrictNamedArgs{
+		"orgID":  orgID,
+		"userID": userID,
+		"since":  since,
+		"before": before,
+	}
+
+	rows, err := conn(ctx, r.DB).Query(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar semanas pendentes"}, err
+	}
+	defer rows.Close()
+
+	weeks := []time.Time{}
+	for rows.Next() {
+		var week time.Time
+		if err := rows.Scan(&week); err != nil {
+			return domain.DBResponse{Message: "erro ao ler semana pendente"}, err
+		}
+		weeks = append(weeks, week)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar semanas pendentes"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: weeks}, nil
+}
+
+// RemindOverdue marks as reminded the unsubmitted weeks starting at weekStart
+// of the members with timesheets in organizations that ask for weekly
+// submissions, and returns them. Weeks already reminded, and weeks before the
+// organization enabled the submissions, are skipped.
+func (r *TimesheetWeekRepository) RemindOverdue(ctx context.Context, weekStart time.Time) (domain.DBResponse, error) {
+	query := `
+		WITH tw AS (
+			INSERT INTO timesheet_weeks (organization_id, user_id, week_start, reminded_at)
+			SELECT DISTINCT dt.organization_id, dt.user_id, @weekStart::date, NOW()
+			FROM daily_timesheets dt
+			JOIN week_submission_settings ws ON ws.organization_id = dt.organization_id
+			JOIN organization_users ou ON ou.organization_id = dt.organization_id AND ou.user_id = dt.user_id
+			WHERE ws.enabled
+				AND date_trunc('week', ws.updated_at)::date <= @weekStart::date
+				AND dt.date >= @weekStart::date
+				AND dt.date < @weekStart::date + 7
+			ON CONFLICT (organization_id, user_id, week_start) DO UPDATE SET
+				reminded_at = EXCLUDED.reminded_at
+			WHERE timesheet_weeks.reminded_at IS NULL
+				AND timesheet_weeks.status IN ('draft', 'rejected')
+			RETURNING *
+		)
+		SELECT` + timesheetWeekColumns + `FROM tw` + timesheetWeekJoins
+
+	return r.listWeeks(ctx, query, pgx.StrictNamedArgs{"weekStart": weekStart})
+}
+
+func scanTimesheetWeek(row pgx.Row) (domain.TimesheetWeek, error) {
+	var w domain.TimesheetWeek
+	err := row.Scan(
+		&w.ID,
+		&w.OrganizationID,
+		&w.UserID,
+		&w.UserName,
+		&w.UserEmail,
+		&w.WeekStart,
+		&w.Status,
+		&w.WorkedMinutes,
+		&w.Note,
+		&w.SubmittedAt,
+		&w.ReviewedBy,
+		&w.ReviewerName,
+		&w.ReviewedAt,
+		&w.ReviewNote,
+		&w.RemindedAt,
+	)
+	return w, err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/marcelorc13/timesheet-pro/internal/domain"
 	"golang.org/x/crypto/bcrypt"
@@ -17,11 +18,20 @@ func NewUserRepository(db *pgxpool.Pool) *UserRepository {
 	return &UserRepository{db}
 }
 
-func (r *UserRepository) List(ctx context.Context) (domain.DBResponse, error) {
-	results, err := r.DB.Query(ctx, "SELECT id, name, email, password FROM users")
+// List retrieves a page of users ordered by name
+func (r *UserRepository) List(ctx context.Context, page domain.PageRequest) (domain.DBResponse, error) {
+	const query = `
+		SELECT id, name, email, password
+		FROM users
+		WHERE @cursorKey::text IS NULL OR (name, id) > (@cursorKey::text, @cursorID::uuid)
+		ORDER BY name, id
+		LIMIT NULLIF(@limit::int, 0)
+	`
+	results, err := r.DB.Query(ctx, query, withPage(pgx.StrictNamedArgs{}, page))
 	if err != nil {
 		return domain.DBResponse{Message: "Ocorreu um erro na query"}, err
 	}
+	defer results.Close()
 
 	res := []domain.User{}
 
@@ -30,24 +40,30 @@ func (r *UserRepository) List(ctx context.Context) (domain.DBResponse, error) {
 
 		err = results.Scan(&user.ID, &user.Name, &user.Email, &user.Password)
 		if err != nil {
-			panic(err)
+			return domain.DBResponse{Message: "Ocorreu um erro ao ler usuário"}, err
 		}
 
 		res = append(res, user)
 	}
 
-	if len(res) == 0 {
+	if results.Err() != nil {
+		return domain.DBResponse{Message: "Ocorreu um erro na query"}, results.Err()
+	}
+
+	if len(res) == 0 && page.Cursor == nil {
 		return domain.DBResponse{Message: "O banco ainda não possui usuários"}, nil
 	}
 
-	return domain.DBResponse{Success: true, Data: res}, nil
+	return domain.DBResponse{Success: true, Data: domain.NewCursorPage(res, page, func(u domain.User) domain.Cursor {
+		return domain.Cursor{Key: u.Name, ID: u.ID.String()}
+	})}, nil
 }
 
 func (r *UserRepository) GetByID(ctx context.Context, id string) (domain.DBResponse, error) {
 	var user domain.User
 
-	err := r.DB.QueryRow(ctx, "SELECT id, name, email, password FROM users WHERE id = $1", id).
-		Scan(&user.ID, &user.Name, &user.Email, &user.Password)
+	err := r.DB.QueryRow(ctx, "SELECT id, name, email, password, cpf FROM users WHERE id = $1", id).
+		Scan(&user.ID, &user.Name, &user.Email, &user.Password, &user.CPF)
 
 	if err == sql.ErrNoRows {
 		return domain.DBResponse{Message: "usuário não encontrado"}, nil
@@ -126,18 +142,18 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (domain.D
 	return domain.DBResponse{Success: true, Data: user}, nil
 }
 
-// UpdateUser updates a user's name and email
-func (r *UserRepository) UpdateUser(ctx context.Context, userID, name, email string) (domain.DBResponse, error) {
+// UpdateUser updates a user's name, email and CPF
+func (r *UserRepository) UpdateUser(ctx context.Context, userID, name, email, cpf string) (domain.DBResponse, error) {
 	const query = `
 		UPDATE users 
-		SET name = $1, email = $2
-		WHERE id = $3
-		RETURNING id, name, email
+		SET name = $1, email = $2, cpf = $3
+		WHERE id = $4
+		RETURNING id, name, email, cpf
 	`
 	
 	var updatedUser domain.User
-	err := r.DB.QueryRow(ctx, query, name, email, userID).
-		Scan(&updatedUser.ID, &updatedUser.Name, &updatedUser.Email)
+	err := r.DB.QueryRow(ctx, query, name, email, cpf, userID).
+		Scan(&updatedUser.ID, &updatedUser.Name, &updatedUser.Email, &updatedUser.CPF)
 	
 	if err == sql.ErrNoRows {
 		return domain.DBResponse{Message: "usuário não encontrado"}, nil
@@ -0,0 +1,382 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+type WebhookRepository struct {
+	DB *pgxpool.Pool
+}
+
+func NewWebhookRepository(db *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{db}
+}
+
+const webhookColumns = `
+	id,
+	organization_id,
+	url,
+	secret,
+	events,
+	active,
+	created_at
+`
+
+// Create stores a webhook, joining the context transaction if there is one
+func (r *WebhookRepository) Create(ctx context.Context, w domain.Webhook) (domain.DBResponse, error) {
+	query := `
+		INSERT INTO webhooks (organization_id, url, secret, events, active)
+		VALUES (@orgID, @url, @secret, @events, @active)
+		RETURNING` + webhookColumns
+	args := pgx.StrictNamedArgs{
+		"orgID":  w.OrganizationID,
+		"url":    w.URL,
+		"secret": w.Secret,
+		"events": webhookEventNames(w.Events),
+		"active": w.Active,
+	}
+
+	created, err := scanWebhook(conn(ctx, r.DB).QueryRow(ctx, query, args))
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao criar webhook"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: created}, nil
+}
+
+// GetByID retrieves a webhook of the organization
+func (r *WebhookRepository) GetByID(ctx context.Context, orgID, webhookID uuid.UUID) (domain.DBResponse, error) {
+	query := `SELECT` + webhookColumns + `FROM webhooks WHERE id = @id AND organization_id = @orgID`
+
+	w, err := scanWebhook(r.DB.QueryRow(ctx, query, pgx.StrictNamedArgs{"id": webhookID, "orgID": orgID}))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "webhook não encontrado"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao buscar webhook"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: w}, nil
+}
+
+// ListByOrganization retrieves a page of the organization webhooks, oldest first
+func (r *WebhookRepository) ListByOrganization(ctx context.Context, orgID uuid.UUID, page domain.PageRequest) (domain.DBResponse, error) {
+	query := `SELECT` + webhookColumns + `
+		FROM webhooks
+		WHERE organization_id = @orgID
+			AND (@cursorKey::timestamptz IS NULL OR (created_at, id) > (@cursorKey::timestamptz, @cursorID::uuid))
+		ORDER BY created_at, id
+		LIMIT NULLIF(@limit::int, 0)
+	`
+
+	rows, err := r.DB.Query(ctx, query, withPage(pgx.StrictNamedArgs{"orgID": orgID}, page))
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar webhooks"}, err
+	}
+	defer rows.Close()
+
+	webhooks := []domain.Webhook{}
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return domain.DBResponse{Message: "erro ao ler webhook"}, err
+		}
+		webhooks = append(webhooks, w)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar webhooks"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: domain.NewCursorPage(webhooks, page, func(w domain.Webhook) domain.Cursor {
+		return domain.Cursor{Key: w.CreatedAt.Format(time.RFC3339Nano), ID: w.ID.String()}
+	})}, nil
+}
+
+// Update saves the webhook URL, events and active flag, joining the context transaction if there is one
+func (r *WebhookRepository) Update(ctx context.Context, w domain.Webhook) (domain.DBResponse, error) {
+	query := `
+		UPDATE webhooks
+		SET url = @url, events = @events, active = @active
+		WHERE id = @id AND organization_id = @orgID
+		RETURNING` + webhookColumns
+	args := pgx.StrictNamedArgs{
+		"id":     w.ID,
+		"orgID":  w.OrganizationID,
+		"url":    w.URL,
+		"events": webhookEventNames(w.Events),
+		"active": w.Active,
+	}
+
+	updated, err := scanWebhook(conn(ctx, r.DB).QueryRow(ctx, query, args))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "webhook não encontrado"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao atualizar webhook"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: updated}, nil
+}
+
+// Delete removes a webhook and its deliveries, joining the context transaction if there is one
+func (r *WebhookRepository) Delete(ctx context.Context, orgID, webhookID uuid.UUID) (domain.DBResponse, error) {
+	const query = `DELETE FROM webhooks WHERE id = @id AND organization_id = @orgID`
+
+	tag, err := conn(ctx, r.DB).Exec(ctx, query, pgx.StrictNamedArgs{"id": webhookID, "orgID": orgID})
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao remover webhook"}, err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.DBResponse{Success: false, Message: "webhook não encontrado"}, nil
+	}
+
+	return domain.DBResponse{Success: true, Message: "webhook removido com sucesso"}, nil
+}
+
+// Enqueue queues the event for every active webhook of the organization
+// subscribed to it. It joins the context transaction if there is one, so the
+// deliveries only exist if the change they describe is committed.
+func (r *WebhookRepository) Enqueue(ctx context.Context, orgID uuid.UUID, event domain.WebhookEvent, payload []byte) (domain.DBResponse, error) {
+	const query = `
+		INSERT INTO webhook_deliveries (webhook_id, organization_id, event, payload)
+		SELECT id, organization_id, @event::text, @payload::jsonb
+		FROM webhooks
+		WHERE organization_id = @orgID AND active AND @event::text = ANY(events)
+	`
+	args := pgx.StrictNamedArgs{
+		"orgID":   orgID,
+		"event":   string(event),
+		"payload": payload,
+	}
+
+	tag, err := conn(ctx, r.DB).Exec(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao enfileirar webhooks"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: tag.RowsAffected()}, nil
+}
+
+const webhookDeliveryColumns = `
+	d.id,
+	d.webhook_id,
+	d.organization_id,
+	w.url,
+	d.event,
+	d.payload,
+	d.status,
+	d.attempts,
+	d.next_attempt_at,
+	d.response_status,
+	d.response_body,
+	d.last_error,
+	d.created_at,
+	d.delivered_at
+`
+
+// ListDeliveries retrieves a page of the organization deliveries, newest
+// first. A nil webhookID lists the deliveries of every webhook.
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, orgID uuid.UUID, webhookID *uuid.UUID, page domain.PageRequest) (domain.DBResponse, error) {
+	query := `SELECT` + webhookDeliveryColumns + `
+		FROM webhook_deliveries d
+		JOIN webhooks w ON w.id = d.webhook_id
+		WHERE d.organization_id = @orgID
+			AND (@webhookID::uuid IS NULL OR d.webhook_id = @webhookID::uuid)
+			AND (@cursorKey::timestamptz IS NULL OR (d.created_at, d.id) < (@cursorKey::timestamptz, @cursorID::uuid))
+		ORDER BY d.created_at DESC, d.id DESC
+		LIMIT NULLIF(@limit::int, 0)
+	`
+	args := withPage(pgx.StrictNamedArgs{"orgID": orgID, "webhookID": webhookID}, page)
+
+	rows, err := r.DB.Query(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar entregas de webhook"}, err
+	}
+	defer rows.Close()
+
+	deliveries := []domain.WebhookDelivery{}
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return domain.DBResponse{Message: "erro ao ler entrega de webhook"}, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar entregas de webhook"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: domain.NewCursorPage(deliveries, page, func(d domain.WebhookDelivery) domain.Cursor {
+		return domain.Cursor{Key: d.CreatedAt.Format(time.RFC3339Nano), ID: d.ID.String()}
+	})}, nil
+}
+
+// Redeliver queues a copy of a delivery of the organization to be sent right away
+func (r *WebhookRepository) Redeliver(ctx context.Context, orgID, deliveryID uuid.UUID) (domain.DBResponse, error) {
+	query := `
+		WITH copy AS (
+			INSERT INTO webhook_deliveries (webhook_id, organization_id, event, payload)
+			SELECT webhook_id, organization_id, event, payload
+			FROM webhook_deliveries
+			WHERE id = @id AND organization_id = @orgID
+			RETURNING *
+		)
+		SELECT` + webhookDeliveryColumns + `
+		FROM copy d
+		JOIN webhooks w ON w.id = d.webhook_id
+	`
+
+	d, err := scanWebhookDelivery(r.DB.QueryRow(ctx, query, pgx.StrictNamedArgs{"id": deliveryID, "orgID": orgID}))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DBResponse{Success: false, Message: "entrega de webhook não encontrada"}, nil
+		}
+		return domain.DBResponse{Message: "erro ao reenviar webhook"}, err
+	}
+
+	return domain.DBResponse{Success: true, Data: d}, nil
+}
+
+// ClaimDue locks up to limit pending deliveries whose next attempt is due and
+// postpones them by lease, so other workers skip them while they are being
+// sent. Data is a []domain.PendingWebhookDelivery.
+func (r *WebhookRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) (domain.DBResponse, error) {
+	query := `
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = NOW() + make_interval(secs => @lease)
+		FROM webhooks w
+		WHERE w.id = d.webhook_id
+			AND d.id IN (
+				SELECT q.id
+				FROM webhook_deliveries q
+				JOIN webhooks qw ON qw.id = q.webhook_id
+				WHERE q.status = 'pending' AND q.next_attempt_at <= NOW() AND qw.active
+				ORDER BY q.next_attempt_at
+				LIMIT @limit
+				FOR UPDATE OF q SKIP LOCKED
+			)
+		RETURNING` + webhookDeliveryColumns + `, w.secret`
+	args := pgx.StrictNamedArgs{
+		"limit": limit,
+		"lease": lease.Seconds(),
+	}
+
+	rows, err := r.DB.Query(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao buscar entregas pendentes"}, err
+	}
+	defer rows.Close()
+
+	due := []domain.PendingWebhookDelivery{}
+	for rows.Next() {
+		var p domain.PendingWebhookDelivery
+		p.WebhookDelivery, err = scanWebhookDelivery(rows, &p.Secret)
+		if err != nil {
+			return domain.DBResponse{Message: "erro ao ler entrega pendente"}, err
+		}
+		due = append(due, p)
+	}
+
+	if rows.Err() != nil {
+		return domain.DBResponse{Message: "erro ao processar entregas pendentes"}, rows.Err()
+	}
+
+	return domain.DBResponse{Success: true, Data: due}, nil
+}
+
+// RecordAttempt stores the outcome of sending a delivery. A successful attempt
+// marks it delivered; a failed one schedules the retry at nextAttempt, or marks
+// it failed when nextAttempt is nil.
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, deliveryID uuid.UUID, attempt domain.WebhookAttempt, nextAttempt *time.Time) (domain.DBResponse, error) {
+	const query = `
+		UPDATE webhook_deliveries
+		SET attempts = attempts + 1,
+			status = CASE
+				WHEN @succeeded THEN 'delivered'
+				WHEN @nextAttempt::timestamptz IS NULL THEN 'failed'
+				ELSE 'pending'
+			END,
+			next_attempt_at = COALESCE(@nextAttempt::timestamptz, next_attempt_at),
+			response_status = @responseStatus,
+			response_body = @responseBody,
+			last_error = @lastError,
+			delivered_at = CASE WHEN @succeeded THEN NOW() ELSE delivered_at END
+		WHERE id = @id
+	`
+	args := pgx.StrictNamedArgs{
+		"id":             deliveryID,
+		"succeeded":      attempt.Succeeded(),
+		"nextAttempt":    nextAttempt,
+		"responseStatus": attempt.StatusCode,
+		"responseBody":   attempt.ResponseBody,
+		"lastError":      attempt.Error,
+	}
+
+	_, err := r.DB.Exec(ctx, query, args)
+	if err != nil {
+		return domain.DBResponse{Message: "erro ao registrar tentativa de entrega"}, err
+	}
+
+	return domain.DBResponse{Success: true}, nil
+}
+
+func scanWebhook(row pgx.Row) (domain.Webhook, error) {
+	var w domain.Webhook
+	var events []string
+	err := row.Scan(
+		&w.ID,
+		&w.OrganizationID,
+		&w.URL,
+		&w.Secret,
+		&events,
+		&w.Active,
+		&w.CreatedAt,
+	)
+	for _, e := range events {
+		w.Events = append(w.Events, domain.WebhookEvent(e))
+	}
+	return w, err
+}
+
+// scanWebhookDelivery reads the webhookDeliveryColumns followed by extra
+func scanWebhookDelivery(row pgx.Row, extra ...any) (domain.WebhookDelivery, error) {
+	var d domain.WebhookDelivery
+	var event, status string
+	dest := []any{
+		&d.ID,
+		&d.WebhookID,
+		&d.OrganizationID,
+		&d.URL,
+		&event,
+		&d.Payload,
+		&status,
+		&d.Attempts,
+		&d.NextAttemptAt,
+		&d.ResponseStatus,
+		&d.ResponseBody,
+		&d.LastError,
+		&d.CreatedAt,
+		&d.DeliveredAt,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	d.Event = domain.WebhookEvent(event)
+	d.Status = domain.WebhookDeliveryStatus(status)
+	return d, err
+}
+
+func webhookEventNames(events []domain.WebhookEvent) []string {
+	names := make([]string, len(events))
+	for i, e := range events {
+		names[i] = string(e)
+	}
+	return names
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+)
+
+type AuditHandler struct {
+	service *service.AuditService
+}
+
+func NewAuditHandler(as *service.AuditService) *AuditHandler {
+	return &AuditHandler{as}
+}
+
+// List handles GET /api/v1/organizations/:id/audit?actor=&action=&start=&end=&limit=&cursor=
+// Admin only - returns the organization audit log filtered by actor, action and date range
+func (h *AuditHandler) List(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	filter, err := domain.ParseAuditFilter(c.Query("actor"), c.Query("action"), c.Query("start"), c.Query("end"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	page, ok := pageRequest(c, domain.CursorTimestamp, domain.CursorInt)
+	if !ok {
+		return
+	}
+
+	logs, err := h.service.List(c.Request.Context(), userID, orgID, filter, page)
+	if err != nil {
+		if err.Error() == "apenas administradores podem visualizar a auditoria" {
+			c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	writePage(c, "Registros de auditoria", page, *logs)
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	"github.com/marcelorc13/timesheet-pro/internal/utils"
+)
+
+// requestUserID extracts the authenticated user ID from the token cookie,
+// writing the error response when it is missing or invalid
+func requestUserID(c *gin.Context) (uuid.UUID, bool) {
+	tokenString, err := c.Cookie("token")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, domain.HttpResponse{Status: http.StatusUnauthorized, Message: "Token não encontrado"})
+		return uuid.Nil, false
+	}
+
+	claims, err := utils.GetTokenClaims(tokenString)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, domain.HttpResponse{Status: http.StatusUnauthorized, Message: "Token inválido"})
+		return uuid.Nil, false
+	}
+
+	userIDStr, ok := claims["id"].(string)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, domain.HttpResponse{Status: http.StatusUnauthorized, Message: "Token inválido"})
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, domain.HttpResponse{Status: http.StatusUnauthorized, Message: "ID de usuário inválido no token"})
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
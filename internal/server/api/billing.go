@@ -0,0 +1,332 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+)
+
+type BillingHandler struct {
+	service *service.BillingService
+}
+
+func NewBillingHandler(bs *service.BillingService) *BillingHandler {
+	return &BillingHandler{bs}
+}
+
+// CreateClient handles POST /api/v1/organizations/:id/clients
+// Admin only - adds a client the project hours are billed to
+func (h *BillingHandler) CreateClient(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var cc domain.CreateClient
+	if err := c.ShouldBind(&cc); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	client, err := h.service.CreateClient(c.Request.Context(), userID, orgID, cc)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusCreated, domain.HttpResponse{Status: http.StatusCreated, Message: "Cliente criado com sucesso", Data: client})
+}
+
+// ListClients handles GET /api/v1/organizations/:id/clients
+// Admin only
+func (h *BillingHandler) ListClients(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	clients, err := h.service.Clients(c.Request.Context(), userID, orgID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Clientes da organização", Data: clients})
+}
+
+// UpdateClient handles PUT /api/v1/organizations/:id/clients/:clientId
+// Admin only - changes a client; omitted fields are kept
+func (h *BillingHandler) UpdateClient(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	clientID, err := uuid.Parse(c.Param("clientId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID do cliente inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var uc domain.UpdateClient
+	if err := c.ShouldBind(&uc); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	client, err := h.service.UpdateClient(c.Request.Context(), userID, orgID, clientID, uc)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Cliente atualizado com sucesso", Data: client})
+}
+
+// ListMemberRates handles GET /api/v1/organizations/:id/billing-rates
+// Admin only - returns every member with the hourly rate they are billed at
+func (h *BillingHandler) ListMemberRates(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	rates, err := h.service.MemberRates(c.Request.Context(), userID, orgID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Valores/hora dos membros", Data: rates})
+}
+
+// SetMemberRate handles PUT /api/v1/organizations/:id/billing-rates/:userId
+// Admin only - sets the member hourly rate; zero bills the project rates
+func (h *BillingHandler) SetMemberRate(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	memberID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID do usuário inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var ur domain.UpdateMemberRate
+	if err := c.ShouldBind(&ur); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	rate, err := h.service.SetMemberRate(c.Request.Context(), userID, orgID, memberID, ur)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Valor/hora atualizado com sucesso", Data: rate})
+}
+
+// CreateInvoice handles POST /api/v1/organizations/:id/invoices
+// Admin only - generates an invoice draft for the client hours of the period
+func (h *BillingHandler) CreateInvoice(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var ci domain.CreateInvoice
+	if err := c.ShouldBind(&ci); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	invoice, err := h.service.CreateInvoice(c.Request.Context(), userID, orgID, ci)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusCreated, domain.HttpResponse{Status: http.StatusCreated, Message: "Rascunho de fatura gerado com sucesso", Data: invoice})
+}
+
+// ListInvoices handles GET /api/v1/organizations/:id/invoices
+// Admin only - returns the invoices without their lines, newest first
+func (h *BillingHandler) ListInvoices(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	invoices, err := h.service.Invoices(c.Request.Context(), userID, orgID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Faturas da organização", Data: invoices})
+}
+
+// GetInvoice handles GET /api/v1/organizations/:id/invoices/:invoiceId
+// Admin only - returns the invoice with its lines
+func (h *BillingHandler) GetInvoice(c *gin.Context) {
+	orgID, invoiceID, userID, ok := invoiceParams(c)
+	if !ok {
+		return
+	}
+
+	invoice, err := h.service.Invoice(c.Request.Context(), userID, orgID, invoiceID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Fatura", Data: invoice})
+}
+
+// IssueInvoice handles POST /api/v1/organizations/:id/invoices/:invoiceId/issue
+// Admin only - numbers the draft and makes it final
+func (h *BillingHandler) IssueInvoice(c *gin.Context) {
+	orgID, invoiceID, userID, ok := invoiceParams(c)
+	if !ok {
+		return
+	}
+
+	invoice, err := h.service.IssueInvoice(c.Request.Context(), userID, orgID, invoiceID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Fatura emitida com sucesso", Data: invoice})
+}
+
+// DeleteInvoice handles DELETE /api/v1/organizations/:id/invoices/:invoiceId
+// Admin only - discards a draft
+func (h *BillingHandler) DeleteInvoice(c *gin.Context) {
+	orgID, invoiceID, userID, ok := invoiceParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteInvoice(c.Request.Context(), userID, orgID, invoiceID); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Rascunho descartado com sucesso"})
+}
+
+// GetInvoicePDF handles GET /api/v1/organizations/:id/invoices/:invoiceId/pdf
+// Admin only
+func (h *BillingHandler) GetInvoicePDF(c *gin.Context) {
+	h.invoiceFile(c, "pdf")
+}
+
+// GetInvoiceCSV handles GET /api/v1/organizations/:id/invoices/:invoiceId/csv
+// Admin only
+func (h *BillingHandler) GetInvoiceCSV(c *gin.Context) {
+	h.invoiceFile(c, "csv")
+}
+
+func (h *BillingHandler) invoiceFile(c *gin.Context, format string) {
+	orgID, invoiceID, userID, ok := invoiceParams(c)
+	if !ok {
+		return
+	}
+
+	content, filename, contentType, err := h.service.InvoiceFile(c.Request.Context(), userID, orgID, invoiceID, format)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	c.Data(http.StatusOK, contentType, content)
+}
+
+// invoiceParams parses the organization and invoice IDs of the path and the
+// requesting user, writing the error response when one is invalid
+func invoiceParams(c *gin.Context) (orgID, invoiceID, userID uuid.UUID, ok bool) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	invoiceID, err = uuid.Parse(c.Param("invoiceId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da fatura inválido"})
+		return
+	}
+
+	userID, ok = requestUserID(c)
+	return
+}
+
+func (h *BillingHandler) writeError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "apenas administradores podem gerenciar o faturamento":
+		c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+	case "cliente não encontrado", "fatura não encontrada", "usuário não é membro desta organização":
+		c.JSON(http.StatusNotFound, domain.HttpResponse{Status: http.StatusNotFound, Message: err.Error()})
+	case "apenas rascunhos podem ser emitidos", "apenas rascunhos podem ser descartados", "outra fatura foi emitida ao mesmo tempo, tente novamente":
+		c.JSON(http.StatusConflict, domain.HttpResponse{Status: http.StatusConflict, Message: err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+	}
+}
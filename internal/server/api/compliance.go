@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+)
+
+type ComplianceHandler struct {
+	service *service.ComplianceService
+}
+
+func NewComplianceHandler(cs *service.ComplianceService) *ComplianceHandler {
+	return &ComplianceHandler{cs}
+}
+
+// Report handles GET /api/v1/organizations/:id/compliance?month=YYYY-MM
+// Admin only - returns the labor-law violations of each member in the month,
+// the current one by default
+func (h *ComplianceHandler) Report(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if m := c.Query("month"); m != "" {
+		month, err = domain.ParseMonth(m)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+			return
+		}
+	}
+
+	report, err := h.service.Report(c.Request.Context(), userID, orgID, month)
+	if err != nil {
+		if err.Error() == "apenas administradores podem acompanhar a conformidade da organização" {
+			c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, domain.HttpResponse{Status: http.StatusInternalServerError, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Conformidade da organização", Data: report})
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+)
+
+type EspelhoHandler struct {
+	service *service.EspelhoService
+}
+
+func NewEspelhoHandler(es *service.EspelhoService) *EspelhoHandler {
+	return &EspelhoHandler{es}
+}
+
+// GetMyPDF handles GET /api/v1/organizations/:id/espelho/:month/pdf
+// Returns the authenticated user's espelho de ponto of the month (YYYY-MM)
+func (h *EspelhoHandler) GetMyPDF(c *gin.Context) {
+	orgID, month, ok := espelhoParams(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	h.writePDF(c, userID, userID, orgID, month)
+}
+
+// GetUserPDF handles GET /api/v1/organizations/:id/users/:userId/espelho/:month/pdf
+// Admins and the member's managers can download the espelho of other members
+func (h *EspelhoHandler) GetUserPDF(c *gin.Context) {
+	orgID, month, ok := espelhoParams(c)
+	if !ok {
+		return
+	}
+
+	targetUserID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID do usuário inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	h.writePDF(c, userID, targetUserID, orgID, month)
+}
+
+// Sign handles POST /api/v1/organizations/:id/espelho/:month/sign
+// Records the authenticated user's acknowledgment of the espelho whose hash is posted
+func (h *EspelhoHandler) Sign(c *gin.Context) {
+	orgID, month, ok := espelhoParams(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var se domain.SignEspelho
+	if err := c.ShouldBind(&se); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	sig, err := h.service.Sign(c.Request.Context(), userID, orgID, month, se)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusCreated, domain.HttpResponse{Status: http.StatusCreated, Message: "Espelho de ponto assinado com sucesso", Data: sig})
+}
+
+// Signatures handles GET /api/v1/organizations/:id/espelho/:month/signatures
+// Admin only - lists which members signed the espelho of the month
+func (h *EspelhoHandler) Signatures(c *gin.Context) {
+	orgID, month, ok := espelhoParams(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	page, ok := pageRequest(c, domain.CursorTimestamp, domain.CursorUUID)
+	if !ok {
+		return
+	}
+
+	statuses, err := h.service.Signatures(c.Request.Context(), userID, orgID, month, page)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	writePage(c, "Assinaturas dos espelhos de ponto", page, *statuses)
+}
+
+func (h *EspelhoHandler) writePDF(c *gin.Context, requestingUserID, targetUserID, orgID uuid.UUID, month time.Time) {
+	doc, err := h.service.Document(c.Request.Context(), requestingUserID, targetUserID, orgID, month)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"%s\"", doc.FileName))
+	c.Header("X-Document-Hash", doc.Hash)
+	c.Data(http.StatusOK, "application/pdf", doc.Content)
+}
+
+func espelhoParams(c *gin.Context) (uuid.UUID, time.Time, bool) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return uuid.Nil, time.Time{}, false
+	}
+
+	month, err := domain.ParseMonth(c.Param("month"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return uuid.Nil, time.Time{}, false
+	}
+
+	return orgID, month, true
+}
+
+func (h *EspelhoHandler) writeError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "usuário não é membro desta organização",
+		"apenas administradores e gestores podem visualizar timesheets de outros usuários",
+		"apenas administradores podem acompanhar as assinaturas dos espelhos":
+		c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+	case "o espelho deste mês já foi assinado", "o espelho foi alterado, baixe a versão atual antes de assinar":
+		c.JSON(http.StatusConflict, domain.HttpResponse{Status: http.StatusConflict, Message: err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+	}
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	"github.com/marcelorc13/timesheet-pro/internal/export"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+)
+
+type ExportHandler struct {
+	service *service.ExportService
+}
+
+func NewExportHandler(es *service.ExportService) *ExportHandler {
+	return &ExportHandler{es}
+}
+
+// AFD handles GET /api/v1/organizations/:id/exports/afd?start=YYYY-MM-DD&end=YYYY-MM-DD
+// Admin only - downloads the AFD file (Portaria 671) of the period
+func (h *ExportHandler) AFD(c *gin.Context) {
+	orgID, userID, start, end, ok := exportParams(c)
+	if !ok {
+		return
+	}
+
+	content, filename, err := h.service.AFD(c.Request.Context(), userID, orgID, start, end)
+	if err != nil {
+		writeExportError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	c.Data(http.StatusOK, "text/plain; charset=iso-8859-1", content)
+}
+
+// AEJ handles GET /api/v1/organizations/:id/exports/aej?start=YYYY-MM-DD&end=YYYY-MM-DD&member=
+// Admin only - downloads the AEJ file (Portaria 671) of the period, optionally for one member
+func (h *ExportHandler) AEJ(c *gin.Context) {
+	orgID, userID, start, end, ok := exportParams(c)
+	if !ok {
+		return
+	}
+
+	memberID, ok := exportMember(c)
+	if !ok {
+		return
+	}
+
+	content, filename, err := h.service.AEJ(c.Request.Context(), userID, orgID, start, end, memberID)
+	if err != nil {
+		writeExportError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	c.Data(http.StatusOK, "text/plain; charset=iso-8859-1", content)
+}
+
+// CSV handles GET /api/v1/organizations/:id/exports/csv?start=&end=&member=&summary=true
+// Admin only - streams one row per day, or the per member summary when summary=true
+func (h *ExportHandler) CSV(c *gin.Context) {
+	summaryOnly := c.Query("summary") == "true"
+	filename := "timesheets.csv"
+	if summaryOnly {
+		filename = "timesheets-resumo.csv"
+	}
+
+	h.streamTimesheets(c, "text/csv; charset=utf-8", filename, func() (export.TimesheetWriter, error) {
+		return export.NewTimesheetCSV(c.Writer, summaryOnly)
+	})
+}
+
+// XLSX handles GET /api/v1/organizations/:id/exports/xlsx?start=&end=&member=
+// Admin only - streams a workbook with a sheet of days and a summary sheet per member
+func (h *ExportHandler) XLSX(c *gin.Context) {
+	h.streamTimesheets(c, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "timesheets.xlsx", func() (export.TimesheetWriter, error) {
+		return export.NewTimesheetXLSX(c.Writer)
+	})
+}
+
+func (h *ExportHandler) streamTimesheets(c *gin.Context, contentType, filename string, newWriter func() (export.TimesheetWriter, error)) {
+	orgID, userID, start, end, ok := exportParams(c)
+	if !ok {
+		return
+	}
+
+	memberID, ok := exportMember(c)
+	if !ok {
+		return
+	}
+
+	err := h.service.Timesheets(c.Request.Context(), userID, orgID, start, end, memberID, func() (export.TimesheetWriter, error) {
+		c.Header("Content-Type", contentType)
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+		c.Status(http.StatusOK)
+		return newWriter()
+	})
+	if err != nil {
+		if c.Writer.Written() {
+			// Streaming already started, the client gets a truncated file
+			c.Error(err)
+			return
+		}
+		c.Writer.Header().Del("Content-Disposition")
+		writeExportError(c, err)
+	}
+}
+
+// exportParams reads the organization, the authenticated user and the period
+// of an export request, writing the error response when any is invalid
+func exportParams(c *gin.Context) (uuid.UUID, uuid.UUID, time.Time, time.Time, bool) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return uuid.Nil, uuid.Nil, time.Time{}, time.Time{}, false
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, time.Time{}, time.Time{}, false
+	}
+
+	start, err := time.ParseInLocation("2006-01-02", c.Query("start"), time.Local)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "formato de data inicial inválido (use YYYY-MM-DD)"})
+		return uuid.Nil, uuid.Nil, time.Time{}, time.Time{}, false
+	}
+
+	end, err := time.ParseInLocation("2006-01-02", c.Query("end"), time.Local)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "formato de data final inválido (use YYYY-MM-DD)"})
+		return uuid.Nil, uuid.Nil, time.Time{}, time.Time{}, false
+	}
+
+	return orgID, userID, start, end, true
+}
+
+// exportMember reads the optional member filter of an export request
+func exportMember(c *gin.Context) (*uuid.UUID, bool) {
+	member := c.Query("member")
+	if member == "" {
+		return nil, true
+	}
+
+	id, err := uuid.Parse(member)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID do usuário inválido"})
+		return nil, false
+	}
+
+	return &id, true
+}
+
+func writeExportError(c *gin.Context, err error) {
+	if err.Error() == "apenas administradores podem exportar dados da organização" {
+		c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusUnprocessableEntity, domain.HttpResponse{Status: http.StatusUnprocessableEntity, Message: err.Error()})
+}
@@ -0,0 +1,230 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+)
+
+// kioskCookieMaxAge keeps an activated kiosk signed in for a year
+const kioskCookieMaxAge = 365 * 24 * 60 * 60
+
+type KioskHandler struct {
+	service *service.KioskService
+}
+
+func NewKioskHandler(ks *service.KioskService) *KioskHandler {
+	return &KioskHandler{ks}
+}
+
+// RegisterDevice handles POST /api/v1/organizations/:id/kiosk/devices
+// Admin only - registers a shared terminal; the token is only returned here
+func (h *KioskHandler) RegisterDevice(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var cd domain.CreateKioskDevice
+	if err := c.ShouldBind(&cd); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	device, err := h.service.RegisterDevice(c.Request.Context(), userID, orgID, cd)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, domain.HttpResponse{Status: http.StatusCreated, Message: "Terminal cadastrado com sucesso", Data: device})
+}
+
+// ListDevices handles GET /api/v1/organizations/:id/kiosk/devices
+// Admin only
+func (h *KioskHandler) ListDevices(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	page, ok := pageRequest(c, domain.CursorTimestamp, domain.CursorUUID)
+	if !ok {
+		return
+	}
+
+	devices, err := h.service.ListDevices(c.Request.Context(), userID, orgID, page)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	writePage(c, "Terminais de ponto da organização", page, *devices)
+}
+
+// RevokeDevice handles POST /api/v1/organizations/:id/kiosk/devices/:deviceId/revoke
+// Admin only
+func (h *KioskHandler) RevokeDevice(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	deviceID, err := uuid.Parse(c.Param("deviceId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID do terminal inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RevokeDevice(c.Request.Context(), userID, orgID, deviceID); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Terminal revogado com sucesso"})
+}
+
+// SetMemberCredentials handles PUT /api/v1/organizations/:id/users/:userId/kiosk-credentials
+// Admin only - sets or clears (empty string) the member PIN and badge code
+func (h *KioskHandler) SetMemberCredentials(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	memberID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID do usuário inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var kc domain.KioskCredentials
+	if err := c.ShouldBind(&kc); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	if err := h.service.SetMemberCredentials(c.Request.Context(), userID, orgID, memberID, kc); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Credenciais salvas com sucesso"})
+}
+
+// Activate handles POST /api/v1/kiosk/activate
+// Stores the device token in a cookie so the browser works as the kiosk
+func (h *KioskHandler) Activate(c *gin.Context) {
+	var ak domain.ActivateKiosk
+	if err := c.ShouldBind(&ak); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	device, err := h.service.Authenticate(c.Request.Context(), ak.Token)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.SetCookie(domain.KioskTokenCookie, ak.Token, kioskCookieMaxAge, "/", "", true, true)
+	c.Header("HX-Redirect", "/kiosk")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Terminal ativado", Data: device})
+}
+
+// Deactivate handles POST /api/v1/kiosk/deactivate
+// Removes the device token from the browser
+func (h *KioskHandler) Deactivate(c *gin.Context) {
+	c.SetCookie(domain.KioskTokenCookie, "", -1, "/", "", true, true)
+	c.Header("HX-Redirect", "/kiosk")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Terminal desativado"})
+}
+
+// Punch handles POST /api/v1/kiosk/punch
+// Kiosk device only (X-Kiosk-Token header or kiosk cookie) - clocks in/out
+// the member identified by PIN or badge code and returns the punch receipt
+func (h *KioskHandler) Punch(c *gin.Context) {
+	device, ok := h.requestDevice(c)
+	if !ok {
+		return
+	}
+
+	var pr domain.KioskPunchRequest
+	if err := c.ShouldBind(&pr); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	receipt, err := h.service.Punch(c.Request.Context(), *device, pr)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: receipt.TypeID.Label() + " registrada", Data: receipt})
+}
+
+// requestDevice authenticates the kiosk device of the request, writing the
+// error response when the token is missing or not valid
+func (h *KioskHandler) requestDevice(c *gin.Context) (*domain.KioskDevice, bool) {
+	token := c.GetHeader(domain.KioskTokenHeader)
+	if token == "" {
+		token, _ = c.Cookie(domain.KioskTokenCookie)
+	}
+
+	device, err := h.service.Authenticate(c.Request.Context(), token)
+	if err != nil {
+		h.writeError(c, err)
+		return nil, false
+	}
+
+	return device, true
+}
+
+func (h *KioskHandler) writeError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "terminal não autorizado":
+		c.JSON(http.StatusUnauthorized, domain.HttpResponse{Status: http.StatusUnauthorized, Message: err.Error()})
+	case "apenas administradores podem gerenciar terminais de ponto", "usuário não é membro desta organização":
+		c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+	case "terminal não encontrado", "membro não encontrado":
+		c.JSON(http.StatusNotFound, domain.HttpResponse{Status: http.StatusNotFound, Message: err.Error()})
+	case "PIN ou crachá não reconhecido":
+		c.JSON(http.StatusUnauthorized, domain.HttpResponse{Status: http.StatusUnauthorized, Message: err.Error()})
+	case "PIN ou crachá já está em uso nesta organização":
+		c.JSON(http.StatusConflict, domain.HttpResponse{Status: http.StatusConflict, Message: err.Error()})
+	case "muitas tentativas neste terminal, aguarde um minuto":
+		c.JSON(http.StatusTooManyRequests, domain.HttpResponse{Status: http.StatusTooManyRequests, Message: err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+	}
+}
@@ -227,6 +227,38 @@ func (h OrganizationHandler) Delete(c *gin.Context) {
 	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Organização deletada com sucesso"})
 }
 
+// ListMembers handles GET /api/v1/organizations/:id/users
+// Returns a page of the organization members
+func (h OrganizationHandler) ListMembers(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	page, ok := pageRequest(c, domain.CursorTimestamp, domain.CursorUUID)
+	if !ok {
+		return
+	}
+
+	members, err := h.service.ListMembers(c.Request.Context(), userID, orgID, page)
+	if err != nil {
+		if err.Error() == "usuário não é membro desta organização" {
+			c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	writePage(c, "Membros da organização", page, *members)
+}
+
 func (h OrganizationHandler) AddUser(c *gin.Context) {
 	id := c.Param("id")
 
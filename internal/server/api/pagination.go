@@ -0,0 +1,34 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+// pageRequest reads the limit and cursor query parameters of a listing whose
+// cursor key and ID have the given types, writing the error response when
+// they are invalid
+func pageRequest(c *gin.Context, key, id domain.CursorField) (domain.PageRequest, bool) {
+	page, err := domain.ParsePageRequest(c.Query("limit"), c.Query("cursor"))
+	if err == nil {
+		err = page.Check(key, id)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return page, false
+	}
+	return page, true
+}
+
+// writePage responds with the page items, the limit used and the cursor of the next page
+func writePage[T any](c *gin.Context, message string, page domain.PageRequest, res domain.CursorPage[T]) {
+	c.JSON(http.StatusOK, domain.HttpResponse{
+		Status:     http.StatusOK,
+		Message:    message,
+		Data:       res.Items,
+		Limit:      page.Limit,
+		NextCursor: res.NextCursor,
+	})
+}
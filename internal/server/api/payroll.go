@@ -0,0 +1,213 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+)
+
+type PayrollHandler struct {
+	service *service.PayrollService
+}
+
+func NewPayrollHandler(ps *service.PayrollService) *PayrollHandler {
+	return &PayrollHandler{ps}
+}
+
+// GetSettings handles GET /api/v1/organizations/:id/payroll/settings
+// Admin only - returns the payroll event codes and file layout of the organization
+func (h *PayrollHandler) GetSettings(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	settings, err := h.service.Settings(c.Request.Context(), userID, orgID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Configuração da folha de pagamento", Data: settings})
+}
+
+// UpdateSettings handles PUT /api/v1/organizations/:id/payroll/settings
+// Admin only - replaces the payroll event codes and file layout of the organization
+func (h *PayrollHandler) UpdateSettings(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var us domain.UpdatePayrollSettings
+	if err := c.ShouldBind(&us); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	settings, err := h.service.UpdateSettings(c.Request.Context(), userID, orgID, us)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Configuração da folha de pagamento salva", Data: settings})
+}
+
+// Export handles GET /api/v1/organizations/:id/exports/payroll?start=YYYY-MM-DD&end=YYYY-MM-DD
+// Admin only - downloads the payroll events of the period in the organization layout
+func (h *PayrollHandler) Export(c *gin.Context) {
+	orgID, userID, start, end, ok := exportParams(c)
+	if !ok {
+		return
+	}
+
+	content, filename, contentType, err := h.service.Export(c.Request.Context(), userID, orgID, start, end)
+	if err != nil {
+		writeExportError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	c.Data(http.StatusOK, contentType, content)
+}
+
+// ListPayPeriods handles GET /api/v1/organizations/:id/pay-periods
+// Admin only - lists the closed and reopened months of the organization
+func (h *PayrollHandler) ListPayPeriods(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	periods, err := h.service.PayPeriods(c.Request.Context(), userID, orgID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Períodos de folha", Data: periods})
+}
+
+// GetPayPeriod handles GET /api/v1/organizations/:id/pay-periods/:month
+// Admin only - returns the month (YYYY-MM) with the totals kept when it was closed
+func (h *PayrollHandler) GetPayPeriod(c *gin.Context) {
+	orgID, userID, month, ok := payPeriodParams(c)
+	if !ok {
+		return
+	}
+
+	period, err := h.service.PayPeriod(c.Request.Context(), userID, orgID, month)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	if period == nil {
+		c.JSON(http.StatusNotFound, domain.HttpResponse{Status: http.StatusNotFound, Message: "período de folha não encontrado"})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Período de folha", Data: period})
+}
+
+// ClosePayPeriod handles POST /api/v1/organizations/:id/pay-periods/:month/close
+// Admin only - closes an ended month, keeping the totals and locking its timesheets
+func (h *PayrollHandler) ClosePayPeriod(c *gin.Context) {
+	orgID, userID, month, ok := payPeriodParams(c)
+	if !ok {
+		return
+	}
+
+	period, err := h.service.ClosePayPeriod(c.Request.Context(), userID, orgID, month)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Período de folha fechado", Data: period})
+}
+
+// ReopenPayPeriod handles POST /api/v1/organizations/:id/pay-periods/:month/reopen
+// Admin only - reopens a closed month with the reason given
+func (h *PayrollHandler) ReopenPayPeriod(c *gin.Context) {
+	orgID, userID, month, ok := payPeriodParams(c)
+	if !ok {
+		return
+	}
+
+	var rp domain.ReopenPayPeriod
+	if err := c.ShouldBind(&rp); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	period, err := h.service.ReopenPayPeriod(c.Request.Context(), userID, orgID, month, rp)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Período de folha reaberto", Data: period})
+}
+
+func payPeriodParams(c *gin.Context) (uuid.UUID, uuid.UUID, time.Time, bool) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return uuid.Nil, uuid.Nil, time.Time{}, false
+	}
+
+	month, err := domain.ParseMonth(c.Param("month"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return uuid.Nil, uuid.Nil, time.Time{}, false
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, time.Time{}, false
+	}
+
+	return orgID, userID, month, true
+}
+
+func (h *PayrollHandler) writeError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "apenas administradores podem configurar a folha de pagamento",
+		"apenas administradores podem fechar ou reabrir períodos de folha":
+		c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+	case "período de folha não encontrado":
+		c.JSON(http.StatusNotFound, domain.HttpResponse{Status: http.StatusNotFound, Message: err.Error()})
+	case "o período já está fechado", "o período não está fechado":
+		c.JSON(http.StatusConflict, domain.HttpResponse{Status: http.StatusConflict, Message: err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+	}
+}
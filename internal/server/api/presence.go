@@ -0,0 +1,105 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+)
+
+// presenceKeepAlive is how often an idle stream sends a comment so proxies
+// do not close the connection
+const presenceKeepAlive = 25 * time.Second
+
+type PresenceHandler struct {
+	service *service.TimesheetService
+}
+
+func NewPresenceHandler(ts *service.TimesheetService) *PresenceHandler {
+	return &PresenceHandler{ts}
+}
+
+// List handles GET /api/v1/organizations/:id/presence
+// Admins and managers - returns who is working now and the last punch of each member
+func (h *PresenceHandler) List(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	presence, err := h.service.Presence(c.Request.Context(), userID, orgID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Presença dos membros", Data: presence})
+}
+
+// Stream handles GET /api/v1/organizations/:id/presence/stream
+// Admins and managers - Server-Sent Events stream sending a "punch" event for
+// each clock in/out of the members they supervise
+func (h *PresenceHandler) Stream(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	punches, cancel, err := h.service.SubscribePunches(c.Request.Context(), userID, orgID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	defer cancel()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	keepAlive := time.NewTicker(presenceKeepAlive)
+	defer keepAlive.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case ev, ok := <-punches:
+			if !ok {
+				return false
+			}
+			c.SSEvent("punch", ev)
+		case <-keepAlive.C:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func (h *PresenceHandler) writeError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "usuário não é membro desta organização",
+		"apenas administradores e gestores podem visualizar timesheets da organização":
+		c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+	}
+}
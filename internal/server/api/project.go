@@ -0,0 +1,310 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+)
+
+type ProjectHandler struct {
+	service *service.ProjectService
+}
+
+func NewProjectHandler(ps *service.ProjectService) *ProjectHandler {
+	return &ProjectHandler{ps}
+}
+
+// Create handles POST /api/v1/organizations/:id/projects
+// Admin only - adds a project members can allocate their hours to
+func (h *ProjectHandler) Create(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var cp domain.CreateProject
+	if err := c.ShouldBind(&cp); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	project, err := h.service.CreateProject(c.Request.Context(), userID, orgID, cp)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusCreated, domain.HttpResponse{Status: http.StatusCreated, Message: "Projeto criado com sucesso", Data: project})
+}
+
+// List handles GET /api/v1/organizations/:id/projects?archived=true
+// Members - returns the active projects and their tasks; admins may include
+// the archived ones
+func (h *ProjectHandler) List(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	projects, err := h.service.Projects(c.Request.Context(), userID, orgID, c.Query("archived") == "true")
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Projetos da organização", Data: projects})
+}
+
+// Update handles PUT /api/v1/organizations/:id/projects/:projectId
+// Admin only - changes a project; omitted fields are kept
+func (h *ProjectHandler) Update(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	projectID, err := uuid.Parse(c.Param("projectId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID do projeto inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var up domain.UpdateProject
+	if err := c.ShouldBind(&up); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	project, err := h.service.UpdateProject(c.Request.Context(), userID, orgID, projectID, up)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Projeto atualizado com sucesso", Data: project})
+}
+
+// CreateTask handles POST /api/v1/organizations/:id/projects/:projectId/tasks
+// Admin only - adds a task to the project
+func (h *ProjectHandler) CreateTask(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	projectID, err := uuid.Parse(c.Param("projectId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID do projeto inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var ct domain.CreateProjectTask
+	if err := c.ShouldBind(&ct); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	task, err := h.service.CreateTask(c.Request.Context(), userID, orgID, projectID, ct)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusCreated, domain.HttpResponse{Status: http.StatusCreated, Message: "Tarefa criada com sucesso", Data: task})
+}
+
+// DeleteTask handles DELETE /api/v1/organizations/:id/projects/:projectId/tasks/:taskId
+// Admin only - removes a task; its allocations stay on the project
+func (h *ProjectHandler) DeleteTask(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	projectID, err := uuid.Parse(c.Param("projectId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID do projeto inválido"})
+		return
+	}
+
+	taskID, err := uuid.Parse(c.Param("taskId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da tarefa inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteTask(c.Request.Context(), userID, orgID, projectID, taskID); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Tarefa removida com sucesso"})
+}
+
+// Report handles GET /api/v1/organizations/:id/projects/report?start=YYYY-MM-DD&end=YYYY-MM-DD&project=
+// Admin only - returns the allocated hours of the period by project and
+// member, with the billable amount of each
+func (h *ProjectHandler) Report(c *gin.Context) {
+	orgID, userID, start, end, ok := exportParams(c)
+	if !ok {
+		return
+	}
+
+	var projectID *uuid.UUID
+	if p := c.Query("project"); p != "" {
+		id, err := uuid.Parse(p)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID do projeto inválido"})
+			return
+		}
+		projectID = &id
+	}
+
+	report, err := h.service.Report(c.Request.Context(), userID, orgID, start, end, projectID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Horas por projeto", Data: report})
+}
+
+// CreateAllocation handles POST /api/v1/organizations/:id/time-allocations
+// Members - allocates a worked interval or minutes of a day to a project
+func (h *ProjectHandler) CreateAllocation(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var ca domain.CreateTimeAllocation
+	if err := c.ShouldBind(&ca); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	allocation, err := h.service.CreateAllocation(c.Request.Context(), userID, orgID, ca)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusCreated, domain.HttpResponse{Status: http.StatusCreated, Message: "Horas apontadas com sucesso", Data: allocation})
+}
+
+// ListMyAllocations handles GET /api/v1/organizations/:id/time-allocations/me?date=YYYY-MM-DD
+// Members - returns the user allocations of the day, today by default
+func (h *ProjectHandler) ListMyAllocations(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	date := time.Now()
+	if d := c.Query("date"); d != "" {
+		date, err = time.ParseInLocation("2006-01-02", d, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "formato de data inválido (use YYYY-MM-DD)"})
+			return
+		}
+	}
+
+	allocations, err := h.service.Allocations(c.Request.Context(), userID, orgID, date)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Apontamentos de horas", Data: allocations})
+}
+
+// DeleteAllocation handles DELETE /api/v1/organizations/:id/time-allocations/:allocationId
+// Members - removes one of the user allocations
+func (h *ProjectHandler) DeleteAllocation(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	allocationID, err := uuid.Parse(c.Param("allocationId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID do apontamento inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteAllocation(c.Request.Context(), userID, orgID, allocationID); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Apontamento removido com sucesso"})
+}
+
+func (h *ProjectHandler) writeError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "apenas administradores podem gerenciar projetos", "usuário não é membro desta organização":
+		c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+	case "projeto não encontrado", "tarefa não encontrada", "apontamento de horas não encontrado", "cliente não encontrado":
+		c.JSON(http.StatusNotFound, domain.HttpResponse{Status: http.StatusNotFound, Message: err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+	}
+}
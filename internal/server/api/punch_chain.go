@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+)
+
+type PunchChainHandler struct {
+	service *service.PunchChainService
+}
+
+func NewPunchChainHandler(pcs *service.PunchChainService) *PunchChainHandler {
+	return &PunchChainHandler{pcs}
+}
+
+// Verify handles GET /api/v1/organizations/:id/chain/verify
+// Admin only - walks the organization hash chain and reports any break
+func (h *PunchChainHandler) Verify(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.Verify(c.Request.Context(), userID, orgID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	message := "Cadeia de registros íntegra"
+	if !report.Valid {
+		message = fmt.Sprintf("Cadeia de registros com %d inconsistência(s)", len(report.Breaks))
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: message, Data: report})
+}
+
+// Export handles GET /api/v1/organizations/:id/chain/export
+// Admin only - downloads the chain signed with the organization key
+func (h *PunchChainHandler) Export(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	signed, err := h.service.Export(c.Request.Context(), userID, orgID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"registros-%s.json\"", orgID))
+	c.JSON(http.StatusOK, signed)
+}
+
+// PublicKey handles GET /api/v1/organizations/:id/chain/public-key
+// Public - returns the key third parties use to check exported data
+func (h *PunchChainHandler) PublicKey(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	key, err := h.service.PublicKey(c.Request.Context(), orgID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Chave pública da organização", Data: gin.H{
+		"organization_id": key.OrganizationID,
+		"algorithm":       key.Algorithm,
+		"public_key":      base64.StdEncoding.EncodeToString(key.PublicKey),
+		"created_at":      key.CreatedAt,
+	}})
+}
+
+func (h *PunchChainHandler) writeError(c *gin.Context, err error) {
+	if err.Error() == "apenas administradores podem verificar a integridade dos registros" {
+		c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+}
@@ -0,0 +1,302 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+)
+
+type PunchPolicyHandler struct {
+	service *service.PunchPolicyService
+}
+
+func NewPunchPolicyHandler(ps *service.PunchPolicyService) *PunchPolicyHandler {
+	return &PunchPolicyHandler{ps}
+}
+
+// GetPolicy handles GET /api/v1/organizations/:id/punch-policy
+// Admin only
+func (h *PunchPolicyHandler) GetPolicy(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	policy, err := h.service.Policy(c.Request.Context(), userID, orgID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Política de registro de ponto", Data: policy})
+}
+
+// UpdatePolicy handles PUT /api/v1/organizations/:id/punch-policy
+// Admin only - fields left out of the body are kept
+func (h *PunchPolicyHandler) UpdatePolicy(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var up domain.UpdatePunchPolicy
+	if err := c.ShouldBind(&up); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	policy, err := h.service.UpdatePolicy(c.Request.Context(), userID, orgID, up)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Política de registro de ponto salva com sucesso", Data: policy})
+}
+
+// CurrentQR handles GET /api/v1/organizations/:id/punch-policy/qr
+// Admin only - returns the on-site code for custom displays; it rotates at expires_at
+func (h *PunchPolicyHandler) CurrentQR(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	qr, err := h.service.CurrentQR(c.Request.Context(), userID, orgID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "QR code de registro de ponto", Data: qr})
+}
+
+// CreateGeofence handles POST /api/v1/organizations/:id/geofences
+// Admin only - adds a circle or polygon where members may punch
+func (h *PunchPolicyHandler) CreateGeofence(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var cg domain.CreateGeofence
+	if err := c.ShouldBind(&cg); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	fence, err := h.service.CreateGeofence(c.Request.Context(), userID, orgID, cg)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusCreated, domain.HttpResponse{Status: http.StatusCreated, Message: "Área criada com sucesso", Data: fence})
+}
+
+// CreateGeofenceFromAddress handles POST /api/v1/organizations/:id/geofences/from-address
+// Admin only - adds a circle around the organization address coordinates
+func (h *PunchPolicyHandler) CreateGeofenceFromAddress(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var fa domain.GeofenceFromAddress
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBind(&fa); err != nil {
+			c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+			return
+		}
+	}
+
+	fence, err := h.service.CreateGeofenceFromAddress(c.Request.Context(), userID, orgID, fa.RadiusMeters)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusCreated, domain.HttpResponse{Status: http.StatusCreated, Message: "Área criada com sucesso", Data: fence})
+}
+
+// DeleteGeofence handles DELETE /api/v1/organizations/:id/geofences/:geofenceId
+// Admin only
+func (h *PunchPolicyHandler) DeleteGeofence(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	geofenceID, err := uuid.Parse(c.Param("geofenceId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da área inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteGeofence(c.Request.Context(), userID, orgID, geofenceID); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Área removida com sucesso"})
+}
+
+// CreateNetwork handles POST /api/v1/organizations/:id/punch-networks
+// Admin only - adds an IP range in CIDR notation members may punch from
+func (h *PunchPolicyHandler) CreateNetwork(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var cn domain.CreatePunchNetwork
+	if err := c.ShouldBind(&cn); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	network, err := h.service.CreateNetwork(c.Request.Context(), userID, orgID, cn)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusCreated, domain.HttpResponse{Status: http.StatusCreated, Message: "Rede adicionada com sucesso", Data: network})
+}
+
+// DeleteNetwork handles DELETE /api/v1/organizations/:id/punch-networks/:networkId
+// Admin only
+func (h *PunchPolicyHandler) DeleteNetwork(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	networkID, err := uuid.Parse(c.Param("networkId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da rede inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteNetwork(c.Request.Context(), userID, orgID, networkID); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Rede removida com sucesso"})
+}
+
+// OutOfFencePunches handles GET /api/v1/organizations/:id/out-of-fence-punches
+// Admin only - punches made outside every area, newest first
+func (h *PunchPolicyHandler) OutOfFencePunches(c *gin.Context) {
+	h.flaggedPunches(c, domain.PunchFlagGeofence, "Registros de ponto fora das áreas permitidas")
+}
+
+// FlaggedPunches handles GET /api/v1/organizations/:id/flagged-punches?flag=geofence|network
+// Admin only - punches flagged for review, newest first; every flag when none is given
+func (h *PunchPolicyHandler) FlaggedPunches(c *gin.Context) {
+	flag := domain.PunchFlag(c.Query("flag"))
+	switch flag {
+	case "", domain.PunchFlagGeofence, domain.PunchFlagNetwork:
+	default:
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "motivo de revisão inválido"})
+		return
+	}
+
+	h.flaggedPunches(c, flag, "Registros de ponto para revisão")
+}
+
+func (h *PunchPolicyHandler) flaggedPunches(c *gin.Context, flag domain.PunchFlag, message string) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	page, ok := pageRequest(c, domain.CursorTimestamp, domain.CursorUUID)
+	if !ok {
+		return
+	}
+
+	punches, err := h.service.FlaggedPunches(c.Request.Context(), userID, orgID, flag, page)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	writePage(c, message, page, *punches)
+}
+
+func (h *PunchPolicyHandler) writeError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "apenas administradores podem configurar o registro de ponto":
+		c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+	case "área de registro de ponto não encontrada", "rede de registro de ponto não encontrada":
+		c.JSON(http.StatusNotFound, domain.HttpResponse{Status: http.StatusNotFound, Message: err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+	}
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	"github.com/marcelorc13/timesheet-pro/internal/pdf"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+)
+
+type ReceiptHandler struct {
+	service *service.ReceiptService
+}
+
+func NewReceiptHandler(rs *service.ReceiptService) *ReceiptHandler {
+	return &ReceiptHandler{rs}
+}
+
+// ListMine handles GET /api/v1/organizations/:id/receipts/me?limit=&cursor=
+// Returns the authenticated user's latest punch receipts
+func (h *ReceiptHandler) ListMine(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	page, ok := pageRequest(c, domain.CursorInt, domain.CursorUUID)
+	if !ok {
+		return
+	}
+
+	receipts, err := h.service.ListMine(c.Request.Context(), userID, orgID, page)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	writePage(c, "Comprovantes de registro de ponto", page, *receipts)
+}
+
+// GetByID handles GET /api/v1/receipts/:id
+func (h *ReceiptHandler) GetByID(c *gin.Context) {
+	receipt, ok := h.receipt(c, h.service.GetByID)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Comprovante encontrado", Data: receipt})
+}
+
+// GetPDF handles GET /api/v1/receipts/:id/pdf
+func (h *ReceiptHandler) GetPDF(c *gin.Context) {
+	receipt, ok := h.receipt(c, h.service.GetByID)
+	if !ok {
+		return
+	}
+
+	h.writePDF(c, receipt)
+}
+
+// GetEntryPDF handles GET /api/v1/timesheet-entries/:id/receipt/pdf
+// Used by the timesheet page, which lists entries rather than receipts
+func (h *ReceiptHandler) GetEntryPDF(c *gin.Context) {
+	receipt, ok := h.receipt(c, h.service.GetByEntryID)
+	if !ok {
+		return
+	}
+
+	h.writePDF(c, receipt)
+}
+
+func (h *ReceiptHandler) receipt(c *gin.Context, get func(ctx context.Context, userID, id uuid.UUID) (*domain.PunchReceipt, error)) (*domain.PunchReceipt, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID inválido"})
+		return nil, false
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return nil, false
+	}
+
+	receipt, err := get(c.Request.Context(), userID, id)
+	if err != nil {
+		h.writeError(c, err)
+		return nil, false
+	}
+
+	return receipt, true
+}
+
+func (h *ReceiptHandler) writePDF(c *gin.Context, receipt *domain.PunchReceipt) {
+	content, err := pdf.Receipt(*receipt)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.HttpResponse{Status: http.StatusInternalServerError, Message: "erro ao gerar PDF do comprovante"})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"comprovante-%s.pdf\"", domain.FormatNSR(receipt.NSR)))
+	c.Data(http.StatusOK, "application/pdf", content)
+}
+
+func (h *ReceiptHandler) writeError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "comprovante não encontrado":
+		c.JSON(http.StatusNotFound, domain.HttpResponse{Status: http.StatusNotFound, Message: err.Error()})
+	case "usuário não é membro desta organização", "você não tem permissão para visualizar este comprovante":
+		c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+	}
+}
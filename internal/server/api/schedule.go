@@ -0,0 +1,147 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+)
+
+type ScheduleHandler struct {
+	service *service.ScheduleService
+}
+
+func NewScheduleHandler(ss *service.ScheduleService) *ScheduleHandler {
+	return &ScheduleHandler{ss}
+}
+
+// Create handles POST /api/v1/organizations/:id/schedules
+// Admin only - creates a contracted schedule
+func (h *ScheduleHandler) Create(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var cs domain.CreateWorkSchedule
+	if err := c.ShouldBind(&cs); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	schedule, err := h.service.Create(c.Request.Context(), userID, orgID, cs)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, domain.HttpResponse{Status: http.StatusCreated, Message: "Horário contratual criado com sucesso", Data: schedule})
+}
+
+// List handles GET /api/v1/organizations/:id/schedules
+func (h *ScheduleHandler) List(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	page, ok := pageRequest(c, domain.CursorText, domain.CursorUUID)
+	if !ok {
+		return
+	}
+
+	schedules, err := h.service.List(c.Request.Context(), userID, orgID, page)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	writePage(c, "Horários contratuais da organização", page, *schedules)
+}
+
+// Delete handles DELETE /api/v1/organizations/:id/schedules/:scheduleId
+// Admin only
+func (h *ScheduleHandler) Delete(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	scheduleID, err := uuid.Parse(c.Param("scheduleId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID do horário inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), userID, orgID, scheduleID); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Horário contratual removido com sucesso"})
+}
+
+// AssignToMember handles PUT /api/v1/organizations/:id/users/:userId/schedule
+// Admin only - sets or clears (null schedule_id) the member contracted schedule
+func (h *ScheduleHandler) AssignToMember(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	memberID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID do usuário inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var as domain.AssignWorkSchedule
+	if err := c.ShouldBind(&as); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	if err := h.service.AssignToMember(c.Request.Context(), userID, orgID, memberID, as.ScheduleID); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Horário contratual atribuído com sucesso"})
+}
+
+func (h *ScheduleHandler) writeError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "apenas administradores podem gerenciar horários contratuais", "usuário não é membro desta organização":
+		c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+	case "horário contratual não encontrado", "membro ou horário contratual não encontrado":
+		c.JSON(http.StatusNotFound, domain.HttpResponse{Status: http.StatusNotFound, Message: err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+	}
+}
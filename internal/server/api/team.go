@@ -0,0 +1,198 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+)
+
+type TeamHandler struct {
+	service *service.TeamService
+}
+
+func NewTeamHandler(ts *service.TeamService) *TeamHandler {
+	return &TeamHandler{ts}
+}
+
+// Create handles POST /api/v1/organizations/:id/teams
+// Admin only - creates a team in the organization
+func (h *TeamHandler) Create(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var ct domain.CreateTeam
+	if err := c.ShouldBind(&ct); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	teamID, err := h.service.Create(c.Request.Context(), userID, orgID, ct)
+	if err != nil {
+		if err.Error() == "apenas administradores podem gerenciar equipes" {
+			c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusCreated, domain.HttpResponse{Status: http.StatusCreated, Message: "Equipe criada com sucesso", Data: teamID})
+}
+
+// List handles GET /api/v1/organizations/:id/teams
+// Returns the organization teams with their members
+func (h *TeamHandler) List(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	page, ok := pageRequest(c, domain.CursorText, domain.CursorUUID)
+	if !ok {
+		return
+	}
+
+	teams, err := h.service.List(c.Request.Context(), userID, orgID, page)
+	if err != nil {
+		if err.Error() == "usuário não é membro desta organização" {
+			c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	writePage(c, "Equipes da organização", page, *teams)
+}
+
+// Delete handles DELETE /api/v1/organizations/:id/teams/:teamId
+// Admin only - deletes a team (members stay in the organization)
+func (h *TeamHandler) Delete(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	teamID, err := uuid.Parse(c.Param("teamId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da equipe inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	err = h.service.Delete(c.Request.Context(), userID, orgID, teamID)
+	if err != nil {
+		if err.Error() == "apenas administradores podem gerenciar equipes" {
+			c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Equipe removida com sucesso"})
+}
+
+// AddMember handles POST /api/v1/organizations/:id/teams/:teamId/members
+// Admin only - assigns an organization member to the team
+func (h *TeamHandler) AddMember(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	teamID, err := uuid.Parse(c.Param("teamId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da equipe inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var am domain.AddTeamMember
+	if err := c.ShouldBind(&am); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	err = h.service.AddMember(c.Request.Context(), userID, orgID, teamID, am)
+	if err != nil {
+		if err.Error() == "apenas administradores podem gerenciar equipes" {
+			c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusCreated, domain.HttpResponse{Status: http.StatusCreated, Message: "Membro adicionado à equipe"})
+}
+
+// RemoveMember handles DELETE /api/v1/organizations/:id/teams/:teamId/members/:userId
+// Admin only - removes a member from the team
+func (h *TeamHandler) RemoveMember(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	teamID, err := uuid.Parse(c.Param("teamId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da equipe inválido"})
+		return
+	}
+
+	targetUserID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID do usuário inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	err = h.service.RemoveMember(c.Request.Context(), userID, orgID, teamID, targetUserID)
+	if err != nil {
+		if err.Error() == "apenas administradores podem gerenciar equipes" {
+			c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Membro removido da equipe"})
+}
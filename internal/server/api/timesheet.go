@@ -56,12 +56,70 @@ func (h *TimesheetHandler) ClockIn(c *gin.Context) {
 		return
 	}
 
+	// The body is optional; it carries the proof required by the punch policy
+	// and whether the member is leaving for a break
+	var req domain.ClockInRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBind(&req); err != nil {
+			c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+			return
+		}
+	}
+
 	// Call service
-	err = h.service.ClockIn(c.Request.Context(), userID, orgID)
+	result, err := h.service.ClockIn(c.Request.Context(), userID, orgID, req)
 	if err != nil {
 		// Log the error for debugging
 		println("ClockIn error:", err.Error())
 		
+		switch err.Error() {
+		case "usuário não é membro desta organização",
+			"escaneie o QR code exibido no local de trabalho para registrar o ponto",
+			"QR code inválido ou expirado, escaneie novamente",
+			"permita o acesso à localização para registrar o ponto",
+			"você está fora das áreas permitidas para registrar o ponto",
+			"registro de ponto permitido apenas a partir da rede da organização":
+			c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+			return
+		case domain.ErrPayPeriodClosed.Error():
+			c.JSON(http.StatusConflict, domain.HttpResponse{Status: http.StatusConflict, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	message := "Registro de ponto realizado com sucesso"
+	if result.Warning != "" {
+		message = result.Warning
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: message, Data: result})
+}
+
+// SyncOfflinePunches handles POST /api/v1/organizations/:id/clock-in/offline
+// Records the punches the device queued without connection. Each punch gets
+// its own result; when the request itself fails the device keeps the queue.
+func (h *TimesheetHandler) SyncOfflinePunches(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var sync domain.SyncOfflinePunches
+	if err := c.ShouldBindJSON(&sync); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	results, err := h.service.SyncOfflinePunches(c.Request.Context(), userID, orgID, sync)
+	if err != nil {
 		if err.Error() == "usuário não é membro desta organização" {
 			c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
 			return
@@ -70,7 +128,7 @@ func (h *TimesheetHandler) ClockIn(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Registro de ponto realizado com sucesso"})
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Registros offline sincronizados", Data: results})
 }
 
 // GetMyTimesheets handles GET /api/v1/organizations/:id/timesheets/me
@@ -125,8 +183,13 @@ func (h *TimesheetHandler) GetMyTimesheets(c *gin.Context) {
 			return
 		}
 
+		page, ok := pageRequest(c, domain.CursorDate, domain.CursorUUID)
+		if !ok {
+			return
+		}
+
 		// Get timesheets for date range
-		timesheets, err := h.service.GetUserTimesheets(c.Request.Context(), userID, userID, orgID, startDate, endDate)
+		timesheets, err := h.service.GetUserTimesheets(c.Request.Context(), userID, userID, orgID, startDate, endDate, page)
 		if err != nil {
 			if err.Error() == "usuário não é membro desta organização" {
 				c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
@@ -136,7 +199,7 @@ func (h *TimesheetHandler) GetMyTimesheets(c *gin.Context) {
 			return
 		}
 
-		c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Timesheets encontrados", Data: timesheets})
+		writePage(c, "Timesheets encontrados", page, *timesheets)
 		return
 	}
 
@@ -214,7 +277,7 @@ func (h *TimesheetHandler) GetMyStatus(c *gin.Context) {
 }
 
 // GetUserTimesheets handles GET /api/v1/organizations/:id/users/:userId/timesheets
-// Admin only - returns specific user's timesheets
+// Admins and team managers - returns specific user's timesheets
 func (h *TimesheetHandler) GetUserTimesheets(c *gin.Context) {
 	orgIDStr := c.Param("id")
 	orgID, err := uuid.Parse(orgIDStr)
@@ -277,10 +340,15 @@ func (h *TimesheetHandler) GetUserTimesheets(c *gin.Context) {
 		endDate = parsedEnd
 	}
 
+	page, ok := pageRequest(c, domain.CursorDate, domain.CursorUUID)
+	if !ok {
+		return
+	}
+
 	// Call service
-	timesheets, err := h.service.GetUserTimesheets(c.Request.Context(), requestingUserID, targetUserID, orgID, startDate, endDate)
+	timesheets, err := h.service.GetUserTimesheets(c.Request.Context(), requestingUserID, targetUserID, orgID, startDate, endDate, page)
 	if err != nil {
-		if err.Error() == "apenas administradores podem visualizar timesheets de outros usuários" {
+		if err.Error() == "apenas administradores e gestores podem visualizar timesheets de outros usuários" {
 			c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
 			return
 		}
@@ -288,11 +356,12 @@ func (h *TimesheetHandler) GetUserTimesheets(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Data: timesheets})
+	writePage(c, "", page, *timesheets)
 }
 
 // GetAllTimesheets handles GET /api/v1/organizations/:id/timesheets/all
-// Admin only - returns all organization timesheets for a date
+// ?period=today|week|month|custom&start=&end=&date=&member=&team=&status=&sort=date|name|status&order=asc|desc&limit=&cursor=
+// Admins and managers - returns a page of the organization timesheets
 func (h *TimesheetHandler) GetAllTimesheets(c *gin.Context) {
 	orgIDStr := c.Param("id")
 	_ = orgIDStr
@@ -327,21 +396,22 @@ func (h *TimesheetHandler) GetAllTimesheets(c *gin.Context) {
 		return
 	}
 
-	// Parse date (defaults to today)
-	date := time.Now().Truncate(24 * time.Hour)
-	if dateParam := c.Query("date"); dateParam != "" {
-		parsedDate, err := time.Parse("2006-01-02", dateParam)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "Formato de data inválido (use YYYY-MM-DD)"})
-			return
-		}
-		date = parsedDate
+	// Filters and sorting (defaults to today)
+	query, err := domain.ParseTimesheetQuery(c.Query, time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	page, ok := pageRequest(c, query.CursorKey(), domain.CursorUUID)
+	if !ok {
+		return
 	}
 
 	// Get all timesheets
-	timesheets, err := h.service.GetOrganizationTimesheets(c.Request.Context(), adminUserID, orgID, date)
+	timesheets, err := h.service.ListOrganizationTimesheets(c.Request.Context(), adminUserID, orgID, query, page)
 	if err != nil {
-		if err.Error() == "apenas administradores podem visualizar timesheets da organização" {
+		if err.Error() == "apenas administradores e gestores podem visualizar timesheets da organização" || err.Error() == "você não gerencia esta equipe" {
 			c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
 			return
 		}
@@ -349,7 +419,7 @@ func (h *TimesheetHandler) GetAllTimesheets(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Timesheets da organização", Data: timesheets})
+	writePage(c, "Timesheets da organização", page, *timesheets)
 }
 
 // GetTimesheetByID handles GET /api/v1/timesheets/:id
@@ -404,3 +474,75 @@ func (h *TimesheetHandler) GetTimesheetByID(c *gin.Context) {
 
 	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Data: timesheet})
 }
+
+// ApproveTimesheet handles POST /api/v1/timesheets/:id/approve
+// Admins and team managers - approves a daily timesheet
+func (h *TimesheetHandler) ApproveTimesheet(c *gin.Context) {
+	h.reviewTimesheet(c, true)
+}
+
+// ReproveTimesheet handles POST /api/v1/timesheets/:id/reprove
+// Admins and team managers - reproves a daily timesheet
+func (h *TimesheetHandler) ReproveTimesheet(c *gin.Context) {
+	h.reviewTimesheet(c, false)
+}
+
+func (h *TimesheetHandler) reviewTimesheet(c *gin.Context, approve bool) {
+	timesheetIDStr := c.Param("id")
+	timesheetID, err := uuid.Parse(timesheetIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID do timesheet inválido"})
+		return
+	}
+
+	// Get user ID from JWT token
+	tokenString, err := c.Cookie("token")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, domain.HttpResponse{Status: http.StatusUnauthorized, Message: "Token não encontrado"})
+		return
+	}
+
+	claims, err := utils.GetTokenClaims(tokenString)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, domain.HttpResponse{Status: http.StatusUnauthorized, Message: "Token inválido"})
+		return
+	}
+
+	userIDStr, ok := claims["id"].(string)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, domain.HttpResponse{Status: http.StatusUnauthorized, Message: "Token inválido"})
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, domain.HttpResponse{Status: http.StatusUnauthorized, Message: "ID de usuário inválido no token"})
+		return
+	}
+
+	err = h.service.ReviewTimesheet(c.Request.Context(), userID, timesheetID, approve)
+	if err != nil {
+		if err.Error() == "você não tem permissão para aprovar este timesheet" || err.Error() == "você não pode aprovar o próprio timesheet" {
+			c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+			return
+		}
+		if err.Error() == "timesheet não encontrado" {
+			c.JSON(http.StatusNotFound, domain.HttpResponse{Status: http.StatusNotFound, Message: err.Error()})
+			return
+		}
+		if err.Error() == domain.ErrPayPeriodClosed.Error() {
+			c.JSON(http.StatusConflict, domain.HttpResponse{Status: http.StatusConflict, Message: err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	message := "Timesheet reprovado"
+	if approve {
+		message = "Timesheet aprovado"
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: message})
+}
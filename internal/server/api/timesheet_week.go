@@ -0,0 +1,280 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+)
+
+type TimesheetWeekHandler struct {
+	service *service.TimesheetWeekService
+}
+
+func NewTimesheetWeekHandler(ws *service.TimesheetWeekService) *TimesheetWeekHandler {
+	return &TimesheetWeekHandler{ws}
+}
+
+// GetSettings handles GET /api/v1/organizations/:id/week-settings
+// Members - returns whether the organization asks for weekly submissions
+func (h *TimesheetWeekHandler) GetSettings(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	settings, err := h.service.Settings(c.Request.Context(), userID, orgID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Configuração do envio semanal", Data: settings})
+}
+
+// UpdateSettings handles PUT /api/v1/organizations/:id/week-settings
+// Admin only - turns the weekly submissions on or off
+func (h *TimesheetWeekHandler) UpdateSettings(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var u domain.UpdateWeekSettings
+	if err := c.ShouldBind(&u); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	settings, err := h.service.UpdateSettings(c.Request.Context(), userID, orgID, u)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Envio semanal atualizado com sucesso", Data: settings})
+}
+
+// GetMyWeek handles GET /api/v1/organizations/:id/weeks/me?week=YYYY-MM-DD
+// Members - summarizes the week of the date, the current one by default
+func (h *TimesheetWeekHandler) GetMyWeek(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	weekStart := domain.WeekStart(time.Now())
+	if week := c.Query("week"); week != "" {
+		if weekStart, err = domain.ParseWeek(week); err != nil {
+			c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+			return
+		}
+	}
+
+	summary, err := h.service.Week(c.Request.Context(), userID, orgID, weekStart)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Resumo da semana", Data: summary})
+}
+
+// ListMyWeeks handles GET /api/v1/organizations/:id/weeks/me/history
+// Members - returns the latest submitted or reminded weeks and the past weeks
+// still waiting to be submitted
+func (h *TimesheetWeekHandler) ListMyWeeks(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	weeks, err := h.service.RecentWeeks(c.Request.Context(), userID, orgID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	pending, err := h.service.PendingWeeks(c.Request.Context(), userID, orgID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Semanas do usuário", Data: gin.H{"weeks": weeks, "pending": pending}})
+}
+
+// SubmitMyWeek handles POST /api/v1/organizations/:id/weeks/me/submit
+// Members - sends the week for review
+func (h *TimesheetWeekHandler) SubmitMyWeek(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var sw domain.SubmitWeek
+	if err := c.ShouldBind(&sw); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	week, err := h.service.SubmitWeek(c.Request.Context(), userID, orgID, sw)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Semana enviada para aprovação", Data: week})
+}
+
+// ReviewQueue handles GET /api/v1/organizations/:id/weeks/review
+// Admins and managers - returns the submitted weeks waiting for review
+func (h *TimesheetWeekHandler) ReviewQueue(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	queue, err := h.service.ReviewQueue(c.Request.Context(), userID, orgID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Semanas aguardando aprovação", Data: queue})
+}
+
+// GetWeek handles GET /api/v1/organizations/:id/weeks/:weekId
+// The member of the week and its reviewers
+func (h *TimesheetWeekHandler) GetWeek(c *gin.Context) {
+	orgID, weekID, userID, ok := weekParams(c)
+	if !ok {
+		return
+	}
+
+	summary, err := h.service.WeekByID(c.Request.Context(), userID, orgID, weekID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Resumo da semana", Data: summary})
+}
+
+// ApproveWeek handles POST /api/v1/organizations/:id/weeks/:weekId/approve
+// Admins and managers of the member - approves the week and its days
+func (h *TimesheetWeekHandler) ApproveWeek(c *gin.Context) {
+	h.review(c, true)
+}
+
+// RejectWeek handles POST /api/v1/organizations/:id/weeks/:weekId/reject
+// Admins and managers of the member - sends the week back with a note
+func (h *TimesheetWeekHandler) RejectWeek(c *gin.Context) {
+	h.review(c, false)
+}
+
+func (h *TimesheetWeekHandler) review(c *gin.Context, approve bool) {
+	orgID, weekID, userID, ok := weekParams(c)
+	if !ok {
+		return
+	}
+
+	var rw domain.ReviewWeek
+	if err := c.ShouldBind(&rw); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	review := h.service.RejectWeek
+	message := "Semana rejeitada"
+	if approve {
+		review = h.service.ApproveWeek
+		message = "Semana aprovada com sucesso"
+	}
+
+	week, err := review(c.Request.Context(), userID, orgID, weekID, rw)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: message, Data: week})
+}
+
+// weekParams parses the organization and week IDs of the path and the
+// requesting user, writing the error response when one is invalid
+func weekParams(c *gin.Context) (orgID, weekID, userID uuid.UUID, ok bool) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	weekID, err = uuid.Parse(c.Param("weekId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da semana inválido"})
+		return
+	}
+
+	userID, ok = requestUserID(c)
+	return
+}
+
+func (h *TimesheetWeekHandler) writeError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "apenas administradores podem configurar o envio semanal",
+		"apenas administradores e gestores podem visualizar timesheets da organização",
+		"você não pode revisar a própria semana",
+		"você não tem permissão para revisar esta semana":
+		c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+	case "semana não encontrada", "usuário não é membro desta organização":
+		c.JSON(http.StatusNotFound, domain.HttpResponse{Status: http.StatusNotFound, Message: err.Error()})
+	case "esta semana já foi enviada", "apenas semanas enviadas podem ser revisadas", "a organização não utiliza o envio semanal",
+		domain.ErrPayPeriodClosed.Error():
+		c.JSON(http.StatusConflict, domain.HttpResponse{Status: http.StatusConflict, Message: err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+	}
+}
@@ -22,7 +22,12 @@ func NewUserHandler(us service.UserService) *UserHandler {
 }
 
 func (h UserHandler) List(c *gin.Context) {
-	res, err := h.service.List(c.Request.Context())
+	page, ok := pageRequest(c, domain.CursorText, domain.CursorUUID)
+	if !ok {
+		return
+	}
+
+	res, err := h.service.List(c.Request.Context(), page)
 
 	if err != nil {
 		c.JSON(http.StatusBadRequest, err)
@@ -32,7 +37,7 @@ func (h UserHandler) List(c *gin.Context) {
 		c.JSON(http.StatusNotFound, domain.HttpResponse{Status: http.StatusNotFound, Message: "O banco ainda não possui usuários"})
 		return
 	}
-	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Todos os usuarios do banco", Data: res})
+	writePage(c, "Todos os usuarios do banco", page, *res)
 }
 
 func (h UserHandler) GetByID(c *gin.Context) {
@@ -181,6 +186,7 @@ func (h UserHandler) UpdateMyProfile(c *gin.Context) {
 	var req struct {
 		Name  string `json:"name" form:"name" binding:"required"`
 		Email string `json:"email" form:"email" binding:"required,email"`
+		CPF   string `json:"cpf" form:"cpf"`
 	}
 
 
@@ -195,7 +201,7 @@ func (h UserHandler) UpdateMyProfile(c *gin.Context) {
 		return
 	}
 
-	updatedUser, err := h.service.UpdateProfile(c.Request.Context(), userID, req.Name, req.Email)
+	updatedUser, err := h.service.UpdateProfile(c.Request.Context(), userID, req.Name, req.Email, req.CPF)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
 		return
@@ -0,0 +1,218 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+)
+
+type WebhookHandler struct {
+	service *service.WebhookService
+}
+
+func NewWebhookHandler(ws *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{ws}
+}
+
+// Create handles POST /api/v1/organizations/:id/webhooks
+// Admin only - subscribes a URL to events; the response carries the signing secret
+func (h *WebhookHandler) Create(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	var cw domain.CreateWebhook
+	if err := c.ShouldBind(&cw); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	webhook, err := h.service.Create(c.Request.Context(), userID, orgID, cw)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusCreated, domain.HttpResponse{Status: http.StatusCreated, Message: "Webhook criado com sucesso", Data: webhook})
+}
+
+// List handles GET /api/v1/organizations/:id/webhooks
+// Admin only
+func (h *WebhookHandler) List(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	page, ok := pageRequest(c, domain.CursorTimestamp, domain.CursorUUID)
+	if !ok {
+		return
+	}
+
+	webhooks, err := h.service.List(c.Request.Context(), userID, orgID, page)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	writePage(c, "Webhooks da organização", page, *webhooks)
+}
+
+// Update handles PUT /api/v1/organizations/:id/webhooks/:webhookId
+// Admin only - changes the URL, the events or enables/disables the webhook
+func (h *WebhookHandler) Update(c *gin.Context) {
+	orgID, webhookID, userID, ok := h.webhookParams(c)
+	if !ok {
+		return
+	}
+
+	var uw domain.UpdateWebhook
+	if err := c.ShouldBind(&uw); err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	webhook, err := h.service.Update(c.Request.Context(), userID, orgID, webhookID, uw)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Webhook atualizado com sucesso", Data: webhook})
+}
+
+// Delete handles DELETE /api/v1/organizations/:id/webhooks/:webhookId
+// Admin only
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	orgID, webhookID, userID, ok := h.webhookParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), userID, orgID, webhookID); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusOK, domain.HttpResponse{Status: http.StatusOK, Message: "Webhook removido com sucesso"})
+}
+
+// Deliveries handles GET /api/v1/organizations/:id/webhooks/:webhookId/deliveries
+// Admin only - delivery log of the webhook, newest first
+func (h *WebhookHandler) Deliveries(c *gin.Context) {
+	orgID, webhookID, userID, ok := h.webhookParams(c)
+	if !ok {
+		return
+	}
+
+	h.listDeliveries(c, userID, orgID, &webhookID)
+}
+
+// AllDeliveries handles GET /api/v1/organizations/:id/webhook-deliveries
+// Admin only - delivery log of every webhook of the organization, newest first
+func (h *WebhookHandler) AllDeliveries(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	h.listDeliveries(c, userID, orgID, nil)
+}
+
+// Redeliver handles POST /api/v1/organizations/:id/webhook-deliveries/:deliveryId/redeliver
+// Admin only - queues the delivery payload again as a new delivery
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return
+	}
+
+	deliveryID, err := uuid.Parse(c.Param("deliveryId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da entrega inválido"})
+		return
+	}
+
+	userID, ok := requestUserID(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.service.Redeliver(c.Request.Context(), userID, orgID, deliveryID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("HX-Refresh", "true")
+	c.JSON(http.StatusAccepted, domain.HttpResponse{Status: http.StatusAccepted, Message: "Entrega reenfileirada", Data: delivery})
+}
+
+func (h *WebhookHandler) listDeliveries(c *gin.Context, userID, orgID uuid.UUID, webhookID *uuid.UUID) {
+	page, ok := pageRequest(c, domain.CursorTimestamp, domain.CursorUUID)
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.service.Deliveries(c.Request.Context(), userID, orgID, webhookID, page)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	writePage(c, "Entregas de webhook", page, *deliveries)
+}
+
+func (h *WebhookHandler) webhookParams(c *gin.Context) (orgID, webhookID, userID uuid.UUID, ok bool) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID da organização inválido"})
+		return orgID, webhookID, userID, false
+	}
+
+	webhookID, err = uuid.Parse(c.Param("webhookId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: "ID do webhook inválido"})
+		return orgID, webhookID, userID, false
+	}
+
+	userID, ok = requestUserID(c)
+	return orgID, webhookID, userID, ok
+}
+
+func (h *WebhookHandler) writeError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "apenas administradores podem gerenciar webhooks":
+		c.JSON(http.StatusForbidden, domain.HttpResponse{Status: http.StatusForbidden, Message: err.Error()})
+	case "webhook não encontrado", "entrega de webhook não encontrada":
+		c.JSON(http.StatusNotFound, domain.HttpResponse{Status: http.StatusNotFound, Message: err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, domain.HttpResponse{Status: http.StatusBadRequest, Message: err.Error()})
+	}
+}
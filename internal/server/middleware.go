@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
 	"github.com/marcelorc13/timesheet-pro/internal/utils"
 )
 
@@ -26,3 +27,17 @@ func AuthMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// RequestMetadataMiddleware stores the client IP and user agent in the request
+// context so services can attach them to audit records
+func RequestMetadataMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := domain.WithRequestMetadata(c.Request.Context(), domain.RequestMetadata{
+			IP:        c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		})
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TrustProxies configures which reverse proxies may set the client IP through
+// X-Forwarded-For. proxies is a comma separated list of IPs or CIDR ranges;
+// when empty no proxy is trusted and the client IP is the peer address.
+// platform, when set, names a header the hosting platform sets with the client
+// IP (gin.PlatformCloudflare, gin.PlatformGoogleAppEngine or any header name).
+//
+// The client IP feeds the audit log and the punch network policy, so it must
+// not be taken from headers sent by arbitrary clients.
+func TrustProxies(r *gin.Engine, proxies, platform string) error {
+	var trusted []string
+	for _, proxy := range strings.Split(proxies, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			trusted = append(trusted, proxy)
+		}
+	}
+
+	if err := r.SetTrustedProxies(trusted); err != nil {
+		return err
+	}
+
+	r.TrustedPlatform = strings.TrimSpace(platform)
+	return nil
+}
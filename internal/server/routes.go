@@ -10,7 +10,7 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func (r Router) APIRoutes(uh api.UserHandler, oh api.OrganizationHandler, th api.TimesheetHandler) {
+func (r Router) APIRoutes(uh api.UserHandler, oh api.OrganizationHandler, th api.TimesheetHandler, tmh api.TeamHandler, ah api.AuditHandler, pch api.PunchChainHandler, rh api.ReceiptHandler, eh api.ExportHandler, sh api.ScheduleHandler, esh api.EspelhoHandler, ph api.PayrollHandler, prh api.PresenceHandler, wh api.WebhookHandler, kh api.KioskHandler, pph api.PunchPolicyHandler, ch api.ComplianceHandler, prjh api.ProjectHandler, bh api.BillingHandler, twh api.TimesheetWeekHandler) {
 	docs.SwaggerInfo.BasePath = "/api/v1"
 
 	apiRouter := r.Router.Group("/api/v1")
@@ -34,29 +34,143 @@ func (r Router) APIRoutes(uh api.UserHandler, oh api.OrganizationHandler, th api
 	organizationRoutes.GET("/user/:userId", oh.GetByUserID)
 	organizationRoutes.PUT("/:id", oh.Update)
 	organizationRoutes.DELETE("/:id", oh.Delete)
+	organizationRoutes.GET("/:id/users", oh.ListMembers)
 	organizationRoutes.POST("/:id/users", oh.AddUser)
 	organizationRoutes.DELETE("/:id/users/:userId", oh.RemoveUser)
 	organizationRoutes.POST("/:id/leave", oh.Leave)
 
 	organizationRoutes.POST("/:id/clock-in", th.ClockIn)
+	organizationRoutes.POST("/:id/clock-in/offline", th.SyncOfflinePunches)
 	organizationRoutes.GET("/:id/timesheets/me", th.GetMyTimesheets)
 	organizationRoutes.GET("/:id/timesheets/me/status", th.GetMyStatus)
 	organizationRoutes.GET("/:id/users/:userId/timesheets", th.GetUserTimesheets)
 	organizationRoutes.GET("/:id/timesheets/all", th.GetAllTimesheets)
+	organizationRoutes.GET("/:id/receipts/me", rh.ListMine)
+	organizationRoutes.GET("/:id/presence", prh.List)
+	organizationRoutes.GET("/:id/presence/stream", prh.Stream)
+
+	organizationRoutes.POST("/:id/teams", tmh.Create)
+	organizationRoutes.GET("/:id/teams", tmh.List)
+	organizationRoutes.DELETE("/:id/teams/:teamId", tmh.Delete)
+	organizationRoutes.POST("/:id/teams/:teamId/members", tmh.AddMember)
+	organizationRoutes.DELETE("/:id/teams/:teamId/members/:userId", tmh.RemoveMember)
+
+	organizationRoutes.GET("/:id/audit", ah.List)
+
+	organizationRoutes.GET("/:id/chain/verify", pch.Verify)
+	organizationRoutes.GET("/:id/chain/export", pch.Export)
+	organizationRoutes.GET("/:id/chain/public-key", pch.PublicKey)
+
+	organizationRoutes.GET("/:id/exports/afd", eh.AFD)
+	organizationRoutes.GET("/:id/exports/aej", eh.AEJ)
+	organizationRoutes.GET("/:id/exports/csv", eh.CSV)
+	organizationRoutes.GET("/:id/exports/xlsx", eh.XLSX)
+	organizationRoutes.GET("/:id/exports/payroll", ph.Export)
+
+	organizationRoutes.GET("/:id/payroll/settings", ph.GetSettings)
+	organizationRoutes.PUT("/:id/payroll/settings", ph.UpdateSettings)
+	organizationRoutes.GET("/:id/pay-periods", ph.ListPayPeriods)
+	organizationRoutes.GET("/:id/pay-periods/:month", ph.GetPayPeriod)
+	organizationRoutes.POST("/:id/pay-periods/:month/close", ph.ClosePayPeriod)
+	organizationRoutes.POST("/:id/pay-periods/:month/reopen", ph.ReopenPayPeriod)
+
+	organizationRoutes.POST("/:id/schedules", sh.Create)
+	organizationRoutes.GET("/:id/schedules", sh.List)
+	organizationRoutes.DELETE("/:id/schedules/:scheduleId", sh.Delete)
+	organizationRoutes.PUT("/:id/users/:userId/schedule", sh.AssignToMember)
+
+	organizationRoutes.GET("/:id/espelho/:month/pdf", esh.GetMyPDF)
+	organizationRoutes.GET("/:id/users/:userId/espelho/:month/pdf", esh.GetUserPDF)
+	organizationRoutes.POST("/:id/espelho/:month/sign", esh.Sign)
+	organizationRoutes.GET("/:id/espelho/:month/signatures", esh.Signatures)
+
+	organizationRoutes.POST("/:id/webhooks", wh.Create)
+	organizationRoutes.GET("/:id/webhooks", wh.List)
+	organizationRoutes.PUT("/:id/webhooks/:webhookId", wh.Update)
+	organizationRoutes.DELETE("/:id/webhooks/:webhookId", wh.Delete)
+	organizationRoutes.GET("/:id/webhooks/:webhookId/deliveries", wh.Deliveries)
+	organizationRoutes.GET("/:id/webhook-deliveries", wh.AllDeliveries)
+	organizationRoutes.POST("/:id/webhook-deliveries/:deliveryId/redeliver", wh.Redeliver)
+
+	organizationRoutes.POST("/:id/kiosk/devices", kh.RegisterDevice)
+	organizationRoutes.GET("/:id/kiosk/devices", kh.ListDevices)
+	organizationRoutes.POST("/:id/kiosk/devices/:deviceId/revoke", kh.RevokeDevice)
+	organizationRoutes.PUT("/:id/users/:userId/kiosk-credentials", kh.SetMemberCredentials)
+
+	organizationRoutes.GET("/:id/punch-policy", pph.GetPolicy)
+	organizationRoutes.PUT("/:id/punch-policy", pph.UpdatePolicy)
+	organizationRoutes.GET("/:id/punch-policy/qr", pph.CurrentQR)
+	organizationRoutes.POST("/:id/geofences", pph.CreateGeofence)
+	organizationRoutes.POST("/:id/geofences/from-address", pph.CreateGeofenceFromAddress)
+	organizationRoutes.DELETE("/:id/geofences/:geofenceId", pph.DeleteGeofence)
+	organizationRoutes.GET("/:id/out-of-fence-punches", pph.OutOfFencePunches)
+	organizationRoutes.POST("/:id/punch-networks", pph.CreateNetwork)
+	organizationRoutes.DELETE("/:id/punch-networks/:networkId", pph.DeleteNetwork)
+	organizationRoutes.GET("/:id/flagged-punches", pph.FlaggedPunches)
+
+	organizationRoutes.GET("/:id/compliance", ch.Report)
+
+	organizationRoutes.POST("/:id/projects", prjh.Create)
+	organizationRoutes.GET("/:id/projects", prjh.List)
+	organizationRoutes.GET("/:id/projects/report", prjh.Report)
+	organizationRoutes.PUT("/:id/projects/:projectId", prjh.Update)
+	organizationRoutes.POST("/:id/projects/:projectId/tasks", prjh.CreateTask)
+	organizationRoutes.DELETE("/:id/projects/:projectId/tasks/:taskId", prjh.DeleteTask)
+	organizationRoutes.POST("/:id/time-allocations", prjh.CreateAllocation)
+	organizationRoutes.GET("/:id/time-allocations/me", prjh.ListMyAllocations)
+	organizationRoutes.DELETE("/:id/time-allocations/:allocationId", prjh.DeleteAllocation)
+
+	organizationRoutes.POST("/:id/clients", bh.CreateClient)
+	organizationRoutes.GET("/:id/clients", bh.ListClients)
+	organizationRoutes.PUT("/:id/clients/:clientId", bh.UpdateClient)
+	organizationRoutes.GET("/:id/billing-rates", bh.ListMemberRates)
+	organizationRoutes.PUT("/:id/billing-rates/:userId", bh.SetMemberRate)
+	organizationRoutes.POST("/:id/invoices", bh.CreateInvoice)
+	organizationRoutes.GET("/:id/invoices", bh.ListInvoices)
+	organizationRoutes.GET("/:id/invoices/:invoiceId", bh.GetInvoice)
+	organizationRoutes.POST("/:id/invoices/:invoiceId/issue", bh.IssueInvoice)
+	organizationRoutes.DELETE("/:id/invoices/:invoiceId", bh.DeleteInvoice)
+	organizationRoutes.GET("/:id/invoices/:invoiceId/pdf", bh.GetInvoicePDF)
+	organizationRoutes.GET("/:id/invoices/:invoiceId/csv", bh.GetInvoiceCSV)
+
+	organizationRoutes.GET("/:id/week-settings", twh.GetSettings)
+	organizationRoutes.PUT("/:id/week-settings", twh.UpdateSettings)
+	organizationRoutes.GET("/:id/weeks/me", twh.GetMyWeek)
+	organizationRoutes.GET("/:id/weeks/me/history", twh.ListMyWeeks)
+	organizationRoutes.POST("/:id/weeks/me/submit", twh.SubmitMyWeek)
+	organizationRoutes.GET("/:id/weeks/review", twh.ReviewQueue)
+	organizationRoutes.GET("/:id/weeks/:weekId", twh.GetWeek)
+	organizationRoutes.POST("/:id/weeks/:weekId/approve", twh.ApproveWeek)
+	organizationRoutes.POST("/:id/weeks/:weekId/reject", twh.RejectWeek)
+
+	// Kiosk device routes, authenticated by the device token instead of a user session
+	kioskRoutes := apiRouter.Group("kiosk/")
+	kioskRoutes.POST("/activate", kh.Activate)
+	kioskRoutes.POST("/deactivate", kh.Deactivate)
+	kioskRoutes.POST("/punch", kh.Punch)
 
 	// Timesheet by ID route (not scoped to organization)
 	apiRouter.GET("/timesheets/:id", th.GetTimesheetByID)
+	apiRouter.POST("/timesheets/:id/approve", th.ApproveTimesheet)
+	apiRouter.POST("/timesheets/:id/reprove", th.ReproveTimesheet)
+
+	apiRouter.GET("/receipts/:id", rh.GetByID)
+	apiRouter.GET("/receipts/:id/pdf", rh.GetPDF)
+	apiRouter.GET("/timesheet-entries/:id/receipt/pdf", rh.GetEntryPDF)
 
 	r.Router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 	// http://localhost:port/swagger/index.html
 }
 
-func (r Router) ViewsRoutes(ovh views.OrganizationViewHandler, tvh views.TimesheetViewHandler, pvh views.ProfileViewHandler, orgRepo *repository.OrganizationRepository) {
+func (r Router) ViewsRoutes(ovh views.OrganizationViewHandler, tvh views.TimesheetViewHandler, pvh views.ProfileViewHandler, tmvh views.TeamViewHandler, avh views.AuditViewHandler, evh views.ExportViewHandler, esvh views.EspelhoViewHandler, prvh views.PresenceViewHandler, whvh views.WebhookViewHandler, kvh views.KioskViewHandler, ppvh views.PunchPolicyViewHandler, cvh views.ComplianceViewHandler, prjvh views.ProjectViewHandler, bvh views.BillingViewHandler, twvh views.TimesheetWeekViewHandler, orgRepo *repository.OrganizationRepository) {
 	viewsRouter := r.Router.Group("/")
 
 	viewsRouter.GET("/signup", views.SignupHandler)
 	viewsRouter.GET("/login", views.LoginHandler)
 	viewsRouter.GET("/logout", views.LogoutHandler)
+	viewsRouter.GET("/kiosk", kvh.KioskPageHandler)
+	viewsRouter.GET("/offline-punches.js", views.OfflinePunchesScriptHandler)
+	viewsRouter.GET("/timesheet-sw.js", views.TimesheetServiceWorkerHandler)
 
 	authRoutes := viewsRouter.Group("/")
 	authRoutes.Use(AuthMiddleware())
@@ -68,8 +182,24 @@ func (r Router) ViewsRoutes(ovh views.OrganizationViewHandler, tvh views.Timeshe
 	authRoutes.GET("/organizations/:id", ovh.OrganizationDetailHandler)
 	authRoutes.GET("/organizations/:id/edit", ovh.OrganizationEditHandler)
 	authRoutes.GET("/organizations/:id/add-user", ovh.OrganizationAddUserHandler)
+	authRoutes.GET("/organizations/:id/teams", tmvh.TeamsPageHandler)
+	authRoutes.GET("/organizations/:id/audit", avh.AuditPageHandler)
+	authRoutes.GET("/organizations/:id/exports", evh.ExportsPageHandler)
+	authRoutes.GET("/organizations/:id/pay-periods", evh.PayPeriodsPageHandler)
+	authRoutes.GET("/organizations/:id/espelhos", esvh.SignaturesPageHandler)
+	authRoutes.GET("/organizations/:id/presence", prvh.PresencePageHandler)
+	authRoutes.GET("/organizations/:id/webhooks", whvh.WebhooksPageHandler)
+	authRoutes.GET("/organizations/:id/kiosk", kvh.KioskAdminPageHandler)
+	authRoutes.GET("/organizations/:id/punch-policy", ppvh.PunchPolicyPageHandler)
+	authRoutes.GET("/organizations/:id/punch-policy/qr", ppvh.PunchQRDisplayHandler)
+	authRoutes.GET("/organizations/:id/compliance", cvh.CompliancePageHandler)
+	authRoutes.GET("/organizations/:id/projects", prjvh.ProjectsPageHandler)
+	authRoutes.GET("/organizations/:id/billing", bvh.BillingPageHandler)
+	authRoutes.GET("/organizations/:id/weeks", twvh.ReviewPageHandler)
 	
 	authRoutes.GET("/timesheet", tvh.TimesheetPageHandler)
+	authRoutes.GET("/timesheet/espelho", esvh.EspelhoPageHandler)
+	authRoutes.GET("/timesheet/week", twvh.WeekPageHandler)
 	authRoutes.GET("/admin/timesheets", tvh.AdminTimesheetPageHandler)
 
 	authRoutes.GET("/profile", pvh.ProfilePageHandler)
@@ -13,6 +13,7 @@ type Router struct {
 }
 
 func NewRouter(r *gin.Engine) *Router {
+	r.Use(RequestMetadataMiddleware())
 	return &Router{Router: r}
 }
 
@@ -0,0 +1,29 @@
+package views
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed assets/offline_punches.js
+var offlinePunchesScript []byte
+
+//go:embed assets/timesheet_sw.js
+var timesheetServiceWorker []byte
+
+// OfflinePunchesScriptHandler serves the offline punch queue used by the
+// timesheet page and its service worker
+func OfflinePunchesScriptHandler(c *gin.Context) {
+	c.Header("Cache-Control", "no-cache")
+	c.Data(http.StatusOK, "application/javascript; charset=utf-8", offlinePunchesScript)
+}
+
+// TimesheetServiceWorkerHandler serves the service worker of the timesheet
+// page. It is public because browsers do not follow the login redirect when
+// updating a service worker.
+func TimesheetServiceWorkerHandler(c *gin.Context) {
+	c.Header("Cache-Control", "no-cache")
+	c.Data(http.StatusOK, "application/javascript; charset=utf-8", timesheetServiceWorker)
+}
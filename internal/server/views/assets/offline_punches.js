@@ -0,0 +1,105 @@
+// Offline punch queue shared by the timesheet page and its service worker.
+// Punches made without connection are kept in IndexedDB with the device time
+// and sent in batches once the connection is back.
+const OfflinePunches = (() => {
+	const DB_NAME = 'timesheet-offline';
+	const STORE = 'punches';
+	const BATCH_SIZE = 50;
+
+	function open() {
+		return new Promise((resolve, reject) => {
+			const req = indexedDB.open(DB_NAME, 1);
+			req.onupgradeneeded = () => req.result.createObjectStore(STORE, { keyPath: 'client_id' });
+			req.onsuccess = () => resolve(req.result);
+			req.onerror = () => reject(req.error);
+		});
+	}
+
+	async function request(mode, fn) {
+		const db = await open();
+		return new Promise((resolve, reject) => {
+			const req = fn(db.transaction(STORE, mode).objectStore(STORE));
+			req.onsuccess = () => resolve(req.result);
+			req.onerror = () => reject(req.error);
+		});
+	}
+
+	// All queued punches, oldest first
+	async function all() {
+		const punches = await request('readonly', (store) => store.getAll());
+		return punches.sort((a, b) => a.captured_at.localeCompare(b.captured_at));
+	}
+
+	async function pending() {
+		return (await all()).filter((p) => p.status === 'pending');
+	}
+
+	function add(punch) {
+		return request('readwrite', (store) => store.put({ ...punch, status: 'pending' }));
+	}
+
+	async function discardRejected() {
+		for (const punch of await all()) {
+			if (punch.status === 'rejected') {
+				await request('readwrite', (store) => store.delete(punch.client_id));
+			}
+		}
+	}
+
+	// Sends the pending punches of each organization. Recorded and duplicate
+	// punches leave the queue; rejected ones stay with the reason and are not
+	// sent again. Throws when the server cannot be reached, keeping the queue.
+	async function sync() {
+		const summary = { recorded: 0, rejected: 0, warnings: [] };
+		const byOrganization = new Map();
+		for (const punch of await pending()) {
+			const punches = byOrganization.get(punch.organization_id) || [];
+			punches.push(punch);
+			byOrganization.set(punch.organization_id, punches);
+		}
+
+		for (const [organizationID, punches] of byOrganization) {
+			for (let i = 0; i < punches.length; i += BATCH_SIZE) {
+				const batch = punches.slice(i, i + BATCH_SIZE);
+				const response = await fetch('/api/v1/organizations/' + organizationID + '/clock-in/offline', {
+					method: 'POST',
+					credentials: 'same-origin',
+					headers: { 'Content-Type': 'application/json' },
+					body: JSON.stringify({
+						sent_at: new Date().toISOString(),
+						punches: batch.map(({ client_id, captured_at, qr_code, latitude, longitude, accuracy, break: isBreak }) => ({ client_id, captured_at, break: !!isBreak, qr_code, latitude, longitude, accuracy })),
+					}),
+				});
+				if (!response.ok) {
+					throw new Error('sincronização recusada: ' + response.status);
+				}
+
+				const results = (await response.json()).data || [];
+				for (const result of results) {
+					const punch = batch.find((p) => p.client_id === result.client_id);
+					if (!punch) {
+						continue;
+					}
+					if (result.status === 'rejected') {
+						summary.rejected++;
+						await request('readwrite', (store) => store.put({ ...punch, status: 'rejected', message: result.message }));
+						continue;
+					}
+					if (result.status === 'recorded') {
+						summary.recorded++;
+						if (result.message) {
+							summary.warnings.push(result.message);
+						}
+					}
+					await request('readwrite', (store) => store.delete(punch.client_id));
+				}
+			}
+		}
+
+		return summary;
+	}
+
+	return { SYNC_TAG: 'offline-punches', all, pending, add, discardRejected, sync };
+})();
+
+self.OfflinePunches = OfflinePunches;
@@ -0,0 +1,60 @@
+// Service worker of the timesheet page. It keeps a copy of the page, the queue
+// script and the CDN assets they use so the page opens without connection,
+// and sends the offline punch queue when the browser reports the connection
+// is back.
+importScripts('/offline-punches.js');
+
+const CACHE = 'timesheet-v1';
+// Same origin paths kept for offline use; the page is cached without its query string
+const PATHS = ['/timesheet', '/offline-punches.js'];
+
+self.addEventListener('install', () => self.skipWaiting());
+
+self.addEventListener('activate', (event) => {
+	event.waitUntil(
+		caches.keys()
+			.then((keys) => Promise.all(keys.filter((key) => key !== CACHE).map((key) => caches.delete(key))))
+			.then(() => self.clients.claim()),
+	);
+});
+
+// Network first, so the page and assets are always fresh when online
+self.addEventListener('fetch', (event) => {
+	const request = event.request;
+	if (request.method !== 'GET') {
+		return;
+	}
+
+	const url = new URL(request.url);
+	const isLocal = url.origin === self.location.origin;
+	if (isLocal && !PATHS.includes(url.pathname)) {
+		return;
+	}
+
+	const key = isLocal ? url.pathname : request;
+	event.respondWith(
+		fetch(request)
+			.then((response) => {
+				// Redirects to the login page are never cached
+				if ((response.ok && !response.redirected) || response.type === 'opaque') {
+					const copy = response.clone();
+					caches.open(CACHE).then((cache) => cache.put(key, copy));
+				}
+				return response;
+			})
+			.catch(() => caches.match(key).then((cached) => cached || Response.error())),
+	);
+});
+
+self.addEventListener('sync', (event) => {
+	if (event.tag === OfflinePunches.SYNC_TAG) {
+		event.waitUntil(OfflinePunches.sync().then(notify));
+	}
+});
+
+async function notify(summary) {
+	const clients = await self.clients.matchAll({ type: 'window' });
+	for (const client of clients) {
+		client.postMessage({ type: 'offline-punches-synced', summary });
+	}
+}
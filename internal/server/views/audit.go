@@ -0,0 +1,104 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+	"github.com/marcelorc13/timesheet-pro/internal/templates/pages"
+	"github.com/marcelorc13/timesheet-pro/internal/utils"
+)
+
+type AuditViewHandler struct {
+	auditServ *service.AuditService
+	orgServ   *service.OrganizationService
+}
+
+func NewAuditViewHandler(auditServ *service.AuditService, orgServ *service.OrganizationService) *AuditViewHandler {
+	return &AuditViewHandler{
+		auditServ: auditServ,
+		orgServ:   orgServ,
+	}
+}
+
+// AuditPageHandler shows the organization audit log with actor, action and date filters
+func (h *AuditViewHandler) AuditPageHandler(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.String(http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	// Get user ID from JWT token
+	tokenString, err := c.Cookie("token")
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	claims, err := utils.GetTokenClaims(tokenString)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userIDStr, ok := claims["id"].(string)
+	if !ok {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userName, ok := claims["name"].(string)
+	if !ok {
+		userName = ""
+	}
+
+	isAdmin, err := h.orgServ.IsUserAdmin(c.Request.Context(), userID, orgID)
+	if err != nil || !isAdmin {
+		c.String(http.StatusForbidden, "Apenas administradores podem visualizar a auditoria")
+		return
+	}
+
+	org, err := h.orgServ.GetByID(c.Request.Context(), orgID)
+	if err != nil || org == nil {
+		c.String(http.StatusNotFound, "Organização não encontrada")
+		return
+	}
+
+	members, err := h.orgServ.GetMembers(c.Request.Context(), orgID)
+	if err != nil || members == nil {
+		empty := []domain.OrganizationUser{}
+		members = &empty
+	}
+
+	query := pages.AuditQuery{
+		Actor:  c.Query("actor"),
+		Action: c.Query("action"),
+		Start:  c.Query("start"),
+		End:    c.Query("end"),
+	}
+
+	logs := []domain.AuditLog{}
+	filter, err := domain.ParseAuditFilter(query.Actor, query.Action, query.Start, query.End)
+	if err != nil {
+		query.Error = err.Error()
+	} else {
+		// The page shows the latest entries matching the filter
+		page, err := h.auditServ.List(c.Request.Context(), userID, orgID, filter, domain.PageRequest{Limit: domain.MaxPageLimit})
+		if err != nil {
+			query.Error = err.Error()
+		} else {
+			logs = page.Items
+		}
+	}
+
+	utils.Render(c.Request.Context(), c.Writer, pages.OrganizationAuditPage(*org, logs, *members, query, userName))
+}
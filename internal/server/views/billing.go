@@ -0,0 +1,89 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+	"github.com/marcelorc13/timesheet-pro/internal/templates/pages"
+	"github.com/marcelorc13/timesheet-pro/internal/utils"
+)
+
+type BillingViewHandler struct {
+	billingServ *service.BillingService
+	orgServ     *service.OrganizationService
+}
+
+func NewBillingViewHandler(billingServ *service.BillingService, orgServ *service.OrganizationService) *BillingViewHandler {
+	return &BillingViewHandler{
+		billingServ: billingServ,
+		orgServ:     orgServ,
+	}
+}
+
+// BillingPageHandler shows the organization clients, the member hourly rates
+// and the invoices generated from the project hours
+func (h *BillingViewHandler) BillingPageHandler(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.String(http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	// Get user ID from JWT token
+	tokenString, err := c.Cookie("token")
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	claims, err := utils.GetTokenClaims(tokenString)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userIDStr, ok := claims["id"].(string)
+	if !ok {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userName, ok := claims["name"].(string)
+	if !ok {
+		userName = ""
+	}
+
+	clients, err := h.billingServ.Clients(c.Request.Context(), userID, orgID)
+	if err != nil {
+		c.String(http.StatusForbidden, err.Error())
+		return
+	}
+
+	rates, err := h.billingServ.MemberRates(c.Request.Context(), userID, orgID)
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	invoices, err := h.billingServ.Invoices(c.Request.Context(), userID, orgID)
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	org, err := h.orgServ.GetByID(c.Request.Context(), orgID)
+	if err != nil || org == nil {
+		c.String(http.StatusNotFound, "Organização não encontrada")
+		return
+	}
+
+	utils.Render(c.Request.Context(), c.Writer, pages.OrganizationBillingPage(*org, clients, rates, invoices, userName))
+}
@@ -0,0 +1,89 @@
+package views
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+	"github.com/marcelorc13/timesheet-pro/internal/templates/pages"
+	"github.com/marcelorc13/timesheet-pro/internal/utils"
+)
+
+type ComplianceViewHandler struct {
+	complianceServ *service.ComplianceService
+	orgServ        *service.OrganizationService
+}
+
+func NewComplianceViewHandler(complianceServ *service.ComplianceService, orgServ *service.OrganizationService) *ComplianceViewHandler {
+	return &ComplianceViewHandler{
+		complianceServ: complianceServ,
+		orgServ:        orgServ,
+	}
+}
+
+// CompliancePageHandler shows the labor-law violations of the members in the
+// month, the current one by default so violations are noticed as they happen
+func (h *ComplianceViewHandler) CompliancePageHandler(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.String(http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	// Get user ID from JWT token
+	tokenString, err := c.Cookie("token")
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	claims, err := utils.GetTokenClaims(tokenString)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userIDStr, ok := claims["id"].(string)
+	if !ok {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userName, ok := claims["name"].(string)
+	if !ok {
+		userName = ""
+	}
+
+	now := time.Now()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if m := c.Query("month"); m != "" {
+		month, err = domain.ParseMonth(m)
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	report, err := h.complianceServ.Report(c.Request.Context(), userID, orgID, month)
+	if err != nil {
+		c.String(http.StatusForbidden, err.Error())
+		return
+	}
+
+	org, err := h.orgServ.GetByID(c.Request.Context(), orgID)
+	if err != nil || org == nil {
+		c.String(http.StatusNotFound, "Organização não encontrada")
+		return
+	}
+
+	utils.Render(c.Request.Context(), c.Writer, pages.OrganizationCompliancePage(*org, *report, userName))
+}
@@ -0,0 +1,160 @@
+package views
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+	"github.com/marcelorc13/timesheet-pro/internal/templates/pages"
+	"github.com/marcelorc13/timesheet-pro/internal/utils"
+)
+
+type EspelhoViewHandler struct {
+	espelhoServ *service.EspelhoService
+	orgServ     *service.OrganizationService
+}
+
+func NewEspelhoViewHandler(espelhoServ *service.EspelhoService, orgServ *service.OrganizationService) *EspelhoViewHandler {
+	return &EspelhoViewHandler{
+		espelhoServ: espelhoServ,
+		orgServ:     orgServ,
+	}
+}
+
+// EspelhoPageHandler shows the user's monthly espelho de ponto and its signature
+func (h *EspelhoViewHandler) EspelhoPageHandler(c *gin.Context) {
+	// Get user ID from JWT token
+	tokenString, err := c.Cookie("token")
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	claims, err := utils.GetTokenClaims(tokenString)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userIDStr, ok := claims["id"].(string)
+	if !ok {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userName, ok := claims["name"].(string)
+	if !ok {
+		userName = ""
+	}
+
+	month, ok := selectedMonth(c)
+	if !ok {
+		return
+	}
+
+	org, err := h.orgServ.GetOrganizationByUserID(c.Request.Context(), userID)
+	if err != nil || org == nil {
+		c.String(http.StatusNotFound, "Você não pertence a nenhuma organização")
+		return
+	}
+
+	doc, err := h.espelhoServ.Document(c.Request.Context(), userID, userID, org.ID, month)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao gerar o espelho de ponto")
+		return
+	}
+
+	signature, err := h.espelhoServ.Signature(c.Request.Context(), userID, org.ID, month)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao buscar a assinatura")
+		return
+	}
+
+	now := time.Now()
+	canSign := month.Before(time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC))
+
+	utils.Render(c.Request.Context(), c.Writer, pages.EspelhoPage(*org, month, doc, signature, canSign, userName))
+}
+
+// SignaturesPageHandler shows which members signed the espelho of the month
+func (h *EspelhoViewHandler) SignaturesPageHandler(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.String(http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	// Get user ID from JWT token
+	tokenString, err := c.Cookie("token")
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	claims, err := utils.GetTokenClaims(tokenString)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userIDStr, ok := claims["id"].(string)
+	if !ok {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userName, ok := claims["name"].(string)
+	if !ok {
+		userName = ""
+	}
+
+	month, ok := selectedMonth(c)
+	if !ok {
+		return
+	}
+
+	statuses, err := h.espelhoServ.Signatures(c.Request.Context(), userID, orgID, month, domain.PageRequest{})
+	if err != nil {
+		c.String(http.StatusForbidden, err.Error())
+		return
+	}
+
+	org, err := h.orgServ.GetByID(c.Request.Context(), orgID)
+	if err != nil || org == nil {
+		c.String(http.StatusNotFound, "Organização não encontrada")
+		return
+	}
+
+	utils.Render(c.Request.Context(), c.Writer, pages.OrganizationEspelhosPage(*org, month, statuses.Items, userName))
+}
+
+// selectedMonth reads the month query parameter, defaulting to the previous
+// month, which is the one pending signature
+func selectedMonth(c *gin.Context) (time.Time, bool) {
+	if m := c.Query("month"); m != "" {
+		month, err := domain.ParseMonth(m)
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return time.Time{}, false
+		}
+		return month, true
+	}
+
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0), true
+}
@@ -0,0 +1,163 @@
+package views
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+	"github.com/marcelorc13/timesheet-pro/internal/templates/pages"
+	"github.com/marcelorc13/timesheet-pro/internal/utils"
+)
+
+type ExportViewHandler struct {
+	orgServ     *service.OrganizationService
+	payrollServ *service.PayrollService
+}
+
+func NewExportViewHandler(orgServ *service.OrganizationService, payrollServ *service.PayrollService) *ExportViewHandler {
+	return &ExportViewHandler{
+		orgServ:     orgServ,
+		payrollServ: payrollServ,
+	}
+}
+
+// ExportsPageHandler shows the organization export downloads
+func (h *ExportViewHandler) ExportsPageHandler(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.String(http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	// Get user ID from JWT token
+	tokenString, err := c.Cookie("token")
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	claims, err := utils.GetTokenClaims(tokenString)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userIDStr, ok := claims["id"].(string)
+	if !ok {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userName, ok := claims["name"].(string)
+	if !ok {
+		userName = ""
+	}
+
+	isAdmin, err := h.orgServ.IsUserAdmin(c.Request.Context(), userID, orgID)
+	if err != nil || !isAdmin {
+		c.String(http.StatusForbidden, "Apenas administradores podem exportar dados da organização")
+		return
+	}
+
+	org, err := h.orgServ.GetByID(c.Request.Context(), orgID)
+	if err != nil || org == nil {
+		c.String(http.StatusNotFound, "Organização não encontrada")
+		return
+	}
+
+	members, err := h.orgServ.GetMembers(c.Request.Context(), orgID)
+	if err != nil || members == nil {
+		empty := []domain.OrganizationUser{}
+		members = &empty
+	}
+
+	payroll, err := h.payrollServ.Settings(c.Request.Context(), userID, orgID)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao buscar configuração da folha de pagamento")
+		return
+	}
+
+	utils.Render(c.Request.Context(), c.Writer, pages.OrganizationExportsPage(*org, *members, *payroll, userName))
+}
+
+// PayPeriodsPageHandler shows the pay periods of the organization with the
+// selected month, the previous one by default, to close or reopen it
+func (h *ExportViewHandler) PayPeriodsPageHandler(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.String(http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	// Get user ID from JWT token
+	tokenString, err := c.Cookie("token")
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	claims, err := utils.GetTokenClaims(tokenString)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userIDStr, ok := claims["id"].(string)
+	if !ok {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userName, ok := claims["name"].(string)
+	if !ok {
+		userName = ""
+	}
+
+	now := time.Now()
+	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	month := currentMonth.AddDate(0, -1, 0)
+	if m := c.Query("month"); m != "" {
+		month, err = domain.ParseMonth(m)
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	periods, err := h.payrollServ.PayPeriods(c.Request.Context(), userID, orgID)
+	if err != nil {
+		c.String(http.StatusForbidden, err.Error())
+		return
+	}
+
+	period, err := h.payrollServ.PayPeriod(c.Request.Context(), userID, orgID, month)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao buscar o período de folha")
+		return
+	}
+
+	org, err := h.orgServ.GetByID(c.Request.Context(), orgID)
+	if err != nil || org == nil {
+		c.String(http.StatusNotFound, "Organização não encontrada")
+		return
+	}
+
+	canClose := month.Before(currentMonth) && (period == nil || !period.Closed)
+
+	utils.Render(c.Request.Context(), c.Writer, pages.OrganizationPayPeriodsPage(*org, month, period, periods, canClose, userName))
+}
@@ -0,0 +1,106 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+	"github.com/marcelorc13/timesheet-pro/internal/templates/pages"
+	"github.com/marcelorc13/timesheet-pro/internal/utils"
+)
+
+type KioskViewHandler struct {
+	kioskServ *service.KioskService
+	orgServ   *service.OrganizationService
+}
+
+func NewKioskViewHandler(kioskServ *service.KioskService, orgServ *service.OrganizationService) *KioskViewHandler {
+	return &KioskViewHandler{
+		kioskServ: kioskServ,
+		orgServ:   orgServ,
+	}
+}
+
+// KioskPageHandler shows the full screen punch page of the device stored in
+// the kiosk cookie, or the activation form when the browser is not a kiosk yet
+func (h *KioskViewHandler) KioskPageHandler(c *gin.Context) {
+	token, err := c.Cookie(domain.KioskTokenCookie)
+	if err != nil {
+		utils.Render(c.Request.Context(), c.Writer, pages.KioskActivatePage())
+		return
+	}
+
+	device, err := h.kioskServ.Authenticate(c.Request.Context(), token)
+	if err != nil {
+		utils.Render(c.Request.Context(), c.Writer, pages.KioskActivatePage())
+		return
+	}
+
+	org, err := h.orgServ.GetByID(c.Request.Context(), device.OrganizationID)
+	if err != nil || org == nil {
+		c.String(http.StatusNotFound, "Organização não encontrada")
+		return
+	}
+
+	utils.Render(c.Request.Context(), c.Writer, pages.KioskPage(*org, *device))
+}
+
+// KioskAdminPageHandler shows the kiosk devices and member credentials of an organization
+func (h *KioskViewHandler) KioskAdminPageHandler(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.String(http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	// Get user ID from JWT token
+	tokenString, err := c.Cookie("token")
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	claims, err := utils.GetTokenClaims(tokenString)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userIDStr, ok := claims["id"].(string)
+	if !ok {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userName, ok := claims["name"].(string)
+	if !ok {
+		userName = ""
+	}
+
+	devices, err := h.kioskServ.ListDevices(c.Request.Context(), userID, orgID, domain.PageRequest{})
+	if err != nil {
+		c.String(http.StatusForbidden, err.Error())
+		return
+	}
+
+	org, err := h.orgServ.GetByID(c.Request.Context(), orgID)
+	if err != nil || org == nil {
+		c.String(http.StatusNotFound, "Organização não encontrada")
+		return
+	}
+
+	members, err := h.kioskServ.ListMemberCredentials(c.Request.Context(), userID, orgID)
+	if err != nil {
+		members = []domain.KioskMember{}
+	}
+
+	utils.Render(c.Request.Context(), c.Writer, pages.OrganizationKioskPage(*org, devices.Items, members, userName))
+}
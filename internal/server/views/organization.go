@@ -86,13 +86,16 @@ func (h *OrganizationViewHandler) OrganizationDetailHandler(c *gin.Context) {
 		members = &empty
 	}
 
+	role, _ := h.orgServ.GetUserRole(c.Request.Context(), userID, orgID)
+	isManager := role == domain.Manager
+
 	// Get user name
 	userName, ok := claims["name"].(string)
 	if !ok {
 		userName = ""
 	}
 
-	utils.Render(c.Request.Context(), c.Writer, pages.OrganizationDetailPage(*org, isAdmin, userIDStr, *members, userName))
+	utils.Render(c.Request.Context(), c.Writer, pages.OrganizationDetailPage(*org, isAdmin, isManager, userIDStr, *members, userName))
 }
 
 // OrganizationCreateHandler shows the create organization form
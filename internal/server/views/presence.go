@@ -0,0 +1,85 @@
+package views
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+	"github.com/marcelorc13/timesheet-pro/internal/templates/pages"
+	"github.com/marcelorc13/timesheet-pro/internal/utils"
+)
+
+type PresenceViewHandler struct {
+	timesheetServ *service.TimesheetService
+	orgServ       *service.OrganizationService
+}
+
+func NewPresenceViewHandler(timesheetServ *service.TimesheetService, orgServ *service.OrganizationService) *PresenceViewHandler {
+	return &PresenceViewHandler{
+		timesheetServ: timesheetServ,
+		orgServ:       orgServ,
+	}
+}
+
+// PresencePageHandler shows the "who's in now" board of the organization
+func (h *PresenceViewHandler) PresencePageHandler(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.String(http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	// Get user ID from JWT token
+	tokenString, err := c.Cookie("token")
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	claims, err := utils.GetTokenClaims(tokenString)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userIDStr, ok := claims["id"].(string)
+	if !ok {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userName, ok := claims["name"].(string)
+	if !ok {
+		userName = ""
+	}
+
+	presence, err := h.timesheetServ.Presence(c.Request.Context(), userID, orgID)
+	if err != nil {
+		c.String(http.StatusForbidden, err.Error())
+		return
+	}
+
+	now := time.Now()
+
+	// Refreshes triggered by punch events only replace the board
+	if c.GetHeader("HX-Request") == "true" && c.GetHeader("HX-History-Restore-Request") != "true" {
+		utils.Render(c.Request.Context(), c.Writer, pages.PresenceBoard(presence, now))
+		return
+	}
+
+	org, err := h.orgServ.GetByID(c.Request.Context(), orgID)
+	if err != nil || org == nil {
+		c.String(http.StatusNotFound, "Organização não encontrada")
+		return
+	}
+
+	utils.Render(c.Request.Context(), c.Writer, pages.OrganizationPresencePage(*org, presence, now, userName))
+}
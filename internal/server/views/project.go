@@ -0,0 +1,108 @@
+package views
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+	"github.com/marcelorc13/timesheet-pro/internal/templates/pages"
+	"github.com/marcelorc13/timesheet-pro/internal/utils"
+)
+
+type ProjectViewHandler struct {
+	projectServ *service.ProjectService
+	billingServ *service.BillingService
+	orgServ     *service.OrganizationService
+}
+
+func NewProjectViewHandler(projectServ *service.ProjectService, billingServ *service.BillingService, orgServ *service.OrganizationService) *ProjectViewHandler {
+	return &ProjectViewHandler{
+		projectServ: projectServ,
+		billingServ: billingServ,
+		orgServ:     orgServ,
+	}
+}
+
+// ProjectsPageHandler shows the organization projects and the hours allocated
+// to them in the period, the current month by default
+func (h *ProjectViewHandler) ProjectsPageHandler(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.String(http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	// Get user ID from JWT token
+	tokenString, err := c.Cookie("token")
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	claims, err := utils.GetTokenClaims(tokenString)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userIDStr, ok := claims["id"].(string)
+	if !ok {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userName, ok := claims["name"].(string)
+	if !ok {
+		userName = ""
+	}
+
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if s := c.Query("start"); s != "" {
+		if start, err = time.ParseInLocation("2006-01-02", s, time.Local); err != nil {
+			c.String(http.StatusBadRequest, "Formato de data inválido (use YYYY-MM-DD)")
+			return
+		}
+	}
+	if e := c.Query("end"); e != "" {
+		if end, err = time.ParseInLocation("2006-01-02", e, time.Local); err != nil {
+			c.String(http.StatusBadRequest, "Formato de data inválido (use YYYY-MM-DD)")
+			return
+		}
+	}
+
+	projects, err := h.projectServ.Projects(c.Request.Context(), userID, orgID, true)
+	if err != nil {
+		c.String(http.StatusForbidden, err.Error())
+		return
+	}
+
+	clients, err := h.billingServ.Clients(c.Request.Context(), userID, orgID)
+	if err != nil {
+		c.String(http.StatusForbidden, err.Error())
+		return
+	}
+
+	report, err := h.projectServ.Report(c.Request.Context(), userID, orgID, start, end, nil)
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	org, err := h.orgServ.GetByID(c.Request.Context(), orgID)
+	if err != nil || org == nil {
+		c.String(http.StatusNotFound, "Organização não encontrada")
+		return
+	}
+
+	utils.Render(c.Request.Context(), c.Writer, pages.OrganizationProjectsPage(*org, projects, clients, *report, userName))
+}
@@ -0,0 +1,165 @@
+package views
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+	"github.com/marcelorc13/timesheet-pro/internal/templates/pages"
+	"github.com/marcelorc13/timesheet-pro/internal/utils"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// punchQRSize is the side in pixels of the QR code image shown on the office display
+const punchQRSize = 512
+
+// flaggedPunchesShown is how many recent flagged punches the page lists
+const flaggedPunchesShown = 50
+
+type PunchPolicyViewHandler struct {
+	policyServ *service.PunchPolicyService
+	orgServ    *service.OrganizationService
+}
+
+func NewPunchPolicyViewHandler(policyServ *service.PunchPolicyService, orgServ *service.OrganizationService) *PunchPolicyViewHandler {
+	return &PunchPolicyViewHandler{
+		policyServ: policyServ,
+		orgServ:    orgServ,
+	}
+}
+
+// PunchPolicyPageHandler shows the organization punch policy settings
+func (h *PunchPolicyViewHandler) PunchPolicyPageHandler(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.String(http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	// Get user ID from JWT token
+	tokenString, err := c.Cookie("token")
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	claims, err := utils.GetTokenClaims(tokenString)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userIDStr, ok := claims["id"].(string)
+	if !ok {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userName, ok := claims["name"].(string)
+	if !ok {
+		userName = ""
+	}
+
+	policy, err := h.policyServ.Policy(c.Request.Context(), userID, orgID)
+	if err != nil {
+		c.String(http.StatusForbidden, err.Error())
+		return
+	}
+
+	org, err := h.orgServ.GetByID(c.Request.Context(), orgID)
+	if err != nil || org == nil {
+		c.String(http.StatusNotFound, "Organização não encontrada")
+		return
+	}
+
+	punches := []domain.FlaggedPunch{}
+	if page, err := h.policyServ.FlaggedPunches(c.Request.Context(), userID, orgID, "", domain.PageRequest{Limit: flaggedPunchesShown}); err == nil {
+		punches = page.Items
+	}
+
+	utils.Render(c.Request.Context(), c.Writer, pages.OrganizationPunchPolicyPage(*org, *policy, punches, c.ClientIP(), userName))
+}
+
+// PunchQRDisplayHandler shows the rotating QR code in full screen for the
+// office display. HTMX requests get only the code, which reloads itself when
+// it expires.
+func (h *PunchPolicyViewHandler) PunchQRDisplayHandler(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.String(http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	// Get user ID from JWT token
+	tokenString, err := c.Cookie("token")
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	claims, err := utils.GetTokenClaims(tokenString)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userIDStr, ok := claims["id"].(string)
+	if !ok {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	qr, err := h.policyServ.CurrentQR(c.Request.Context(), userID, orgID)
+	if err != nil {
+		c.String(http.StatusForbidden, err.Error())
+		return
+	}
+
+	// Scanning the code opens the timesheet page with the code filled in
+	qr.URL = requestOrigin(c) + "/timesheet?qr=" + url.QueryEscape(qr.Code)
+	png, err := qrcode.Encode(qr.URL, qrcode.Medium, punchQRSize)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao gerar QR code")
+		return
+	}
+	image := "data:image/png;base64," + base64.StdEncoding.EncodeToString(png)
+
+	c.Header("Cache-Control", "no-store")
+	if c.GetHeader("HX-Request") == "true" {
+		utils.Render(c.Request.Context(), c.Writer, pages.PunchQRCode(orgID, *qr, image))
+		return
+	}
+
+	org, err := h.orgServ.GetByID(c.Request.Context(), orgID)
+	if err != nil || org == nil {
+		c.String(http.StatusNotFound, "Organização não encontrada")
+		return
+	}
+
+	utils.Render(c.Request.Context(), c.Writer, pages.PunchQRDisplayPage(*org, *qr, image))
+}
+
+// requestOrigin returns the scheme and host the client used to reach the server
+func requestOrigin(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host
+}
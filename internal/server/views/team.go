@@ -0,0 +1,88 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+	"github.com/marcelorc13/timesheet-pro/internal/templates/pages"
+	"github.com/marcelorc13/timesheet-pro/internal/utils"
+)
+
+type TeamViewHandler struct {
+	teamServ *service.TeamService
+	orgServ  *service.OrganizationService
+}
+
+func NewTeamViewHandler(teamServ *service.TeamService, orgServ *service.OrganizationService) *TeamViewHandler {
+	return &TeamViewHandler{
+		teamServ: teamServ,
+		orgServ:  orgServ,
+	}
+}
+
+// TeamsPageHandler shows the team management page of an organization
+func (h *TeamViewHandler) TeamsPageHandler(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.String(http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	// Get user ID from JWT token
+	tokenString, err := c.Cookie("token")
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	claims, err := utils.GetTokenClaims(tokenString)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userIDStr, ok := claims["id"].(string)
+	if !ok {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userName, ok := claims["name"].(string)
+	if !ok {
+		userName = ""
+	}
+
+	isAdmin, err := h.orgServ.IsUserAdmin(c.Request.Context(), userID, orgID)
+	if err != nil || !isAdmin {
+		c.String(http.StatusForbidden, "Apenas administradores podem gerenciar equipes")
+		return
+	}
+
+	org, err := h.orgServ.GetByID(c.Request.Context(), orgID)
+	if err != nil || org == nil {
+		c.String(http.StatusNotFound, "Organização não encontrada")
+		return
+	}
+
+	teams := []domain.Team{}
+	if page, err := h.teamServ.List(c.Request.Context(), userID, orgID, domain.PageRequest{}); err == nil {
+		teams = page.Items
+	}
+
+	members, err := h.orgServ.GetMembers(c.Request.Context(), orgID)
+	if err != nil || members == nil {
+		empty := []domain.OrganizationUser{}
+		members = &empty
+	}
+
+	utils.Render(c.Request.Context(), c.Writer, pages.OrganizationTeamsPage(*org, teams, *members, userName))
+}
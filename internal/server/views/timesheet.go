@@ -17,12 +17,16 @@ import (
 type TimesheetViewHandler struct {
 	timesheetServ *service.TimesheetService
 	orgServ       *service.OrganizationService
+	projectServ   *service.ProjectService
+	weekServ      *service.TimesheetWeekService
 }
 
-func NewTimesheetViewHandler(timesheetServ *service.TimesheetService, orgServ *service.OrganizationService) *TimesheetViewHandler {
+func NewTimesheetViewHandler(timesheetServ *service.TimesheetService, orgServ *service.OrganizationService, projectServ *service.ProjectService, weekServ *service.TimesheetWeekService) *TimesheetViewHandler {
 	return &TimesheetViewHandler{
 		timesheetServ: timesheetServ,
 		orgServ:       orgServ,
+		projectServ:   projectServ,
+		weekServ:      weekServ,
 	}
 }
 
@@ -79,10 +83,23 @@ func (h *TimesheetViewHandler) TimesheetPageHandler(c *gin.Context) {
 		lastTimestampStr = &formatted
 	}
 
-	utils.Render(c.Request.Context(), c.Writer, pages.TimesheetPage(*org, timesheet, status, lastTimestampStr, userName))
+	// The office QR code links here with the scanned code in the query string
+	policy, err := h.timesheetServ.PunchPolicy(c.Request.Context(), userID, org.ID)
+	if err != nil {
+		policy = &domain.PunchPolicy{OrganizationID: org.ID}
+	}
+
+	// Worked hours of the day can be allocated to the active projects
+	projects, _ := h.projectServ.Projects(c.Request.Context(), userID, org.ID, false)
+	allocations, _ := h.projectServ.Allocations(c.Request.Context(), userID, org.ID, time.Now())
+
+	// Past weeks not submitted yet, when the organization asks for them
+	pendingWeeks, _ := h.weekServ.PendingWeeks(c.Request.Context(), userID, org.ID)
+
+	utils.Render(c.Request.Context(), c.Writer, pages.TimesheetPage(*org, timesheet, status, lastTimestampStr, *policy, c.Query("qr"), projects, allocations, pendingWeeks, userName))
 }
 
-// AdminTimesheetPageHandler shows admin view of all organization timesheets
+// AdminTimesheetPageHandler shows the admin/manager view of the organization timesheets
 func (h *TimesheetViewHandler) AdminTimesheetPageHandler(c *gin.Context) {
 	// Get user ID from JWT token
 	tokenString, err := c.Cookie("token")
@@ -122,19 +139,50 @@ func (h *TimesheetViewHandler) AdminTimesheetPageHandler(c *gin.Context) {
 		return
 	}
 
-	// Check if user is admin
-	isAdmin, err := h.orgServ.IsUserAdmin(c.Request.Context(), userID, org.ID)
-	if err != nil || !isAdmin {
-		c.String(http.StatusForbidden, "Apenas administradores podem acessar esta página")
+	// Check if user is admin or manager
+	canSupervise, err := h.timesheetServ.CanSuperviseTimesheets(c.Request.Context(), userID, org.ID)
+	if err != nil || !canSupervise {
+		c.String(http.StatusForbidden, "Apenas administradores e gestores podem acessar esta página")
 		return
 	}
 
-	// Get all timesheets for today
-	today := time.Now().Truncate(24 * time.Hour)
-	timesheets, err := h.timesheetServ.GetOrganizationTimesheets(c.Request.Context(), userID, org.ID, today)
+	isAdmin, _ := h.orgServ.IsUserAdmin(c.Request.Context(), userID, org.ID)
+
+	teams, err := h.timesheetServ.GetVisibleTeams(c.Request.Context(), userID, org.ID)
 	if err != nil {
-		timesheets = []domain.UserTimesheet{}
+		teams = []domain.Team{}
+	}
+
+	// Filters, sorting and pagination (defaults to today)
+	view := pages.AdminTimesheetView{Page: domain.TimesheetPage{Timesheets: []domain.UserTimesheet{}}}
+	view.Query, err = domain.ParseTimesheetQuery(c.Query, time.Now())
+	if err != nil {
+		view.Error = err.Error()
+	} else {
+		page, err := h.timesheetServ.GetOrganizationTimesheets(c.Request.Context(), userID, org.ID, view.Query)
+		if err != nil {
+			view.Error = err.Error()
+		} else {
+			view.Page = *page
+		}
+
+		view.Totals, err = h.timesheetServ.SummarizeTeams(c.Request.Context(), userID, org.ID, view.Query, teams)
+		if err != nil {
+			view.Totals = []domain.TeamTotal{}
+		}
+	}
+
+	// HTMX requests from the filters only refresh the results
+	if c.GetHeader("HX-Request") == "true" && c.GetHeader("HX-History-Restore-Request") != "true" {
+		utils.Render(c.Request.Context(), c.Writer, pages.AdminTimesheetResults(view))
+		return
+	}
+
+	members, err := h.orgServ.GetMembers(c.Request.Context(), org.ID)
+	if err != nil || members == nil {
+		empty := []domain.OrganizationUser{}
+		members = &empty
 	}
 
-	utils.Render(c.Request.Context(), c.Writer, pages.AdminTimesheetPage(*org, timesheets, userName))
+	utils.Render(c.Request.Context(), c.Writer, pages.AdminTimesheetPage(*org, view, teams, *members, isAdmin, userName))
 }
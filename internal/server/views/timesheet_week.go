@@ -0,0 +1,168 @@
+package views
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+	"github.com/marcelorc13/timesheet-pro/internal/templates/pages"
+	"github.com/marcelorc13/timesheet-pro/internal/utils"
+)
+
+type TimesheetWeekViewHandler struct {
+	weekServ *service.TimesheetWeekService
+	orgServ  *service.OrganizationService
+}
+
+func NewTimesheetWeekViewHandler(weekServ *service.TimesheetWeekService, orgServ *service.OrganizationService) *TimesheetWeekViewHandler {
+	return &TimesheetWeekViewHandler{
+		weekServ: weekServ,
+		orgServ:  orgServ,
+	}
+}
+
+// WeekPageHandler shows the user's week summary with the submit button, the
+// current week by default
+func (h *TimesheetWeekViewHandler) WeekPageHandler(c *gin.Context) {
+	// Get user ID from JWT token
+	tokenString, err := c.Cookie("token")
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	claims, err := utils.GetTokenClaims(tokenString)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userIDStr, ok := claims["id"].(string)
+	if !ok {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userName, ok := claims["name"].(string)
+	if !ok {
+		userName = ""
+	}
+
+	currentWeek := domain.WeekStart(time.Now())
+	weekStart := currentWeek
+	if week := c.Query("week"); week != "" {
+		if weekStart, err = domain.ParseWeek(week); err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	org, err := h.orgServ.GetOrganizationByUserID(c.Request.Context(), userID)
+	if err != nil || org == nil {
+		c.String(http.StatusNotFound, "Você não pertence a nenhuma organização")
+		return
+	}
+
+	settings, err := h.weekServ.Settings(c.Request.Context(), userID, org.ID)
+	if err != nil {
+		c.String(http.StatusForbidden, err.Error())
+		return
+	}
+
+	summary, err := h.weekServ.Week(c.Request.Context(), userID, org.ID, weekStart)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao buscar a semana")
+		return
+	}
+
+	recent, err := h.weekServ.RecentWeeks(c.Request.Context(), userID, org.ID)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao buscar as semanas")
+		return
+	}
+
+	pending, err := h.weekServ.PendingWeeks(c.Request.Context(), userID, org.ID)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao buscar as semanas pendentes")
+		return
+	}
+
+	canSubmit := settings.Enabled && summary.Week.Status.CanSubmit() && !weekStart.After(currentWeek)
+
+	utils.Render(c.Request.Context(), c.Writer, pages.TimesheetWeekPage(*org, *summary, recent, pending, canSubmit, settings.Enabled, userName))
+}
+
+// ReviewPageHandler shows the submitted weeks waiting for the review of the
+// user, with the weekly submission toggle for admins
+func (h *TimesheetWeekViewHandler) ReviewPageHandler(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.String(http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	// Get user ID from JWT token
+	tokenString, err := c.Cookie("token")
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	claims, err := utils.GetTokenClaims(tokenString)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userIDStr, ok := claims["id"].(string)
+	if !ok {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userName, ok := claims["name"].(string)
+	if !ok {
+		userName = ""
+	}
+
+	queue, err := h.weekServ.ReviewQueue(c.Request.Context(), userID, orgID)
+	if err != nil {
+		c.String(http.StatusForbidden, err.Error())
+		return
+	}
+
+	settings, err := h.weekServ.Settings(c.Request.Context(), userID, orgID)
+	if err != nil {
+		c.String(http.StatusForbidden, err.Error())
+		return
+	}
+
+	isAdmin, err := h.orgServ.IsUserAdmin(c.Request.Context(), userID, orgID)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao verificar permissões")
+		return
+	}
+
+	org, err := h.orgServ.GetByID(c.Request.Context(), orgID)
+	if err != nil || org == nil {
+		c.String(http.StatusNotFound, "Organização não encontrada")
+		return
+	}
+
+	utils.Render(c.Request.Context(), c.Writer, pages.WeekReviewPage(*org, queue, *settings, isAdmin, userName))
+}
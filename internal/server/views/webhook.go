@@ -0,0 +1,85 @@
+package views
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	service "github.com/marcelorc13/timesheet-pro/internal/services"
+	"github.com/marcelorc13/timesheet-pro/internal/templates/pages"
+	"github.com/marcelorc13/timesheet-pro/internal/utils"
+)
+
+// webhookDeliveriesShown is how many recent deliveries the page lists
+const webhookDeliveriesShown = 50
+
+type WebhookViewHandler struct {
+	webhookServ *service.WebhookService
+	orgServ     *service.OrganizationService
+}
+
+func NewWebhookViewHandler(webhookServ *service.WebhookService, orgServ *service.OrganizationService) *WebhookViewHandler {
+	return &WebhookViewHandler{
+		webhookServ: webhookServ,
+		orgServ:     orgServ,
+	}
+}
+
+// WebhooksPageHandler shows the organization webhooks and their recent deliveries
+func (h *WebhookViewHandler) WebhooksPageHandler(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.String(http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	// Get user ID from JWT token
+	tokenString, err := c.Cookie("token")
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	claims, err := utils.GetTokenClaims(tokenString)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userIDStr, ok := claims["id"].(string)
+	if !ok {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login")
+		return
+	}
+
+	userName, ok := claims["name"].(string)
+	if !ok {
+		userName = ""
+	}
+
+	webhooks, err := h.webhookServ.List(c.Request.Context(), userID, orgID, domain.PageRequest{})
+	if err != nil {
+		c.String(http.StatusForbidden, err.Error())
+		return
+	}
+
+	org, err := h.orgServ.GetByID(c.Request.Context(), orgID)
+	if err != nil || org == nil {
+		c.String(http.StatusNotFound, "Organização não encontrada")
+		return
+	}
+
+	deliveries := []domain.WebhookDelivery{}
+	if page, err := h.webhookServ.Deliveries(c.Request.Context(), userID, orgID, nil, domain.PageRequest{Limit: webhookDeliveriesShown}); err == nil {
+		deliveries = page.Items
+	}
+
+	utils.Render(c.Request.Context(), c.Writer, pages.OrganizationWebhooksPage(*org, webhooks.Items, deliveries, userName))
+}
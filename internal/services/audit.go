@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	"github.com/marcelorc13/timesheet-pro/internal/repository"
+)
+
+type AuditService struct {
+	auditRepo *repository.AuditRepository
+	orgRepo   *repository.OrganizationRepository
+}
+
+func NewAuditService(auditRepo *repository.AuditRepository, orgRepo *repository.OrganizationRepository) *AuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+		orgRepo:   orgRepo,
+	}
+}
+
+// List retrieves a page of the organization audit log. Requesting user must be admin.
+func (s *AuditService) List(ctx context.Context, requestingUserID, orgID uuid.UUID, filter domain.AuditFilter, page domain.PageRequest) (*domain.CursorPage[domain.AuditLog], error) {
+	adminRes, err := s.orgRepo.IsUserAdmin(ctx, requestingUserID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !adminRes.Success {
+		return nil, fmt.Errorf("%s", adminRes.Message)
+	}
+
+	isAdmin, ok := adminRes.Data.(bool)
+	if !ok || !isAdmin {
+		return nil, fmt.Errorf("apenas administradores podem visualizar a auditoria")
+	}
+
+	res, err := s.auditRepo.List(ctx, orgID, filter, page)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	logs, ok := res.Data.(domain.CursorPage[domain.AuditLog])
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados da auditoria")
+	}
+
+	return &logs, nil
+}
+
+// recordAudit appends an audit entry using the request metadata found in ctx.
+// Call it with the context of a TxManager.WithTx so it commits with the change it describes.
+func recordAudit(ctx context.Context, auditRepo *repository.AuditRepository, orgID, actorID uuid.UUID, action domain.AuditAction, targetType, targetID string, before, after any) error {
+	entry := domain.AuditLog{
+		OrganizationID: orgID,
+		ActorID:        &actorID,
+		Action:         action,
+		TargetType:     targetType,
+		TargetID:       targetID,
+	}
+
+	if before != nil {
+		raw, err := json.Marshal(before)
+		if err != nil {
+			return err
+		}
+		entry.Before = raw
+	}
+
+	if after != nil {
+		raw, err := json.Marshal(after)
+		if err != nil {
+			return err
+		}
+		entry.After = raw
+	}
+
+	meta := domain.RequestMetadataFrom(ctx)
+	entry.IP = meta.IP
+	entry.UserAgent = meta.UserAgent
+
+	res, err := auditRepo.Insert(ctx, entry)
+	if err != nil {
+		return err
+	}
+
+	if !res.Success {
+		return fmt.Errorf("%s", res.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,509 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	"github.com/marcelorc13/timesheet-pro/internal/export"
+	"github.com/marcelorc13/timesheet-pro/internal/pdf"
+	"github.com/marcelorc13/timesheet-pro/internal/repository"
+)
+
+const billingDeniedMessage = "apenas administradores podem gerenciar o faturamento"
+
+type BillingService struct {
+	billingRepo *repository.BillingRepository
+	orgRepo     *repository.OrganizationRepository
+	auditRepo   *repository.AuditRepository
+	txManager   *repository.TxManager
+}
+
+func NewBillingService(billingRepo *repository.BillingRepository, orgRepo *repository.OrganizationRepository, auditRepo *repository.AuditRepository, txManager *repository.TxManager) *BillingService {
+	return &BillingService{
+		billingRepo: billingRepo,
+		orgRepo:     orgRepo,
+		auditRepo:   auditRepo,
+		txManager:   txManager,
+	}
+}
+
+// CreateClient adds a client to the organization. Requesting user must be admin.
+func (s *BillingService) CreateClient(ctx context.Context, requestingUserID, orgID uuid.UUID, cc domain.CreateClient) (*domain.Client, error) {
+	validate := validator.New()
+	if err := validate.Struct(cc); err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, billingDeniedMessage); err != nil {
+		return nil, err
+	}
+
+	document, err := domain.NormalizeDocument(cc.Document)
+	if err != nil {
+		return nil, err
+	}
+
+	client := domain.Client{
+		OrganizationID:  orgID,
+		Name:            strings.TrimSpace(cc.Name),
+		Document:        document,
+		Email:           strings.TrimSpace(cc.Email),
+		RoundingMinutes: cc.RoundingMinutes,
+		RoundingMode:    domain.RoundNearest,
+	}
+	if cc.RoundingMode != "" {
+		client.RoundingMode = domain.RoundingMode(cc.RoundingMode)
+	}
+
+	var saved domain.Client
+	err = s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.billingRepo.CreateClient(ctx, client)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		var ok bool
+		saved, ok = res.Data.(domain.Client)
+		if !ok {
+			return fmt.Errorf("erro ao converter dados do cliente")
+		}
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditClientCreate, "client", saved.ID.String(), nil, saved)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// UpdateClient changes a client of the organization. Nil fields are kept and
+// invoices already generated keep the data they were generated with.
+// Requesting user must be admin.
+func (s *BillingService) UpdateClient(ctx context.Context, requestingUserID, orgID, clientID uuid.UUID, uc domain.UpdateClient) (*domain.Client, error) {
+	validate := validator.New()
+	if err := validate.Struct(uc); err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, billingDeniedMessage); err != nil {
+		return nil, err
+	}
+
+	var saved domain.Client
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		before, err := s.client(ctx, orgID, clientID)
+		if err != nil {
+			return err
+		}
+
+		client := *before
+		if uc.Name != nil {
+			client.Name = strings.TrimSpace(*uc.Name)
+		}
+		if uc.Document != nil {
+			if client.Document, err = domain.NormalizeDocument(*uc.Document); err != nil {
+				return err
+			}
+		}
+		if uc.Email != nil {
+			client.Email = strings.TrimSpace(*uc.Email)
+		}
+		if uc.RoundingMinutes != nil {
+			client.RoundingMinutes = *uc.RoundingMinutes
+		}
+		if uc.RoundingMode != nil {
+			client.RoundingMode = domain.RoundingMode(*uc.RoundingMode)
+		}
+
+		res, err := s.billingRepo.UpdateClient(ctx, client)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		var ok bool
+		saved, ok = res.Data.(domain.Client)
+		if !ok {
+			return fmt.Errorf("erro ao converter dados do cliente")
+		}
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditClientUpdate, "client", clientID.String(), before, saved)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// Clients lists the organization clients. Requesting user must be admin.
+func (s *BillingService) Clients(ctx context.Context, requestingUserID, orgID uuid.UUID) ([]domain.Client, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, billingDeniedMessage); err != nil {
+		return nil, err
+	}
+
+	res, err := s.billingRepo.ListClients(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	clients, ok := res.Data.([]domain.Client)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados dos clientes")
+	}
+
+	return clients, nil
+}
+
+// MemberRates lists every member with the hourly rate they are billed at.
+// Requesting user must be admin.
+func (s *BillingService) MemberRates(ctx context.Context, requestingUserID, orgID uuid.UUID) ([]domain.MemberRate, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, billingDeniedMessage); err != nil {
+		return nil, err
+	}
+
+	res, err := s.billingRepo.ListMemberRates(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	rates, ok := res.Data.([]domain.MemberRate)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter valores/hora dos membros")
+	}
+
+	return rates, nil
+}
+
+// SetMemberRate sets the hourly rate a member is billed at; zero removes it
+// and the member is billed at the project rates. Requesting user must be admin.
+func (s *BillingService) SetMemberRate(ctx context.Context, requestingUserID, orgID, userID uuid.UUID, ur domain.UpdateMemberRate) (*domain.MemberRate, error) {
+	validate := validator.New()
+	if err := validate.Struct(ur); err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, billingDeniedMessage); err != nil {
+		return nil, err
+	}
+
+	var saved domain.MemberRate
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		before, err := s.memberRate(ctx, orgID, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.billingRepo.SetMemberRate(ctx, orgID, userID, domain.CentsFromReais(ur.HourlyRate)); err != nil {
+			return err
+		}
+
+		after, err := s.memberRate(ctx, orgID, userID)
+		if err != nil {
+			return err
+		}
+		saved = *after
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditMemberRateUpdate, "user", userID.String(), before, saved)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// CreateInvoice generates an invoice draft for the billable hours of the
+// client between start and end (inclusive). The draft keeps a copy of the
+// hours and rates, so later corrections only reach a new draft. Requesting
+// user must be admin.
+func (s *BillingService) CreateInvoice(ctx context.Context, requestingUserID, orgID uuid.UUID, ci domain.CreateInvoice) (*domain.Invoice, error) {
+	validate := validator.New()
+	if err := validate.Struct(ci); err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	start, err := time.ParseInLocation("2006-01-02", ci.Start, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("formato de data inválido (use YYYY-MM-DD)")
+	}
+	end, err := time.ParseInLocation("2006-01-02", ci.End, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("formato de data inválido (use YYYY-MM-DD)")
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("data final deve ser posterior à data inicial")
+	}
+
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, billingDeniedMessage); err != nil {
+		return nil, err
+	}
+
+	orgRes, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !orgRes.Success {
+		return nil, fmt.Errorf("%s", orgRes.Message)
+	}
+
+	org, ok := orgRes.Data.(domain.Organization)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados da organização")
+	}
+
+	var saved domain.Invoice
+	err = s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		client, err := s.client(ctx, orgID, uuid.MustParse(ci.ClientID))
+		if err != nil {
+			return err
+		}
+
+		res, err := s.billingRepo.BillableHours(ctx, orgID, client.ID, start, end)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		hours, ok := res.Data.([]domain.BillableHours)
+		if !ok {
+			return fmt.Errorf("erro ao converter horas faturáveis")
+		}
+
+		invoice := domain.NewInvoice(*client, hours, start, end)
+		if len(invoice.Lines) == 0 {
+			return fmt.Errorf("não há horas faturáveis do cliente no período")
+		}
+		invoice.OrganizationName = org.Name
+		invoice.CreatedBy = requestingUserID
+
+		res, err = s.billingRepo.CreateInvoice(ctx, invoice)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		saved, ok = res.Data.(domain.Invoice)
+		if !ok {
+			return fmt.Errorf("erro ao converter dados da fatura")
+		}
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditInvoiceCreate, "invoice", saved.ID.String(), nil, saved)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// Invoices lists the organization invoices, newest first, without their
+// lines. Requesting user must be admin.
+func (s *BillingService) Invoices(ctx context.Context, requestingUserID, orgID uuid.UUID) ([]domain.Invoice, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, billingDeniedMessage); err != nil {
+		return nil, err
+	}
+
+	res, err := s.billingRepo.ListInvoices(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	invoices, ok := res.Data.([]domain.Invoice)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados das faturas")
+	}
+
+	return invoices, nil
+}
+
+// Invoice retrieves an invoice with its lines. Requesting user must be admin.
+func (s *BillingService) Invoice(ctx context.Context, requestingUserID, orgID, invoiceID uuid.UUID) (*domain.Invoice, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, billingDeniedMessage); err != nil {
+		return nil, err
+	}
+
+	return s.invoice(ctx, orgID, invoiceID)
+}
+
+// IssueInvoice numbers a draft and makes it final. Requesting user must be admin.
+func (s *BillingService) IssueInvoice(ctx context.Context, requestingUserID, orgID, invoiceID uuid.UUID) (*domain.Invoice, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, billingDeniedMessage); err != nil {
+		return nil, err
+	}
+
+	var saved domain.Invoice
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		before, err := s.invoice(ctx, orgID, invoiceID)
+		if err != nil {
+			return err
+		}
+
+		res, err := s.billingRepo.IssueInvoice(ctx, orgID, invoiceID)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		var ok bool
+		saved, ok = res.Data.(domain.Invoice)
+		if !ok {
+			return fmt.Errorf("erro ao converter dados da fatura")
+		}
+		saved.Lines = before.Lines
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditInvoiceIssue, "invoice", invoiceID.String(), before, saved)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// DeleteInvoice discards a draft; issued invoices are kept. Requesting user
+// must be admin.
+func (s *BillingService) DeleteInvoice(ctx context.Context, requestingUserID, orgID, invoiceID uuid.UUID) error {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, billingDeniedMessage); err != nil {
+		return err
+	}
+
+	return s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		before, err := s.invoice(ctx, orgID, invoiceID)
+		if err != nil {
+			return err
+		}
+
+		res, err := s.billingRepo.DeleteInvoice(ctx, orgID, invoiceID)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditInvoiceDelete, "invoice", invoiceID.String(), before, nil)
+	})
+}
+
+// InvoiceFile renders an invoice as "pdf" or "csv". Returns the file, its
+// name and content type. Requesting user must be admin.
+func (s *BillingService) InvoiceFile(ctx context.Context, requestingUserID, orgID, invoiceID uuid.UUID, format string) ([]byte, string, string, error) {
+	invoice, err := s.Invoice(ctx, requestingUserID, orgID, invoiceID)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	name := "fatura-" + invoice.Code()
+	if invoice.Number == nil {
+		name = "rascunho-fatura-" + invoice.CreatedAt.Format("20060102150405")
+	}
+
+	switch format {
+	case "pdf":
+		content, err := pdf.Invoice(*invoice)
+		if err != nil {
+			return nil, "", "", fmt.Errorf("erro ao gerar PDF da fatura")
+		}
+		return content, name + ".pdf", "application/pdf", nil
+	case "csv":
+		var buf bytes.Buffer
+		if err := export.InvoiceCSV(&buf, *invoice); err != nil {
+			return nil, "", "", fmt.Errorf("erro ao gerar CSV da fatura")
+		}
+		return buf.Bytes(), name + ".csv", "text/csv; charset=utf-8", nil
+	default:
+		return nil, "", "", fmt.Errorf("formato de fatura inválido: %q", format)
+	}
+}
+
+func (s *BillingService) client(ctx context.Context, orgID, clientID uuid.UUID) (*domain.Client, error) {
+	res, err := s.billingRepo.GetClient(ctx, orgID, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	client, ok := res.Data.(domain.Client)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados do cliente")
+	}
+
+	return &client, nil
+}
+
+func (s *BillingService) memberRate(ctx context.Context, orgID, userID uuid.UUID) (*domain.MemberRate, error) {
+	res, err := s.billingRepo.GetMemberRate(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	rate, ok := res.Data.(domain.MemberRate)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter valor/hora do membro")
+	}
+
+	return &rate, nil
+}
+
+func (s *BillingService) invoice(ctx context.Context, orgID, invoiceID uuid.UUID) (*domain.Invoice, error) {
+	res, err := s.billingRepo.GetInvoice(ctx, orgID, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	invoice, ok := res.Data.(domain.Invoice)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados da fatura")
+	}
+
+	return &invoice, nil
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	"github.com/marcelorc13/timesheet-pro/internal/repository"
+)
+
+type ComplianceService struct {
+	timesheetRepo *repository.TimesheetRepository
+	orgRepo       *repository.OrganizationRepository
+}
+
+func NewComplianceService(timesheetRepo *repository.TimesheetRepository, orgRepo *repository.OrganizationRepository) *ComplianceService {
+	return &ComplianceService{
+		timesheetRepo: timesheetRepo,
+		orgRepo:       orgRepo,
+	}
+}
+
+// Report checks the organization timesheets of the month against the
+// labor-law rules and lists the violations of each member. Requesting user
+// must be admin.
+func (s *ComplianceService) Report(ctx context.Context, requestingUserID, orgID uuid.UUID, month time.Time) (*domain.ComplianceReport, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem acompanhar a conformidade da organização"); err != nil {
+		return nil, err
+	}
+
+	start := month
+	end := month.AddDate(0, 1, -1)
+
+	// The days before the month tell the rest since the previous workday and
+	// how many days in a row the member had already worked
+	res, err := s.timesheetRepo.GetOrganizationTimesheetsForPeriod(ctx, orgID, start.AddDate(0, 0, -domain.MaxConsecutiveDays), end, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	timesheets, ok := res.Data.([]domain.UserTimesheet)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter timesheets")
+	}
+
+	return &domain.ComplianceReport{
+		Month:   month,
+		Members: domain.CheckCompliance(timesheets, start, end),
+	}, nil
+}
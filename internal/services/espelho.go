@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	"github.com/marcelorc13/timesheet-pro/internal/pdf"
+	"github.com/marcelorc13/timesheet-pro/internal/repository"
+)
+
+type EspelhoService struct {
+	timesheetServ *TimesheetService
+	orgRepo       *repository.OrganizationRepository
+	userRepo      *repository.UserRepository
+	signatureRepo *repository.SignatureRepository
+}
+
+func NewEspelhoService(timesheetServ *TimesheetService, orgRepo *repository.OrganizationRepository, userRepo *repository.UserRepository, signatureRepo *repository.SignatureRepository) *EspelhoService {
+	return &EspelhoService{
+		timesheetServ: timesheetServ,
+		orgRepo:       orgRepo,
+		userRepo:      userRepo,
+		signatureRepo: signatureRepo,
+	}
+}
+
+// Document renders the espelho de ponto of targetUserID for the month. The
+// requesting user must be allowed to view the target timesheets.
+func (s *EspelhoService) Document(ctx context.Context, requestingUserID, targetUserID, orgID uuid.UUID, month time.Time) (*domain.EspelhoDocument, error) {
+	timesheets, err := s.timesheetServ.GetUserTimesheets(ctx, requestingUserID, targetUserID, orgID, month, month.AddDate(0, 1, -1), domain.PageRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	orgRes, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !orgRes.Success {
+		return nil, fmt.Errorf("%s", orgRes.Message)
+	}
+
+	org, ok := orgRes.Data.(domain.Organization)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados da organização")
+	}
+
+	userRes, err := s.userRepo.GetByID(ctx, targetUserID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if !userRes.Success {
+		return nil, fmt.Errorf("%s", userRes.Message)
+	}
+
+	user, ok := userRes.Data.(domain.User)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados do usuário")
+	}
+
+	content, err := pdf.Espelho(domain.NewEspelho(org, user.Name, user.CPF, month, timesheets.Items))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar PDF do espelho de ponto")
+	}
+
+	sum := sha256.Sum256(content)
+	return &domain.EspelhoDocument{
+		Content:  content,
+		Hash:     hex.EncodeToString(sum[:]),
+		FileName: fmt.Sprintf("espelho-%s-%s.pdf", month.Format("2006-01"), targetUserID),
+	}, nil
+}
+
+// Signature retrieves the user signature of the month, or nil when the espelho
+// was not signed yet
+func (s *EspelhoService) Signature(ctx context.Context, userID, orgID uuid.UUID, month time.Time) (*domain.TimesheetSignature, error) {
+	res, err := s.signatureRepo.Get(ctx, orgID, userID, month)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	sig, ok := res.Data.(*domain.TimesheetSignature)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados da assinatura")
+	}
+
+	return sig, nil
+}
+
+// Sign records the user acknowledgment of their espelho. The hash must match
+// the current document, so the employee signs exactly what they downloaded.
+func (s *EspelhoService) Sign(ctx context.Context, userID, orgID uuid.UUID, month time.Time, se domain.SignEspelho) (*domain.TimesheetSignature, error) {
+	validate := validator.New()
+	if err := validate.Struct(se); err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	now := time.Now()
+	if !month.Before(time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)) {
+		return nil, fmt.Errorf("apenas espelhos de meses encerrados podem ser assinados")
+	}
+
+	doc, err := s.Document(ctx, userID, userID, orgID, month)
+	if err != nil {
+		return nil, err
+	}
+
+	if doc.Hash != se.DocumentHash {
+		return nil, fmt.Errorf("o espelho foi alterado, baixe a versão atual antes de assinar")
+	}
+
+	meta := domain.RequestMetadataFrom(ctx)
+	res, err := s.signatureRepo.Create(ctx, domain.TimesheetSignature{
+		OrganizationID: orgID,
+		UserID:         userID,
+		Month:          month,
+		DocumentHash:   doc.Hash,
+		IP:             meta.IP,
+		UserAgent:      meta.UserAgent,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	sig, ok := res.Data.(domain.TimesheetSignature)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados da assinatura")
+	}
+
+	return &sig, nil
+}
+
+// Signatures lists a page of members with their signature of the month. Signed
+// espelhos are rendered again to flag records changed after the signature.
+// Requesting user must be admin.
+func (s *EspelhoService) Signatures(ctx context.Context, requestingUserID, orgID uuid.UUID, month time.Time, page domain.PageRequest) (*domain.CursorPage[domain.EspelhoSignatureStatus], error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem acompanhar as assinaturas dos espelhos"); err != nil {
+		return nil, err
+	}
+
+	membersRes, err := s.orgRepo.GetOrganizationMembers(ctx, orgID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	if !membersRes.Success {
+		return nil, fmt.Errorf("%s", membersRes.Message)
+	}
+
+	members, ok := membersRes.Data.(domain.CursorPage[domain.OrganizationUser])
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados dos membros")
+	}
+
+	sigRes, err := s.signatureRepo.ListByMonth(ctx, orgID, month)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sigRes.Success {
+		return nil, fmt.Errorf("%s", sigRes.Message)
+	}
+
+	signatures, ok := sigRes.Data.([]domain.TimesheetSignature)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados das assinaturas")
+	}
+
+	byUser := map[uuid.UUID]*domain.TimesheetSignature{}
+	for i := range signatures {
+		byUser[signatures[i].UserID] = &signatures[i]
+	}
+
+	statuses := make([]domain.EspelhoSignatureStatus, 0, len(members.Items))
+	for _, m := range members.Items {
+		status := domain.EspelhoSignatureStatus{UserID: m.UserID, Name: m.Name, Email: m.Email, Signature: byUser[m.UserID]}
+		if status.Signature != nil {
+			doc, err := s.Document(ctx, requestingUserID, m.UserID, orgID, month)
+			if err != nil {
+				return nil, err
+			}
+			status.Outdated = doc.Hash != status.Signature.DocumentHash
+		}
+		statuses = append(statuses, status)
+	}
+
+	return &domain.CursorPage[domain.EspelhoSignatureStatus]{Items: statuses, NextCursor: members.NextCursor}, nil
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	"github.com/marcelorc13/timesheet-pro/internal/export"
+	"github.com/marcelorc13/timesheet-pro/internal/repository"
+)
+
+type ExportService struct {
+	timesheetRepo *repository.TimesheetRepository
+	orgRepo       *repository.OrganizationRepository
+	scheduleRepo  *repository.ScheduleRepository
+	software      export.Software
+}
+
+func NewExportService(timesheetRepo *repository.TimesheetRepository, orgRepo *repository.OrganizationRepository, scheduleRepo *repository.ScheduleRepository, software export.Software) *ExportService {
+	return &ExportService{
+		timesheetRepo: timesheetRepo,
+		orgRepo:       orgRepo,
+		scheduleRepo:  scheduleRepo,
+		software:      software,
+	}
+}
+
+// AFD generates the AFD file of the organization for the period between start
+// and end (inclusive). Requesting user must be admin.
+func (s *ExportService) AFD(ctx context.Context, requestingUserID, orgID uuid.UUID, start, end time.Time) ([]byte, string, error) {
+	org, punches, err := s.fiscalData(ctx, requestingUserID, orgID, start, end)
+	if err != nil {
+		return nil, "", err
+	}
+
+	header := export.AFDHeader{
+		EmployerDocument: org.CNPJ,
+		EmployerName:     org.Name,
+		Start:            start,
+		End:              end,
+		GeneratedAt:      time.Now(),
+		Software:         s.software,
+	}
+
+	var buf bytes.Buffer
+	if err := export.WriteAFD(&buf, header, punches); err != nil {
+		return nil, "", fmt.Errorf("erro ao gerar arquivo AFD")
+	}
+
+	return buf.Bytes(), export.AFDFileName(header), nil
+}
+
+// AEJ generates the AEJ file of the organization for the period between start
+// and end (inclusive), optionally for a single member. Requesting user must be admin.
+func (s *ExportService) AEJ(ctx context.Context, requestingUserID, orgID uuid.UUID, start, end time.Time, memberID *uuid.UUID) ([]byte, string, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem exportar dados da organização"); err != nil {
+		return nil, "", err
+	}
+
+	if end.Before(start) {
+		return nil, "", fmt.Errorf("data final deve ser posterior à data inicial")
+	}
+
+	org, err := s.organization(ctx, orgID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	schedulesRes, err := s.scheduleRepo.ListByOrganization(ctx, orgID, domain.PageRequest{})
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !schedulesRes.Success {
+		return nil, "", fmt.Errorf("%s", schedulesRes.Message)
+	}
+
+	schedulesPage, ok := schedulesRes.Data.(domain.CursorPage[domain.WorkSchedule])
+	if !ok {
+		return nil, "", fmt.Errorf("erro ao converter horários contratuais")
+	}
+	schedules := schedulesPage.Items
+
+	membersRes, err := s.scheduleRepo.ListMemberSchedules(ctx, orgID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !membersRes.Success {
+		return nil, "", fmt.Errorf("%s", membersRes.Message)
+	}
+
+	members, ok := membersRes.Data.([]domain.MemberSchedule)
+	if !ok {
+		return nil, "", fmt.Errorf("erro ao converter membros")
+	}
+
+	codes := map[uuid.UUID]string{}
+	for _, sc := range schedules {
+		codes[sc.ID] = sc.Code
+	}
+
+	aejMembers := []export.AEJMember{}
+	missingCPF := []string{}
+	missingSchedule := []string{}
+	for _, m := range members {
+		if memberID != nil && m.UserID != *memberID {
+			continue
+		}
+		if m.CPF == "" {
+			missingCPF = append(missingCPF, m.Name)
+		}
+		if m.ScheduleID == nil {
+			missingSchedule = append(missingSchedule, m.Name)
+			continue
+		}
+		aejMembers = append(aejMembers, export.AEJMember{UserID: m.UserID, Name: m.Name, CPF: m.CPF, ScheduleCode: codes[*m.ScheduleID]})
+	}
+
+	if memberID != nil && len(aejMembers) == 0 && len(missingSchedule) == 0 {
+		return nil, "", fmt.Errorf("usuário não é membro desta organização")
+	}
+
+	if len(missingCPF) > 0 {
+		return nil, "", fmt.Errorf("funcionários sem CPF cadastrado: %s", strings.Join(missingCPF, ", "))
+	}
+
+	if len(missingSchedule) > 0 {
+		return nil, "", fmt.Errorf("funcionários sem horário contratual: %s", strings.Join(missingSchedule, ", "))
+	}
+
+	timesheetsRes, err := s.timesheetRepo.GetOrganizationTimesheetsForPeriod(ctx, orgID, start, end, memberID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !timesheetsRes.Success {
+		return nil, "", fmt.Errorf("%s", timesheetsRes.Message)
+	}
+
+	timesheets, ok := timesheetsRes.Data.([]domain.UserTimesheet)
+	if !ok {
+		return nil, "", fmt.Errorf("erro ao converter timesheets")
+	}
+
+	data := export.AEJData{
+		EmployerDocument: org.CNPJ,
+		EmployerName:     org.Name,
+		Start:            start,
+		End:              end,
+		GeneratedAt:      time.Now(),
+		Software:         s.software,
+		Schedules:        schedules,
+		Members:          aejMembers,
+		Timesheets:       timesheets,
+	}
+
+	var buf bytes.Buffer
+	if err := export.WriteAEJ(&buf, data); err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), export.AEJFileName(data), nil
+}
+
+// Timesheets streams the organization timesheets between start and end
+// (inclusive), optionally for a single member, to the writer built by newWriter.
+// The writer is only created once the request is authorized, so nothing is
+// written when an error is returned before streaming starts. Requesting user must be admin.
+func (s *ExportService) Timesheets(ctx context.Context, requestingUserID, orgID uuid.UUID, start, end time.Time, memberID *uuid.UUID, newWriter func() (export.TimesheetWriter, error)) error {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem exportar dados da organização"); err != nil {
+		return err
+	}
+
+	if end.Before(start) {
+		return fmt.Errorf("data final deve ser posterior à data inicial")
+	}
+
+	w, err := newWriter()
+	if err != nil {
+		return err
+	}
+
+	err = s.timesheetRepo.StreamOrganizationTimesheets(ctx, orgID, start, end, memberID, w.WriteTimesheet)
+	if err != nil {
+		return err
+	}
+
+	return w.Close()
+}
+
+// organization loads the organization, which must have a CNPJ to appear in fiscal files
+func (s *ExportService) organization(ctx context.Context, orgID uuid.UUID) (*domain.Organization, error) {
+	orgRes, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !orgRes.Success {
+		return nil, fmt.Errorf("%s", orgRes.Message)
+	}
+
+	org, ok := orgRes.Data.(domain.Organization)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados da organização")
+	}
+
+	if org.CNPJ == "" {
+		return nil, fmt.Errorf("cadastre o CNPJ da organização antes de exportar")
+	}
+
+	return &org, nil
+}
+
+// fiscalData loads what the AFD needs, checking the requesting user is admin
+// and every employee has a CPF
+func (s *ExportService) fiscalData(ctx context.Context, requestingUserID, orgID uuid.UUID, start, end time.Time) (*domain.Organization, []domain.EmployeePunch, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem exportar dados da organização"); err != nil {
+		return nil, nil, err
+	}
+
+	if end.Before(start) {
+		return nil, nil, fmt.Errorf("data final deve ser posterior à data inicial")
+	}
+
+	org, err := s.organization(ctx, orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := s.timesheetRepo.GetEmployeePunches(ctx, orgID, start, end.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !res.Success {
+		return nil, nil, fmt.Errorf("%s", res.Message)
+	}
+
+	punches, ok := res.Data.([]domain.EmployeePunch)
+	if !ok {
+		return nil, nil, fmt.Errorf("erro ao converter registros de ponto")
+	}
+
+	missing := []string{}
+	seen := map[uuid.UUID]bool{}
+	for _, p := range punches {
+		if p.CPF == "" && !seen[p.UserID] {
+			missing = append(missing, p.UserName)
+		}
+		seen[p.UserID] = true
+	}
+
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("funcionários sem CPF cadastrado: %s", strings.Join(missing, ", "))
+	}
+
+	return org, punches, nil
+}
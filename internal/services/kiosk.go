@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	"github.com/marcelorc13/timesheet-pro/internal/repository"
+)
+
+// Punches accepted per kiosk device each minute, and how many unknown
+// credentials lock the device for the rest of the minute
+const (
+	kioskAttemptsPerMinute = 30
+	kioskFailuresPerMinute = 5
+)
+
+type KioskService struct {
+	kioskRepo     *repository.KioskRepository
+	orgRepo       *repository.OrganizationRepository
+	auditRepo     *repository.AuditRepository
+	txManager     *repository.TxManager
+	timesheetServ *TimesheetService
+	pinKey        []byte
+	attempts      *windowLimiter
+	failures      *windowLimiter
+}
+
+func NewKioskService(kioskRepo *repository.KioskRepository, orgRepo *repository.OrganizationRepository, auditRepo *repository.AuditRepository, txManager *repository.TxManager, timesheetServ *TimesheetService, pinKey []byte) *KioskService {
+	return &KioskService{
+		kioskRepo:     kioskRepo,
+		orgRepo:       orgRepo,
+		auditRepo:     auditRepo,
+		txManager:     txManager,
+		timesheetServ: timesheetServ,
+		pinKey:        pinKey,
+		attempts:      newWindowLimiter(kioskAttemptsPerMinute, time.Minute),
+		failures:      newWindowLimiter(kioskFailuresPerMinute, time.Minute),
+	}
+}
+
+// RegisterDevice creates a kiosk device and returns its token, which is only
+// shown this once. Requesting user must be admin.
+func (s *KioskService) RegisterDevice(ctx context.Context, requestingUserID, orgID uuid.UUID, cd domain.CreateKioskDevice) (*domain.RegisteredKioskDevice, error) {
+	validate := validator.New()
+	err := validate.Struct(cd)
+	if err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem gerenciar terminais de ponto"); err != nil {
+		return nil, err
+	}
+
+	token, err := newKioskToken()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar token do terminal")
+	}
+
+	var device domain.KioskDevice
+	err = s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.kioskRepo.Create(ctx, orgID, cd.Name, domain.HashKioskToken(token))
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		var ok bool
+		device, ok = res.Data.(domain.KioskDevice)
+		if !ok {
+			return fmt.Errorf("erro ao converter dados do terminal")
+		}
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditKioskRegister, "kiosk_device", device.ID.String(), nil, device)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.RegisteredKioskDevice{KioskDevice: device, Token: token}, nil
+}
+
+// ListDevices retrieves a page of the organization kiosk devices. Requesting user must be admin.
+func (s *KioskService) ListDevices(ctx context.Context, requestingUserID, orgID uuid.UUID, page domain.PageRequest) (*domain.CursorPage[domain.KioskDevice], error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem gerenciar terminais de ponto"); err != nil {
+		return nil, err
+	}
+
+	res, err := s.kioskRepo.ListByOrganization(ctx, orgID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	devices, ok := res.Data.(domain.CursorPage[domain.KioskDevice])
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados dos terminais")
+	}
+
+	return &devices, nil
+}
+
+// RevokeDevice stops a device from punching. Requesting user must be admin.
+func (s *KioskService) RevokeDevice(ctx context.Context, requestingUserID, orgID, deviceID uuid.UUID) error {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem gerenciar terminais de ponto"); err != nil {
+		return err
+	}
+
+	return s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.kioskRepo.Revoke(ctx, orgID, deviceID)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditKioskRevoke, "kiosk_device", deviceID.String(), nil, res.Data)
+	})
+}
+
+// ListMemberCredentials retrieves which members can punch at kiosks. Requesting user must be admin.
+func (s *KioskService) ListMemberCredentials(ctx context.Context, requestingUserID, orgID uuid.UUID) ([]domain.KioskMember, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem gerenciar terminais de ponto"); err != nil {
+		return nil, err
+	}
+
+	res, err := s.kioskRepo.ListMemberCredentials(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	members, ok := res.Data.([]domain.KioskMember)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados dos membros")
+	}
+
+	return members, nil
+}
+
+// SetMemberCredentials sets the PIN and badge code a member uses at kiosks.
+// Requesting user must be admin.
+func (s *KioskService) SetMemberCredentials(ctx context.Context, requestingUserID, orgID, memberID uuid.UUID, kc domain.KioskCredentials) error {
+	validate := validator.New()
+	err := validate.Struct(kc)
+	if err != nil {
+		return err.(validator.ValidationErrors)
+	}
+
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem gerenciar terminais de ponto"); err != nil {
+		return err
+	}
+
+	var pinHash, badgeCode *string
+	if kc.PIN != nil {
+		hash := ""
+		if *kc.PIN != "" {
+			hash = domain.HashKioskPIN(s.pinKey, orgID, *kc.PIN)
+		}
+		pinHash = &hash
+	}
+	if kc.BadgeCode != nil {
+		code := strings.TrimSpace(*kc.BadgeCode)
+		badgeCode = &code
+	}
+
+	return s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.kioskRepo.SetMemberCredentials(ctx, orgID, memberID, pinHash, badgeCode)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		// The PIN itself is never written to the audit log
+		after := map[string]any{}
+		if pinHash != nil {
+			after["has_pin"] = *pinHash != ""
+		}
+		if badgeCode != nil {
+			after["badge_code"] = *badgeCode
+		}
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditKioskCredentials, "user", memberID.String(), nil, after)
+	})
+}
+
+// Authenticate returns the active device that owns the token
+func (s *KioskService) Authenticate(ctx context.Context, token string) (*domain.KioskDevice, error) {
+	if token == "" {
+		return nil, fmt.Errorf("terminal não autorizado")
+	}
+
+	res, err := s.kioskRepo.Authenticate(ctx, domain.HashKioskToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	device, ok := res.Data.(domain.KioskDevice)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados do terminal")
+	}
+
+	return &device, nil
+}
+
+// Punch identifies the member by PIN or badge code and records the punch at
+// the device. Each device is rate limited, and repeated unknown credentials
+// lock it for the rest of the minute.
+func (s *KioskService) Punch(ctx context.Context, device domain.KioskDevice, pr domain.KioskPunchRequest) (*domain.PunchReceipt, error) {
+	validate := validator.New()
+	err := validate.Struct(pr)
+	if err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	key := device.ID.String()
+	if s.failures.Exceeded(key) || !s.attempts.Allow(key) {
+		return nil, fmt.Errorf("muitas tentativas neste terminal, aguarde um minuto")
+	}
+
+	credential := strings.TrimSpace(pr.Credential)
+	res, err := s.kioskRepo.FindMemberByCredential(ctx, device.OrganizationID, domain.HashKioskPIN(s.pinKey, device.OrganizationID, credential), credential)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		s.failures.Allow(key)
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	userID, ok := res.Data.(uuid.UUID)
+	if !ok {
+		return nil, fmt.Errorf("erro ao identificar membro")
+	}
+
+	return s.timesheetServ.ClockInAtKiosk(ctx, userID, device.OrganizationID, device.ID)
+}
+
+func newKioskToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -5,6 +5,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-playground/validator"
 	"github.com/google/uuid"
@@ -13,14 +14,20 @@ import (
 )
 
 type OrganizationService struct {
-	repository     repository.OrganizationRepository
-	userRepository repository.UserRepository
+	repository        repository.OrganizationRepository
+	userRepository    repository.UserRepository
+	auditRepository   *repository.AuditRepository
+	webhookRepository *repository.WebhookRepository
+	txManager         *repository.TxManager
 }
 
-func NewOrganizationService(organizationRepository repository.OrganizationRepository, userRepository repository.UserRepository) *OrganizationService {
+func NewOrganizationService(organizationRepository repository.OrganizationRepository, userRepository repository.UserRepository, auditRepository *repository.AuditRepository, webhookRepository *repository.WebhookRepository, txManager *repository.TxManager) *OrganizationService {
 	return &OrganizationService{
-		repository:     organizationRepository,
-		userRepository: userRepository,
+		repository:        organizationRepository,
+		userRepository:    userRepository,
+		auditRepository:   auditRepository,
+		webhookRepository: webhookRepository,
+		txManager:         txManager,
 	}
 }
 
@@ -92,6 +99,16 @@ func (s *OrganizationService) Update(ctx context.Context, userID, orgID uuid.UUI
 		return err.(validator.ValidationErrors)
 	}
 
+	uo.CNPJ, err = domain.NormalizeCNPJ(uo.CNPJ)
+	if err != nil {
+		return err
+	}
+
+	coordinates, err := domain.ParseGeoPoint(uo.Latitude, uo.Longitude)
+	if err != nil {
+		return err
+	}
+
 	// Check if user is admin
 	adminRes, err := s.repository.IsUserAdmin(ctx, userID, orgID)
 	if err != nil {
@@ -111,16 +128,23 @@ func (s *OrganizationService) Update(ctx context.Context, userID, orgID uuid.UUI
 		return fmt.Errorf("usuário não tem permissão para atualizar esta organização")
 	}
 
-	res, err := s.repository.Update(ctx, orgID, uo)
+	before, err := s.GetByID(ctx, orgID)
 	if err != nil {
 		return err
 	}
 
-	if !res.Success {
-		return fmt.Errorf("%s", res.Message)
-	}
+	return s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.repository.Update(ctx, orgID, uo, coordinates)
+		if err != nil {
+			return err
+		}
 
-	return nil
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		return recordAudit(ctx, s.auditRepository, orgID, userID, domain.AuditOrganizationUpdate, "organization", orgID.String(), before, uo)
+	})
 }
 
 func (s *OrganizationService) Delete(ctx context.Context, userID, orgID uuid.UUID) error {
@@ -143,16 +167,23 @@ func (s *OrganizationService) Delete(ctx context.Context, userID, orgID uuid.UUI
 		return fmt.Errorf("usuário não tem permissão para deletar esta organização")
 	}
 
-	res, err := s.repository.Delete(ctx, orgID)
+	before, err := s.GetByID(ctx, orgID)
 	if err != nil {
 		return err
 	}
 
-	if !res.Success {
-		return fmt.Errorf("%s", res.Message)
-	}
+	return s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.repository.Delete(ctx, orgID)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
 
-	return nil
+		return recordAudit(ctx, s.auditRepository, orgID, userID, domain.AuditOrganizationDelete, "organization", orgID.String(), before, nil)
+	})
 }
 
 func (s *OrganizationService) IsUserInOrganization(ctx context.Context, userID, organizationID uuid.UUID) (bool, error) {
@@ -193,6 +224,25 @@ func (s *OrganizationService) IsUserAdmin(ctx context.Context, userID, organizat
 	return isAdmin, nil
 }
 
+// GetUserRole returns the role of the user within the organization
+func (s *OrganizationService) GetUserRole(ctx context.Context, userID, organizationID uuid.UUID) (domain.Role, error) {
+	res, err := s.repository.GetUserRole(ctx, userID, organizationID)
+	if err != nil {
+		return "", err
+	}
+
+	if !res.Success {
+		return "", fmt.Errorf("%s", res.Message)
+	}
+
+	role, ok := res.Data.(domain.Role)
+	if !ok {
+		return "", fmt.Errorf("erro ao converter dados")
+	}
+
+	return role, nil
+}
+
 func (s *OrganizationService) AddUserByEmail(ctx context.Context, requestingUserID, orgID uuid.UUID, addUser domain.AddUserToOrganization) error {
 	validate := validator.New()
 	err := validate.Struct(addUser)
@@ -254,21 +304,56 @@ func (s *OrganizationService) AddUserByEmail(ctx context.Context, requestingUser
 	}
 
 	// Add user to organization
-	res, err := s.repository.AddUserToOrganization(ctx, user.ID, orgID, addUser.Role)
+	return s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.repository.AddUserToOrganization(ctx, user.ID, orgID, addUser.Role)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		after := domain.OrganizationUser{UserID: user.ID, Name: user.Name, Email: user.Email, Role: domain.Role(addUser.Role)}
+		if err := recordAudit(ctx, s.auditRepository, orgID, requestingUserID, domain.AuditMemberAdd, "user", user.ID.String(), nil, after); err != nil {
+			return err
+		}
+
+		return enqueueWebhook(ctx, s.webhookRepository, orgID, domain.WebhookMemberAdded, after)
+	})
+}
+
+// GetMembers retrieves all members of an organization
+func (s *OrganizationService) GetMembers(ctx context.Context, organizationID uuid.UUID) (*[]domain.OrganizationUser, error) {
+	page, err := s.membersPage(ctx, organizationID, domain.PageRequest{})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if !res.Success {
-		return fmt.Errorf("%s", res.Message)
+	return &page.Items, nil
+}
+
+// ListMembers retrieves a page of the organization members. Only members can list them.
+func (s *OrganizationService) ListMembers(ctx context.Context, requestingUserID, organizationID uuid.UUID, page domain.PageRequest) (*domain.CursorPage[domain.OrganizationUser], error) {
+	memberRes, err := s.repository.IsUserInOrganization(ctx, requestingUserID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !memberRes.Success {
+		return nil, fmt.Errorf("%s", memberRes.Message)
 	}
 
-	return nil
+	isMember, ok := memberRes.Data.(bool)
+	if !ok || !isMember {
+		return nil, fmt.Errorf("usuário não é membro desta organização")
+	}
+
+	return s.membersPage(ctx, organizationID, page)
 }
 
-// GetMembers retrieves all members of an organization
-func (s *OrganizationService) GetMembers(ctx context.Context, organizationID uuid.UUID) (*[]domain.OrganizationUser, error) {
-	res, err := s.repository.GetOrganizationMembers(ctx, organizationID)
+func (s *OrganizationService) membersPage(ctx context.Context, organizationID uuid.UUID, page domain.PageRequest) (*domain.CursorPage[domain.OrganizationUser], error) {
+	res, err := s.repository.GetOrganizationMembers(ctx, organizationID, page)
 	if err != nil {
 		return nil, err
 	}
@@ -277,7 +362,7 @@ func (s *OrganizationService) GetMembers(ctx context.Context, organizationID uui
 		return nil, fmt.Errorf("%s", res.Message)
 	}
 
-	members, ok := res.Data.([]domain.OrganizationUser)
+	members, ok := res.Data.(domain.CursorPage[domain.OrganizationUser])
 	if !ok {
 		return nil, fmt.Errorf("erro ao converter dados dos membros")
 	}
@@ -310,17 +395,26 @@ func (s *OrganizationService) RemoveUserFromOrganization(ctx context.Context, re
 		return fmt.Errorf("você não pode remover a si mesmo da organização")
 	}
 
-	// Remove user
-	res, err := s.repository.RemoveUserFromOrganization(ctx, organizationID, targetUserID)
-	if err != nil {
-		return err
-	}
-
-	if !res.Success {
-		return fmt.Errorf("%s", res.Message)
-	}
+	// Snapshot the membership being removed
+	before := s.memberSnapshot(ctx, organizationID, targetUserID)
 
-	return nil
+	// Remove user
+	return s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.repository.RemoveUserFromOrganization(ctx, organizationID, targetUserID)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		if err := recordAudit(ctx, s.auditRepository, organizationID, requestorID, domain.AuditMemberRemove, "user", targetUserID.String(), before, nil); err != nil {
+			return err
+		}
+
+		return enqueueWebhook(ctx, s.webhookRepository, organizationID, domain.WebhookMemberRemoved, before)
+	})
 }
 
 func (s *OrganizationService) LeaveOrganization(ctx context.Context, userID, organizationID uuid.UUID) error {
@@ -343,15 +437,55 @@ func (s *OrganizationService) LeaveOrganization(ctx context.Context, userID, org
 		return fmt.Errorf("você não é membro desta organização")
 	}
 
+	before := s.memberSnapshot(ctx, organizationID, userID)
+
 	// Remove user
-	res, err := s.repository.RemoveUserFromOrganization(ctx, organizationID, userID)
+	return s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.repository.RemoveUserFromOrganization(ctx, organizationID, userID)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		return enqueueWebhook(ctx, s.webhookRepository, organizationID, domain.WebhookMemberRemoved, before)
+	})
+}
+
+// memberSnapshot describes the membership of userID, used before it is removed
+func (s *OrganizationService) memberSnapshot(ctx context.Context, orgID, userID uuid.UUID) domain.OrganizationUser {
+	member := domain.OrganizationUser{UserID: userID}
+	if userRes, err := s.userRepository.GetByID(ctx, userID.String()); err == nil && userRes.Success {
+		if user, ok := userRes.Data.(domain.User); ok {
+			member.Name = user.Name
+			member.Email = user.Email
+		}
+	}
+	if roleRes, err := s.repository.GetUserRole(ctx, userID, orgID); err == nil && roleRes.Success {
+		if role, ok := roleRes.Data.(domain.Role); ok {
+			member.Role = role
+		}
+	}
+	return member
+}
+
+// organizationLocation returns the zone the organization works in
+func organizationLocation(ctx context.Context, orgRepo *repository.OrganizationRepository, orgID uuid.UUID) (*time.Location, error) {
+	orgRes, err := orgRepo.GetByID(ctx, orgID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if !res.Success {
-		return fmt.Errorf("%s", res.Message)
+	if !orgRes.Success {
+		return nil, fmt.Errorf("%s", orgRes.Message)
+	}
+
+	org, ok := orgRes.Data.(domain.Organization)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados da organização")
 	}
 
-	return nil
+	return org.Location(), nil
 }
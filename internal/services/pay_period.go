@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+)
+
+const payPeriodAdminMessage = "apenas administradores podem fechar ou reabrir períodos de folha"
+
+// PayPeriods lists the closed and reopened months of the organization,
+// newest first. Requesting user must be admin.
+func (s *PayrollService) PayPeriods(ctx context.Context, requestingUserID, orgID uuid.UUID) ([]domain.PayPeriod, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, payPeriodAdminMessage); err != nil {
+		return nil, err
+	}
+
+	res, err := s.payrollRepo.ListPeriods(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	periods, ok := res.Data.([]domain.PayPeriod)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter períodos de folha")
+	}
+
+	return periods, nil
+}
+
+// PayPeriod retrieves a month of the organization with the totals kept when
+// it was last closed. Returns nil when the month was never closed.
+// Requesting user must be admin.
+func (s *PayrollService) PayPeriod(ctx context.Context, requestingUserID, orgID uuid.UUID, month time.Time) (*domain.PayPeriod, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, payPeriodAdminMessage); err != nil {
+		return nil, err
+	}
+
+	return s.payPeriod(ctx, orgID, month)
+}
+
+// ClosePayPeriod closes an ended month of the organization and keeps each
+// member totals. From then on the timesheets of its days can not change.
+// Requesting user must be admin.
+func (s *PayrollService) ClosePayPeriod(ctx context.Context, requestingUserID, orgID uuid.UUID, month time.Time) (*domain.PayPeriod, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, payPeriodAdminMessage); err != nil {
+		return nil, err
+	}
+
+	month = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if month.AddDate(0, 1, 0).After(time.Now()) {
+		return nil, fmt.Errorf("o período só pode ser fechado após o fim do mês")
+	}
+
+	var period domain.PayPeriod
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.payrollRepo.ClosePeriod(ctx, orgID, month, requestingUserID)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		var ok bool
+		period, ok = res.Data.(domain.PayPeriod)
+		if !ok {
+			return fmt.Errorf("erro ao converter período de folha")
+		}
+
+		// Read under the pay period lock taken by ClosePeriod, in the same
+		// transaction, so no punch lands after the totals
+		totals, err := s.memberTotals(ctx, orgID, period.Month, period.End())
+		if err != nil {
+			return err
+		}
+
+		totalsRes, err := s.payrollRepo.SavePeriodTotals(ctx, period.ID, totals)
+		if err != nil {
+			return err
+		}
+
+		if !totalsRes.Success {
+			return fmt.Errorf("%s", totalsRes.Message)
+		}
+
+		if err := recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditPayPeriodClose, "pay_period", period.ID.String(), nil, period); err != nil {
+			return err
+		}
+
+		period.Totals = totals
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &period, nil
+}
+
+// ReopenPayPeriod reopens a closed month of the organization, recording the
+// reason, so its timesheets can change again. The totals kept stay until the
+// month is closed again. Requesting user must be admin.
+func (s *PayrollService) ReopenPayPeriod(ctx context.Context, requestingUserID, orgID uuid.UUID, month time.Time, rp domain.ReopenPayPeriod) (*domain.PayPeriod, error) {
+	rp.Reason = strings.TrimSpace(rp.Reason)
+
+	validate := validator.New()
+	if err := validate.Struct(rp); err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, payPeriodAdminMessage); err != nil {
+		return nil, err
+	}
+
+	month = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	var period domain.PayPeriod
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		before, err := s.payPeriod(ctx, orgID, month)
+		if err != nil {
+			return err
+		}
+
+		if before == nil {
+			return fmt.Errorf("período de folha não encontrado")
+		}
+		before.Totals = nil
+
+		res, err := s.payrollRepo.ReopenPeriod(ctx, orgID, month, requestingUserID, rp.Reason)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		var ok bool
+		period, ok = res.Data.(domain.PayPeriod)
+		if !ok {
+			return fmt.Errorf("erro ao converter período de folha")
+		}
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditPayPeriodReopen, "pay_period", period.ID.String(), before, period)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &period, nil
+}
+
+func (s *PayrollService) payPeriod(ctx context.Context, orgID uuid.UUID, month time.Time) (*domain.PayPeriod, error) {
+	res, err := s.payrollRepo.GetPeriod(ctx, orgID, month)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	period, ok := res.Data.(*domain.PayPeriod)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter período de folha")
+	}
+
+	return period, nil
+}
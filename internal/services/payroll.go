@@ -0,0 +1,300 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	"github.com/marcelorc13/timesheet-pro/internal/export"
+	"github.com/marcelorc13/timesheet-pro/internal/repository"
+)
+
+// PayrollAdapter writes payroll events in the layout a payroll system imports
+type PayrollAdapter interface {
+	ContentType() string
+	FileExtension() string
+	Write(w io.Writer, events []domain.PayrollEvent) error
+}
+
+// payrollAdapters builds the adapter of each payroll format from the organization settings
+var payrollAdapters = map[string]func(domain.PayrollSettings) PayrollAdapter{
+	"csv": func(s domain.PayrollSettings) PayrollAdapter {
+		return export.NewPayrollCSV(s.CSVColumns, s.CSVDelimiter)
+	},
+	"fixed": func(domain.PayrollSettings) PayrollAdapter {
+		return export.PayrollFixedWidth{}
+	},
+}
+
+// RegisterPayrollAdapter makes a payroll format available to the organizations.
+// Call it during startup, before serving requests.
+func RegisterPayrollAdapter(format string, build func(domain.PayrollSettings) PayrollAdapter) {
+	payrollAdapters[format] = build
+}
+
+type PayrollService struct {
+	timesheetRepo *repository.TimesheetRepository
+	orgRepo       *repository.OrganizationRepository
+	scheduleRepo  *repository.ScheduleRepository
+	payrollRepo   *repository.PayrollRepository
+	auditRepo     *repository.AuditRepository
+	txManager     *repository.TxManager
+}
+
+func NewPayrollService(timesheetRepo *repository.TimesheetRepository, orgRepo *repository.OrganizationRepository, scheduleRepo *repository.ScheduleRepository, payrollRepo *repository.PayrollRepository, auditRepo *repository.AuditRepository, txManager *repository.TxManager) *PayrollService {
+	return &PayrollService{
+		timesheetRepo: timesheetRepo,
+		orgRepo:       orgRepo,
+		scheduleRepo:  scheduleRepo,
+		payrollRepo:   payrollRepo,
+		auditRepo:     auditRepo,
+		txManager:     txManager,
+	}
+}
+
+// Settings retrieves the organization payroll settings, or the defaults when
+// they were never configured. Requesting user must be admin.
+func (s *PayrollService) Settings(ctx context.Context, requestingUserID, orgID uuid.UUID) (*domain.PayrollSettings, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem configurar a folha de pagamento"); err != nil {
+		return nil, err
+	}
+
+	return s.settings(ctx, orgID)
+}
+
+// UpdateSettings replaces the organization payroll settings. Requesting user must be admin.
+func (s *PayrollService) UpdateSettings(ctx context.Context, requestingUserID, orgID uuid.UUID, us domain.UpdatePayrollSettings) (*domain.PayrollSettings, error) {
+	validate := validator.New()
+	if err := validate.Struct(us); err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	if _, ok := payrollAdapters[us.Format]; !ok {
+		return nil, fmt.Errorf("formato de folha de pagamento inválido: %q", us.Format)
+	}
+
+	columns, err := us.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem configurar a folha de pagamento"); err != nil {
+		return nil, err
+	}
+
+	before, err := s.settings(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := domain.PayrollSettings{
+		OrganizationID: orgID,
+		Format:         us.Format,
+		CompanyCode:    strings.TrimSpace(us.CompanyCode),
+		RegularCode:    strings.TrimSpace(us.RegularCode),
+		OvertimeCode:   strings.TrimSpace(us.OvertimeCode),
+		NightCode:      strings.TrimSpace(us.NightCode),
+		AbsenceCode:    strings.TrimSpace(us.AbsenceCode),
+		CSVColumns:     columns,
+		CSVDelimiter:   us.CSVDelimiter,
+	}
+	if settings.CSVDelimiter == "" {
+		settings.CSVDelimiter = ";"
+	}
+
+	var saved domain.PayrollSettings
+	err = s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.payrollRepo.SaveSettings(ctx, settings)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		var ok bool
+		saved, ok = res.Data.(domain.PayrollSettings)
+		if !ok {
+			return fmt.Errorf("erro ao converter configuração da folha de pagamento")
+		}
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditPayrollUpdate, "organization", orgID.String(), before, saved)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// Export computes each member totals between start and end (inclusive) and
+// writes them as payroll events in the organization format. Returns the file,
+// its name and content type. Requesting user must be admin.
+func (s *PayrollService) Export(ctx context.Context, requestingUserID, orgID uuid.UUID, start, end time.Time) ([]byte, string, string, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem exportar dados da organização"); err != nil {
+		return nil, "", "", err
+	}
+
+	if end.Before(start) {
+		return nil, "", "", fmt.Errorf("data final deve ser posterior à data inicial")
+	}
+
+	settings, err := s.settings(ctx, orgID)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	if settings.RegularCode == "" && settings.OvertimeCode == "" && settings.NightCode == "" && settings.AbsenceCode == "" {
+		return nil, "", "", fmt.Errorf("configure os códigos de eventos da folha de pagamento antes de exportar")
+	}
+
+	build, ok := payrollAdapters[settings.Format]
+	if !ok {
+		return nil, "", "", fmt.Errorf("formato de folha de pagamento inválido: %q", settings.Format)
+	}
+	adapter := build(*settings)
+
+	totals, err := s.totals(ctx, orgID, start, end)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	var buf bytes.Buffer
+	if err := adapter.Write(&buf, domain.PayrollEvents(*settings, totals, start, end)); err != nil {
+		return nil, "", "", fmt.Errorf("erro ao gerar arquivo da folha de pagamento")
+	}
+
+	filename := fmt.Sprintf("folha-%s-%s.%s", start.Format("20060102"), end.Format("20060102"), adapter.FileExtension())
+	return buf.Bytes(), filename, adapter.ContentType(), nil
+}
+
+// totals computes the payroll totals of every member for the export, refusing
+// members with hours and no CPF
+func (s *PayrollService) totals(ctx context.Context, orgID uuid.UUID, start, end time.Time) ([]domain.PayrollTotals, error) {
+	totals, err := s.memberTotals(ctx, orgID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := []string{}
+	for _, t := range totals {
+		if t.CPF == "" && t.RegularMinutes+t.OvertimeMinutes+t.NightMinutes+t.AbsenceDays > 0 {
+			missing = append(missing, t.Name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("funcionários sem CPF cadastrado: %s", strings.Join(missing, ", "))
+	}
+
+	return totals, nil
+}
+
+// memberTotals computes the payroll totals of every member, using the
+// contracted daily minutes to split regular hours and overtime
+func (s *PayrollService) memberTotals(ctx context.Context, orgID uuid.UUID, start, end time.Time) ([]domain.PayrollTotals, error) {
+	schedulesRes, err := s.scheduleRepo.ListByOrganization(ctx, orgID, domain.PageRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	if !schedulesRes.Success {
+		return nil, fmt.Errorf("%s", schedulesRes.Message)
+	}
+
+	schedulesPage, ok := schedulesRes.Data.(domain.CursorPage[domain.WorkSchedule])
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter horários contratuais")
+	}
+	schedules := schedulesPage.Items
+
+	membersRes, err := s.scheduleRepo.ListMemberSchedules(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !membersRes.Success {
+		return nil, fmt.Errorf("%s", membersRes.Message)
+	}
+
+	members, ok := membersRes.Data.([]domain.MemberSchedule)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter membros")
+	}
+
+	timesheetsRes, err := s.timesheetRepo.GetOrganizationTimesheetsForPeriod(ctx, orgID, start, end, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if !timesheetsRes.Success {
+		return nil, fmt.Errorf("%s", timesheetsRes.Message)
+	}
+
+	timesheets, ok := timesheetsRes.Data.([]domain.UserTimesheet)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter timesheets")
+	}
+
+	loc, err := organizationLocation(ctx, s.orgRepo, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	dailyMinutes := map[uuid.UUID]int64{}
+	for _, sc := range schedules {
+		dailyMinutes[sc.ID] = int64(sc.DailyMinutes)
+	}
+
+	totals := make([]domain.PayrollTotals, len(members))
+	byUser := map[uuid.UUID]int{}
+	memberDaily := make([]int64, len(members))
+	for i, m := range members {
+		totals[i] = domain.PayrollTotals{UserID: m.UserID, Name: m.Name, CPF: m.CPF}
+		byUser[m.UserID] = i
+		memberDaily[i] = domain.DefaultDailyMinutes
+		if m.ScheduleID != nil {
+			memberDaily[i] = dailyMinutes[*m.ScheduleID]
+		}
+	}
+
+	for _, ts := range timesheets {
+		i, ok := byUser[ts.UserID]
+		if !ok {
+			continue
+		}
+		totals[i].Add(ts.DailyTimesheet, memberDaily[i], loc)
+	}
+
+	return totals, nil
+}
+
+func (s *PayrollService) settings(ctx context.Context, orgID uuid.UUID) (*domain.PayrollSettings, error) {
+	res, err := s.payrollRepo.GetSettings(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	settings, ok := res.Data.(*domain.PayrollSettings)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter configuração da folha de pagamento")
+	}
+
+	if settings == nil {
+		defaults := domain.DefaultPayrollSettings(orgID)
+		return &defaults, nil
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/repository"
+)
+
+// requireOrgAdmin returns deniedMessage as the error when userID is not an
+// admin of the organization
+func requireOrgAdmin(ctx context.Context, orgRepo *repository.OrganizationRepository, userID, orgID uuid.UUID, deniedMessage string) error {
+	adminRes, err := orgRepo.IsUserAdmin(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+
+	if !adminRes.Success {
+		return fmt.Errorf("%s", adminRes.Message)
+	}
+
+	isAdmin, ok := adminRes.Data.(bool)
+	if !ok || !isAdmin {
+		return fmt.Errorf("%s", deniedMessage)
+	}
+
+	return nil
+}
+
+// requireOrgMember returns an error when userID is not a member of the organization
+func requireOrgMember(ctx context.Context, orgRepo *repository.OrganizationRepository, userID, orgID uuid.UUID) error {
+	memberRes, err := orgRepo.IsUserInOrganization(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+
+	if !memberRes.Success {
+		return fmt.Errorf("%s", memberRes.Message)
+	}
+
+	isMember, ok := memberRes.Data.(bool)
+	if !ok || !isMember {
+		return fmt.Errorf("usuário não é membro desta organização")
+	}
+
+	return nil
+}
@@ -0,0 +1,449 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	"github.com/marcelorc13/timesheet-pro/internal/repository"
+)
+
+type ProjectService struct {
+	projectRepo   *repository.ProjectRepository
+	timesheetRepo *repository.TimesheetRepository
+	orgRepo       *repository.OrganizationRepository
+	auditRepo     *repository.AuditRepository
+	txManager     *repository.TxManager
+}
+
+func NewProjectService(projectRepo *repository.ProjectRepository, timesheetRepo *repository.TimesheetRepository, orgRepo *repository.OrganizationRepository, auditRepo *repository.AuditRepository, txManager *repository.TxManager) *ProjectService {
+	return &ProjectService{
+		projectRepo:   projectRepo,
+		timesheetRepo: timesheetRepo,
+		orgRepo:       orgRepo,
+		auditRepo:     auditRepo,
+		txManager:     txManager,
+	}
+}
+
+// CreateProject adds a project to the organization. Requesting user must be admin.
+func (s *ProjectService) CreateProject(ctx context.Context, requestingUserID, orgID uuid.UUID, cp domain.CreateProject) (*domain.Project, error) {
+	validate := validator.New()
+	if err := validate.Struct(cp); err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem gerenciar projetos"); err != nil {
+		return nil, err
+	}
+
+	project := domain.Project{
+		OrganizationID: orgID,
+		Name:           strings.TrimSpace(cp.Name),
+		Billable:       cp.Billable,
+		HourlyRate:     domain.CentsFromReais(cp.HourlyRate),
+	}
+	if cp.ClientID != "" {
+		clientID := uuid.MustParse(cp.ClientID)
+		project.ClientID = &clientID
+	}
+
+	var saved domain.Project
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.projectRepo.CreateProject(ctx, project)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		var ok bool
+		saved, ok = res.Data.(domain.Project)
+		if !ok {
+			return fmt.Errorf("erro ao converter dados do projeto")
+		}
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditProjectCreate, "project", saved.ID.String(), nil, saved)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// UpdateProject changes a project of the organization; archived projects no
+// longer take allocations. Nil fields are kept. Requesting user must be admin.
+func (s *ProjectService) UpdateProject(ctx context.Context, requestingUserID, orgID, projectID uuid.UUID, up domain.UpdateProject) (*domain.Project, error) {
+	validate := validator.New()
+	if err := validate.Struct(up); err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem gerenciar projetos"); err != nil {
+		return nil, err
+	}
+
+	var clientID *uuid.UUID
+	if up.ClientID != nil && *up.ClientID != "" {
+		id, err := uuid.Parse(*up.ClientID)
+		if err != nil {
+			return nil, fmt.Errorf("ID do cliente inválido")
+		}
+		clientID = &id
+	}
+
+	var saved domain.Project
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		before, err := s.project(ctx, orgID, projectID)
+		if err != nil {
+			return err
+		}
+
+		project := *before
+		if up.Name != nil {
+			project.Name = strings.TrimSpace(*up.Name)
+		}
+		if up.ClientID != nil {
+			project.ClientID = clientID
+		}
+		if up.Billable != nil {
+			project.Billable = *up.Billable
+		}
+		if up.HourlyRate != nil {
+			project.HourlyRate = domain.CentsFromReais(*up.HourlyRate)
+		}
+		if up.Archived != nil {
+			project.Archived = *up.Archived
+		}
+
+		res, err := s.projectRepo.UpdateProject(ctx, project)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		var ok bool
+		saved, ok = res.Data.(domain.Project)
+		if !ok {
+			return fmt.Errorf("erro ao converter dados do projeto")
+		}
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditProjectUpdate, "project", projectID.String(), before, saved)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// Projects lists the organization projects with their tasks. Any member sees
+// the active projects to allocate time to; archived ones are only listed for
+// admins, when includeArchived is set.
+func (s *ProjectService) Projects(ctx context.Context, requestingUserID, orgID uuid.UUID, includeArchived bool) ([]domain.Project, error) {
+	if includeArchived {
+		if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem gerenciar projetos"); err != nil {
+			return nil, err
+		}
+	} else if err := requireOrgMember(ctx, s.orgRepo, requestingUserID, orgID); err != nil {
+		return nil, err
+	}
+
+	res, err := s.projectRepo.ListProjects(ctx, orgID, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	projects, ok := res.Data.([]domain.Project)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados dos projetos")
+	}
+
+	return projects, nil
+}
+
+// CreateTask adds a task to a project of the organization. Requesting user must be admin.
+func (s *ProjectService) CreateTask(ctx context.Context, requestingUserID, orgID, projectID uuid.UUID, ct domain.CreateProjectTask) (*domain.ProjectTask, error) {
+	validate := validator.New()
+	if err := validate.Struct(ct); err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem gerenciar projetos"); err != nil {
+		return nil, err
+	}
+
+	var task domain.ProjectTask
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := s.project(ctx, orgID, projectID); err != nil {
+			return err
+		}
+
+		res, err := s.projectRepo.CreateTask(ctx, projectID, strings.TrimSpace(ct.Name))
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		var ok bool
+		task, ok = res.Data.(domain.ProjectTask)
+		if !ok {
+			return fmt.Errorf("erro ao converter dados da tarefa")
+		}
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditProjectTaskCreate, "project_task", task.ID.String(), nil, task)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+// DeleteTask removes a task of a project. Its allocations stay on the project.
+// Requesting user must be admin.
+func (s *ProjectService) DeleteTask(ctx context.Context, requestingUserID, orgID, projectID, taskID uuid.UUID) error {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem gerenciar projetos"); err != nil {
+		return err
+	}
+
+	return s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := s.project(ctx, orgID, projectID); err != nil {
+			return err
+		}
+
+		res, err := s.projectRepo.DeleteTask(ctx, projectID, taskID)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditProjectTaskDelete, "project_task", taskID.String(), res.Data, nil)
+	})
+}
+
+// CreateAllocation allocates part of the member workday to a project, either
+// tagging a worked interval or as minutes of the day. The allocations of a
+// day may not exceed its worked time.
+func (s *ProjectService) CreateAllocation(ctx context.Context, userID, orgID uuid.UUID, ca domain.CreateTimeAllocation) (*domain.TimeAllocation, error) {
+	validate := validator.New()
+	if err := validate.Struct(ca); err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	allocation, err := ca.Allocation(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireOrgMember(ctx, s.orgRepo, userID, orgID); err != nil {
+		return nil, err
+	}
+
+	project, err := s.project(ctx, orgID, allocation.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if project.Archived {
+		return nil, fmt.Errorf("projeto arquivado não recebe apontamentos")
+	}
+
+	allocation.ProjectName = project.Name
+	allocation.Billable = project.Billable
+	if ca.Billable != nil {
+		allocation.Billable = *ca.Billable
+	}
+	if allocation.TaskID != nil {
+		task := project.Task(*allocation.TaskID)
+		if task == nil {
+			return nil, fmt.Errorf("tarefa não encontrada")
+		}
+		allocation.TaskName = task.Name
+	}
+
+	timesheet, err := s.timesheet(ctx, userID, orgID, allocation.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	if timesheet == nil {
+		return nil, fmt.Errorf("não há horas trabalhadas registradas neste dia")
+	}
+	allocation.TimesheetID = timesheet.ID
+
+	var saved domain.TimeAllocation
+	err = s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.projectRepo.LockTimesheet(ctx, timesheet.ID); err != nil {
+			return err
+		}
+
+		existing, err := s.allocations(ctx, timesheet.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := timesheet.CheckAllocation(allocation, existing); err != nil {
+			return err
+		}
+
+		res, err := s.projectRepo.CreateAllocation(ctx, allocation)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		var ok bool
+		saved, ok = res.Data.(domain.TimeAllocation)
+		if !ok {
+			return fmt.Errorf("erro ao converter apontamento de horas")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// Allocations lists the member allocations of the day
+func (s *ProjectService) Allocations(ctx context.Context, userID, orgID uuid.UUID, date time.Time) ([]domain.TimeAllocation, error) {
+	if err := requireOrgMember(ctx, s.orgRepo, userID, orgID); err != nil {
+		return nil, err
+	}
+
+	timesheet, err := s.timesheet(ctx, userID, orgID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	if timesheet == nil {
+		return []domain.TimeAllocation{}, nil
+	}
+
+	return s.allocations(ctx, timesheet.ID)
+}
+
+// DeleteAllocation removes an allocation of the member
+func (s *ProjectService) DeleteAllocation(ctx context.Context, userID, orgID, allocationID uuid.UUID) error {
+	res, err := s.projectRepo.DeleteAllocation(ctx, orgID, userID, allocationID)
+	if err != nil {
+		return err
+	}
+
+	if !res.Success {
+		return fmt.Errorf("%s", res.Message)
+	}
+
+	return nil
+}
+
+// Report sums the allocated hours between start and end (inclusive) by
+// project and member, with the billable amount of each. Requesting user must
+// be admin.
+func (s *ProjectService) Report(ctx context.Context, requestingUserID, orgID uuid.UUID, start, end time.Time, projectID *uuid.UUID) (*domain.ProjectReport, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem gerenciar projetos"); err != nil {
+		return nil, err
+	}
+
+	if end.Before(start) {
+		return nil, fmt.Errorf("data final deve ser posterior à data inicial")
+	}
+
+	res, err := s.projectRepo.Report(ctx, orgID, start, end, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	rows, ok := res.Data.([]domain.ProjectHours)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter horas por projeto")
+	}
+
+	return &domain.ProjectReport{Start: start, End: end, Rows: rows}, nil
+}
+
+func (s *ProjectService) project(ctx context.Context, orgID, projectID uuid.UUID) (*domain.Project, error) {
+	res, err := s.projectRepo.GetProject(ctx, orgID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	project, ok := res.Data.(domain.Project)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados do projeto")
+	}
+
+	return &project, nil
+}
+
+// timesheet retrieves the member timesheet of the day, or nil when the member
+// did not punch on it
+func (s *ProjectService) timesheet(ctx context.Context, userID, orgID uuid.UUID, date time.Time) (*domain.UserTimesheet, error) {
+	res, err := s.timesheetRepo.GetUserTimesheet(ctx, userID, orgID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, nil
+	}
+
+	timesheet, ok := res.Data.(domain.UserTimesheet)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados do timesheet")
+	}
+
+	return &timesheet, nil
+}
+
+func (s *ProjectService) allocations(ctx context.Context, timesheetID uuid.UUID) ([]domain.TimeAllocation, error) {
+	res, err := s.projectRepo.ListAllocations(ctx, timesheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	allocations, ok := res.Data.([]domain.TimeAllocation)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter apontamentos de horas")
+	}
+
+	return allocations, nil
+}
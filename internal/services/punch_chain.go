@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	"github.com/marcelorc13/timesheet-pro/internal/repository"
+)
+
+type PunchChainService struct {
+	timesheetRepo *repository.TimesheetRepository
+	keyRepo       *repository.SigningKeyRepository
+	orgRepo       *repository.OrganizationRepository
+}
+
+func NewPunchChainService(timesheetRepo *repository.TimesheetRepository, keyRepo *repository.SigningKeyRepository, orgRepo *repository.OrganizationRepository) *PunchChainService {
+	return &PunchChainService{
+		timesheetRepo: timesheetRepo,
+		keyRepo:       keyRepo,
+		orgRepo:       orgRepo,
+	}
+}
+
+// Verify walks the organization hash chain and reports every break.
+// Requesting user must be admin.
+func (s *PunchChainService) Verify(ctx context.Context, requestingUserID, orgID uuid.UUID) (*domain.ChainReport, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem verificar a integridade dos registros"); err != nil {
+		return nil, err
+	}
+
+	return s.VerifyOrganization(ctx, orgID)
+}
+
+// VerifyOrganization walks the organization hash chain without permission checks
+// and checks it ends at the stored head. It backs the verification CLI, which
+// runs with database access.
+func (s *PunchChainService) VerifyOrganization(ctx context.Context, orgID uuid.UUID) (*domain.ChainReport, error) {
+	chain, err := s.chain(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	countRes, err := s.timesheetRepo.CountUnchainedEntries(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !countRes.Success {
+		return nil, fmt.Errorf("%s", countRes.Message)
+	}
+
+	unchained, _ := countRes.Data.(int)
+
+	breaks, head := domain.VerifyChain(chain.Records)
+	breaks = append(breaks, domain.VerifyChainHead(chain.Records, chain.Head)...)
+	return &domain.ChainReport{
+		OrganizationID: orgID,
+		Records:        len(chain.Records),
+		Unchained:      unchained,
+		HeadHash:       head,
+		StoredHead:     chain.Head,
+		Valid:          len(breaks) == 0,
+		Breaks:         breaks,
+		VerifiedAt:     time.Now(),
+	}, nil
+}
+
+// Export returns the organization chain signed with its key, so a third party
+// can verify it offline. Requesting user must be admin.
+func (s *PunchChainService) Export(ctx context.Context, requestingUserID, orgID uuid.UUID) (*domain.SignedPunchChainExport, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem verificar a integridade dos registros"); err != nil {
+		return nil, err
+	}
+
+	orgRes, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !orgRes.Success {
+		return nil, fmt.Errorf("%s", orgRes.Message)
+	}
+
+	org, ok := orgRes.Data.(domain.Organization)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados da organização")
+	}
+
+	chain, err := s.chain(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.signingKey(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, head := domain.VerifyChain(chain.Records)
+	export := domain.PunchChainExport{
+		OrganizationID:   orgID,
+		OrganizationName: org.Name,
+		Algorithm:        key.Algorithm,
+		PublicKey:        base64.StdEncoding.EncodeToString(key.PublicKey),
+		GeneratedAt:      time.Now().UTC(),
+		HeadHash:         head,
+		Records:          chain.Records,
+	}
+
+	signed, err := domain.SignPunchChainExport(export, key.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao assinar exportação")
+	}
+
+	return &signed, nil
+}
+
+// PublicKey returns the organization public key, creating the key pair on first use.
+// It is public so third parties can pin it before receiving exports.
+func (s *PunchChainService) PublicKey(ctx context.Context, orgID uuid.UUID) (*domain.SigningKey, error) {
+	key, err := s.signingKey(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	key.PrivateKey = nil
+	return key, nil
+}
+
+func (s *PunchChainService) chain(ctx context.Context, orgID uuid.UUID) (*domain.PunchChain, error) {
+	res, err := s.timesheetRepo.GetPunchChain(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	chain, ok := res.Data.(domain.PunchChain)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados da cadeia")
+	}
+
+	return &chain, nil
+}
+
+func (s *PunchChainService) signingKey(ctx context.Context, orgID uuid.UUID) (*domain.SigningKey, error) {
+	res, err := s.keyRepo.Get(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao gerar chave de assinatura")
+		}
+
+		res, err = s.keyRepo.Create(ctx, domain.SigningKey{
+			OrganizationID: orgID,
+			Algorithm:      "ed25519",
+			PublicKey:      publicKey,
+			PrivateKey:     privateKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if !res.Success {
+			return nil, fmt.Errorf("%s", res.Message)
+		}
+	}
+
+	key, ok := res.Data.(domain.SigningKey)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter chave de assinatura")
+	}
+
+	return &key, nil
+}
@@ -0,0 +1,478 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	"github.com/marcelorc13/timesheet-pro/internal/repository"
+)
+
+type PunchPolicyService struct {
+	policyRepo *repository.PunchPolicyRepository
+	orgRepo    *repository.OrganizationRepository
+	auditRepo  *repository.AuditRepository
+	txManager  *repository.TxManager
+}
+
+func NewPunchPolicyService(policyRepo *repository.PunchPolicyRepository, orgRepo *repository.OrganizationRepository, auditRepo *repository.AuditRepository, txManager *repository.TxManager) *PunchPolicyService {
+	return &PunchPolicyService{
+		policyRepo: policyRepo,
+		orgRepo:    orgRepo,
+		auditRepo:  auditRepo,
+		txManager:  txManager,
+	}
+}
+
+// Policy retrieves the organization punch policy. Requesting user must be admin.
+func (s *PunchPolicyService) Policy(ctx context.Context, requestingUserID, orgID uuid.UUID) (*domain.PunchPolicy, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem configurar o registro de ponto"); err != nil {
+		return nil, err
+	}
+
+	return punchPolicy(ctx, s.policyRepo, orgID)
+}
+
+// UpdatePolicy changes the organization punch policy. Nil fields are kept.
+// Requesting user must be admin.
+func (s *PunchPolicyService) UpdatePolicy(ctx context.Context, requestingUserID, orgID uuid.UUID, up domain.UpdatePunchPolicy) (*domain.PunchPolicy, error) {
+	validate := validator.New()
+	if err := validate.Struct(up); err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem configurar o registro de ponto"); err != nil {
+		return nil, err
+	}
+
+	var saved domain.PunchPolicy
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		before, err := punchPolicy(ctx, s.policyRepo, orgID)
+		if err != nil {
+			return err
+		}
+
+		policy := *before
+		if up.RequireQR != nil {
+			policy.RequireQR = *up.RequireQR
+		}
+		if up.GeofenceMode != nil {
+			policy.GeofenceMode = *up.GeofenceMode
+		}
+		if up.NetworkMode != nil {
+			policy.NetworkMode = *up.NetworkMode
+		}
+
+		saved, err = s.save(ctx, policy)
+		if err != nil {
+			return err
+		}
+		saved.Geofences = before.Geofences
+		saved.Networks = before.Networks
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditPunchPolicyUpdate, "organization", orgID.String(), before, saved)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// CurrentQR returns the code the office display shows now. The QR secret is
+// created on first use, so the display can be set up before the policy is
+// enforced. Requesting user must be admin.
+func (s *PunchPolicyService) CurrentQR(ctx context.Context, requestingUserID, orgID uuid.UUID) (*domain.PunchQR, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem configurar o registro de ponto"); err != nil {
+		return nil, err
+	}
+
+	policy, err := punchPolicy(ctx, s.policyRepo, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy.QRSecret == "" {
+		saved, err := s.save(ctx, *policy)
+		if err != nil {
+			return nil, err
+		}
+		policy = &saved
+	}
+
+	qr := policy.NewPunchQR(time.Now())
+	return &qr, nil
+}
+
+// save stores the policy, creating its QR secret when it has none
+func (s *PunchPolicyService) save(ctx context.Context, policy domain.PunchPolicy) (domain.PunchPolicy, error) {
+	if policy.QRSecret == "" {
+		secret, err := newPunchQRSecret()
+		if err != nil {
+			return domain.PunchPolicy{}, fmt.Errorf("erro ao gerar segredo do QR code")
+		}
+		policy.QRSecret = secret
+	}
+
+	res, err := s.policyRepo.Save(ctx, policy)
+	if err != nil {
+		return domain.PunchPolicy{}, err
+	}
+
+	if !res.Success {
+		return domain.PunchPolicy{}, fmt.Errorf("%s", res.Message)
+	}
+
+	saved, ok := res.Data.(domain.PunchPolicy)
+	if !ok {
+		return domain.PunchPolicy{}, fmt.Errorf("erro ao converter política de registro de ponto")
+	}
+
+	return saved, nil
+}
+
+// CreateGeofence adds an area where members may punch. Requesting user must be admin.
+func (s *PunchPolicyService) CreateGeofence(ctx context.Context, requestingUserID, orgID uuid.UUID, cg domain.CreateGeofence) (*domain.Geofence, error) {
+	validate := validator.New()
+	if err := validate.Struct(cg); err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	fence, err := cg.Geofence(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem configurar o registro de ponto"); err != nil {
+		return nil, err
+	}
+
+	return s.createGeofence(ctx, requestingUserID, fence)
+}
+
+// CreateGeofenceFromAddress adds a circle around the coordinates of the
+// organization address. Requesting user must be admin.
+func (s *PunchPolicyService) CreateGeofenceFromAddress(ctx context.Context, requestingUserID, orgID uuid.UUID, radiusMeters float64) (*domain.Geofence, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem configurar o registro de ponto"); err != nil {
+		return nil, err
+	}
+
+	orgRes, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !orgRes.Success {
+		return nil, fmt.Errorf("%s", orgRes.Message)
+	}
+
+	org, ok := orgRes.Data.(domain.Organization)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados da organização")
+	}
+
+	if org.Address == nil || org.Address.Coordinates() == nil {
+		return nil, fmt.Errorf("informe a latitude e a longitude do endereço da organização")
+	}
+
+	if radiusMeters == 0 {
+		radiusMeters = domain.GeofenceDefaultRadius
+	}
+
+	center := org.Address.Coordinates()
+	fence, err := domain.CreateGeofence{
+		Name:         org.Address.PublicPlace,
+		Kind:         domain.GeofenceCircle,
+		Latitude:     center.Latitude,
+		Longitude:    center.Longitude,
+		RadiusMeters: radiusMeters,
+	}.Geofence(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createGeofence(ctx, requestingUserID, fence)
+}
+
+func (s *PunchPolicyService) createGeofence(ctx context.Context, requestingUserID uuid.UUID, fence domain.Geofence) (*domain.Geofence, error) {
+	var saved domain.Geofence
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.policyRepo.CreateGeofence(ctx, fence)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		var ok bool
+		saved, ok = res.Data.(domain.Geofence)
+		if !ok {
+			return fmt.Errorf("erro ao converter área de registro de ponto")
+		}
+
+		return recordAudit(ctx, s.auditRepo, fence.OrganizationID, requestingUserID, domain.AuditGeofenceCreate, "geofence", saved.ID.String(), nil, saved)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// DeleteGeofence removes an area of the organization. Requesting user must be admin.
+func (s *PunchPolicyService) DeleteGeofence(ctx context.Context, requestingUserID, orgID, geofenceID uuid.UUID) error {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem configurar o registro de ponto"); err != nil {
+		return err
+	}
+
+	return s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.policyRepo.DeleteGeofence(ctx, orgID, geofenceID)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditGeofenceDelete, "geofence", geofenceID.String(), res.Data, nil)
+	})
+}
+
+// CreateNetwork adds an IP range members may punch from. Requesting user must be admin.
+func (s *PunchPolicyService) CreateNetwork(ctx context.Context, requestingUserID, orgID uuid.UUID, cn domain.CreatePunchNetwork) (*domain.PunchNetwork, error) {
+	validate := validator.New()
+	if err := validate.Struct(cn); err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	prefix, err := cn.Prefix()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem configurar o registro de ponto"); err != nil {
+		return nil, err
+	}
+
+	network := domain.PunchNetwork{
+		OrganizationID: orgID,
+		CIDR:           prefix.String(),
+		Description:    strings.TrimSpace(cn.Description),
+	}
+
+	var saved domain.PunchNetwork
+	err = s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.policyRepo.CreateNetwork(ctx, network)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		var ok bool
+		saved, ok = res.Data.(domain.PunchNetwork)
+		if !ok {
+			return fmt.Errorf("erro ao converter rede de registro de ponto")
+		}
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditPunchNetworkCreate, "punch_network", saved.ID.String(), nil, saved)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// DeleteNetwork removes an IP range of the organization. Requesting user must be admin.
+func (s *PunchPolicyService) DeleteNetwork(ctx context.Context, requestingUserID, orgID, networkID uuid.UUID) error {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem configurar o registro de ponto"); err != nil {
+		return err
+	}
+
+	return s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.policyRepo.DeleteNetwork(ctx, orgID, networkID)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditPunchNetworkDelete, "punch_network", networkID.String(), res.Data, nil)
+	})
+}
+
+// FlaggedPunches lists the punches flagged for review, newest first. An empty
+// flag lists punches flagged for any reason. Requesting user must be admin.
+func (s *PunchPolicyService) FlaggedPunches(ctx context.Context, requestingUserID, orgID uuid.UUID, flag domain.PunchFlag, page domain.PageRequest) (*domain.CursorPage[domain.FlaggedPunch], error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem configurar o registro de ponto"); err != nil {
+		return nil, err
+	}
+
+	res, err := s.policyRepo.ListFlaggedPunches(ctx, orgID, flag, page)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	punches, ok := res.Data.(domain.CursorPage[domain.FlaggedPunch])
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter registros para revisão")
+	}
+
+	return &punches, nil
+}
+
+// punchPolicy retrieves the organization punch policy with its geofences and
+// networks, or the unrestricted policy when it was never configured
+func punchPolicy(ctx context.Context, policyRepo *repository.PunchPolicyRepository, orgID uuid.UUID) (*domain.PunchPolicy, error) {
+	res, err := policyRepo.Get(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	policy, ok := res.Data.(*domain.PunchPolicy)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter política de registro de ponto")
+	}
+
+	if policy == nil {
+		policy = &domain.PunchPolicy{OrganizationID: orgID, GeofenceMode: domain.GeofenceRecord, NetworkMode: domain.NetworkFlag}
+	}
+
+	fencesRes, err := policyRepo.ListGeofences(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !fencesRes.Success {
+		return nil, fmt.Errorf("%s", fencesRes.Message)
+	}
+
+	policy.Geofences, ok = fencesRes.Data.([]domain.Geofence)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter áreas de registro de ponto")
+	}
+
+	networksRes, err := policyRepo.ListNetworks(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !networksRes.Success {
+		return nil, fmt.Errorf("%s", networksRes.Message)
+	}
+
+	policy.Networks, ok = networksRes.Data.([]domain.PunchNetwork)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter redes de registro de ponto")
+	}
+
+	return policy, nil
+}
+
+// checkPunchPolicy places a punch from the member session against the
+// organization punch policy. It returns where the punch was made and the
+// warning to show the member, or why the punch is refused.
+func checkPunchPolicy(ctx context.Context, policyRepo *repository.PunchPolicyRepository, orgID uuid.UUID, proof domain.PunchProof, now time.Time) (domain.PunchOrigin, string, error) {
+	policy, err := punchPolicy(ctx, policyRepo, orgID)
+	if err != nil {
+		return domain.PunchOrigin{}, "", err
+	}
+
+	outsideNetwork := policy.RestrictsNetwork() && domain.AllowedNetwork(policy.Networks, domain.RequestMetadataFrom(ctx).IP) == nil
+	if outsideNetwork && policy.NetworkMode == domain.NetworkBlock {
+		return domain.PunchOrigin{}, "", fmt.Errorf("registro de ponto permitido apenas a partir da rede da organização")
+	}
+
+	origin, warning, err := placePunch(policy, proof, now)
+	origin.OutsideNetwork = outsideNetwork
+	return origin, warning, err
+}
+
+// checkOfflinePunchPolicy places a punch from the device queue against the
+// organization punch policy. The QR code is checked at the punch time. The
+// network the punch was made from is unknown, since the queue is sent from
+// wherever the device found a connection: it is flagged, or refused when the
+// policy blocks punches from outside the organization networks.
+func checkOfflinePunchPolicy(policy *domain.PunchPolicy, p domain.OfflinePunch) (domain.PunchOrigin, string, error) {
+	outsideNetwork := policy.RestrictsNetwork()
+	if outsideNetwork && policy.NetworkMode == domain.NetworkBlock {
+		return domain.PunchOrigin{}, "", fmt.Errorf("registro offline não aceito: a organização exige o registro a partir da sua rede")
+	}
+
+	origin, warning, err := placePunch(policy, p.PunchProof, p.CapturedAt)
+	origin.OutsideNetwork = outsideNetwork
+	return origin, warning, err
+}
+
+// placePunch checks the QR code at the punch time and places the reported
+// location against the organization fences
+func placePunch(policy *domain.PunchPolicy, proof domain.PunchProof, at time.Time) (domain.PunchOrigin, string, error) {
+	var origin domain.PunchOrigin
+	var err error
+
+	if policy.RequireQR {
+		if proof.QRCode == "" {
+			return origin, "", fmt.Errorf("escaneie o QR code exibido no local de trabalho para registrar o ponto")
+		}
+		if !policy.VerifyQR(proof.QRCode, at) {
+			return origin, "", fmt.Errorf("QR code inválido ou expirado, escaneie novamente")
+		}
+	}
+
+	origin.Location, err = proof.Location()
+	if err != nil {
+		return origin, "", err
+	}
+
+	if !policy.CollectsLocation() {
+		return origin, "", nil
+	}
+
+	if fence := domain.LocatePunch(policy.Geofences, origin.Location); fence != nil {
+		origin.GeofenceID = &fence.ID
+		return origin, "", nil
+	}
+
+	origin.OutsideGeofence = true
+	switch policy.GeofenceMode {
+	case domain.GeofenceEnforce:
+		if origin.Location == nil {
+			return origin, "", fmt.Errorf("permita o acesso à localização para registrar o ponto")
+		}
+		return origin, "", fmt.Errorf("você está fora das áreas permitidas para registrar o ponto")
+	case domain.GeofenceWarn:
+		return origin, "registro feito fora das áreas permitidas; a administração da organização será informada", nil
+	default:
+		return origin, "", nil
+	}
+}
+
+func newPunchQRSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// windowLimiter allows up to limit events per key in each fixed time window.
+// State is kept in memory, so limits apply per server instance.
+type windowLimiter struct {
+	limit  int
+	window time.Duration
+
+	mu      sync.Mutex
+	windows map[string]*limiterWindow
+}
+
+type limiterWindow struct {
+	start time.Time
+	count int
+}
+
+func newWindowLimiter(limit int, window time.Duration) *windowLimiter {
+	return &windowLimiter{
+		limit:   limit,
+		window:  window,
+		windows: map[string]*limiterWindow{},
+	}
+}
+
+// Allow counts an event for key and reports whether it is within the limit
+func (l *windowLimiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= l.window {
+		l.prune(now)
+		w = &limiterWindow{start: now}
+		l.windows[key] = w
+	}
+
+	w.count++
+	return w.count <= l.limit
+}
+
+// Exceeded reports whether key already used up the current window, without counting an event
+func (l *windowLimiter) Exceeded(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	w, ok := l.windows[key]
+	return ok && time.Since(w.start) < l.window && w.count >= l.limit
+}
+
+// prune drops the expired windows so idle keys do not accumulate
+func (l *windowLimiter) prune(now time.Time) {
+	for key, w := range l.windows {
+		if now.Sub(w.start) >= l.window {
+			delete(l.windows, key)
+		}
+	}
+}
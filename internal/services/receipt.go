@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	"github.com/marcelorc13/timesheet-pro/internal/repository"
+)
+
+type ReceiptService struct {
+	receiptRepo *repository.ReceiptRepository
+	orgRepo     *repository.OrganizationRepository
+}
+
+func NewReceiptService(receiptRepo *repository.ReceiptRepository, orgRepo *repository.OrganizationRepository) *ReceiptService {
+	return &ReceiptService{
+		receiptRepo: receiptRepo,
+		orgRepo:     orgRepo,
+	}
+}
+
+// GetByID retrieves a receipt. Only its owner or an organization admin can see it.
+func (s *ReceiptService) GetByID(ctx context.Context, requestingUserID, receiptID uuid.UUID) (*domain.PunchReceipt, error) {
+	res, err := s.receiptRepo.GetByID(ctx, receiptID)
+	return s.authorize(ctx, requestingUserID, res, err)
+}
+
+// GetByEntryID retrieves the receipt of a timesheet entry. Only its owner or an
+// organization admin can see it.
+func (s *ReceiptService) GetByEntryID(ctx context.Context, requestingUserID, entryID uuid.UUID) (*domain.PunchReceipt, error) {
+	res, err := s.receiptRepo.GetByEntryID(ctx, entryID)
+	return s.authorize(ctx, requestingUserID, res, err)
+}
+
+// ListMine retrieves a page of the requesting user receipts in an organization, newest first
+func (s *ReceiptService) ListMine(ctx context.Context, userID, orgID uuid.UUID, page domain.PageRequest) (*domain.CursorPage[domain.PunchReceipt], error) {
+	memberRes, err := s.orgRepo.IsUserInOrganization(ctx, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !memberRes.Success {
+		return nil, fmt.Errorf("%s", memberRes.Message)
+	}
+
+	isMember, ok := memberRes.Data.(bool)
+	if !ok || !isMember {
+		return nil, fmt.Errorf("usuário não é membro desta organização")
+	}
+
+	res, err := s.receiptRepo.ListByUser(ctx, orgID, userID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	receipts, ok := res.Data.(domain.CursorPage[domain.PunchReceipt])
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados dos comprovantes")
+	}
+
+	return &receipts, nil
+}
+
+func (s *ReceiptService) authorize(ctx context.Context, requestingUserID uuid.UUID, res domain.DBResponse, err error) (*domain.PunchReceipt, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	receipt, ok := res.Data.(domain.PunchReceipt)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados do comprovante")
+	}
+
+	if receipt.UserID == requestingUserID {
+		return &receipt, nil
+	}
+
+	adminRes, err := s.orgRepo.IsUserAdmin(ctx, requestingUserID, receipt.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	isAdmin, _ := adminRes.Data.(bool)
+	if !adminRes.Success || !isAdmin {
+		return nil, fmt.Errorf("você não tem permissão para visualizar este comprovante")
+	}
+
+	return &receipt, nil
+}
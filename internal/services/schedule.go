@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	"github.com/marcelorc13/timesheet-pro/internal/repository"
+)
+
+type ScheduleService struct {
+	scheduleRepo *repository.ScheduleRepository
+	orgRepo      *repository.OrganizationRepository
+}
+
+func NewScheduleService(scheduleRepo *repository.ScheduleRepository, orgRepo *repository.OrganizationRepository) *ScheduleService {
+	return &ScheduleService{
+		scheduleRepo: scheduleRepo,
+		orgRepo:      orgRepo,
+	}
+}
+
+func (s *ScheduleService) Create(ctx context.Context, requestingUserID, orgID uuid.UUID, cs domain.CreateWorkSchedule) (*domain.WorkSchedule, error) {
+	validate := validator.New()
+	err := validate.Struct(cs)
+	if err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	if err := cs.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem gerenciar horários contratuais"); err != nil {
+		return nil, err
+	}
+
+	res, err := s.scheduleRepo.Create(ctx, orgID, cs)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	schedule, ok := res.Data.(domain.WorkSchedule)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados")
+	}
+
+	return &schedule, nil
+}
+
+// List retrieves a page of the organization contracted schedules. Any member can list them.
+func (s *ScheduleService) List(ctx context.Context, requestingUserID, orgID uuid.UUID, page domain.PageRequest) (*domain.CursorPage[domain.WorkSchedule], error) {
+	memberRes, err := s.orgRepo.IsUserInOrganization(ctx, requestingUserID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !memberRes.Success {
+		return nil, fmt.Errorf("%s", memberRes.Message)
+	}
+
+	isMember, ok := memberRes.Data.(bool)
+	if !ok || !isMember {
+		return nil, fmt.Errorf("usuário não é membro desta organização")
+	}
+
+	res, err := s.scheduleRepo.ListByOrganization(ctx, orgID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	schedules, ok := res.Data.(domain.CursorPage[domain.WorkSchedule])
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados")
+	}
+
+	return &schedules, nil
+}
+
+func (s *ScheduleService) Delete(ctx context.Context, requestingUserID, orgID, scheduleID uuid.UUID) error {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem gerenciar horários contratuais"); err != nil {
+		return err
+	}
+
+	res, err := s.scheduleRepo.Delete(ctx, orgID, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	if !res.Success {
+		return fmt.Errorf("%s", res.Message)
+	}
+
+	return nil
+}
+
+// AssignToMember sets the contracted schedule of a member. Requesting user must be admin.
+func (s *ScheduleService) AssignToMember(ctx context.Context, requestingUserID, orgID, userID uuid.UUID, scheduleID *uuid.UUID) error {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem gerenciar horários contratuais"); err != nil {
+		return err
+	}
+
+	res, err := s.scheduleRepo.AssignToMember(ctx, orgID, userID, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	if !res.Success {
+		return fmt.Errorf("%s", res.Message)
+	}
+
+	return nil
+}
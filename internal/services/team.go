@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	"github.com/marcelorc13/timesheet-pro/internal/repository"
+)
+
+type TeamService struct {
+	teamRepo *repository.TeamRepository
+	orgRepo  *repository.OrganizationRepository
+}
+
+func NewTeamService(teamRepo *repository.TeamRepository, orgRepo *repository.OrganizationRepository) *TeamService {
+	return &TeamService{
+		teamRepo: teamRepo,
+		orgRepo:  orgRepo,
+	}
+}
+
+func (s *TeamService) Create(ctx context.Context, requestingUserID, orgID uuid.UUID, ct domain.CreateTeam) (*uuid.UUID, error) {
+	validate := validator.New()
+	err := validate.Struct(ct)
+	if err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	if err := s.requireAdmin(ctx, requestingUserID, orgID, "apenas administradores podem gerenciar equipes"); err != nil {
+		return nil, err
+	}
+
+	res, err := s.teamRepo.Create(ctx, orgID, ct)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	teamID, ok := res.Data.(uuid.UUID)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados")
+	}
+
+	return &teamID, nil
+}
+
+func (s *TeamService) Delete(ctx context.Context, requestingUserID, orgID, teamID uuid.UUID) error {
+	if err := s.requireAdmin(ctx, requestingUserID, orgID, "apenas administradores podem gerenciar equipes"); err != nil {
+		return err
+	}
+
+	res, err := s.teamRepo.Delete(ctx, orgID, teamID)
+	if err != nil {
+		return err
+	}
+
+	if !res.Success {
+		return fmt.Errorf("%s", res.Message)
+	}
+
+	return nil
+}
+
+// List retrieves a page of the organization teams. Any member can list them.
+func (s *TeamService) List(ctx context.Context, requestingUserID, orgID uuid.UUID, page domain.PageRequest) (*domain.CursorPage[domain.Team], error) {
+	memberRes, err := s.orgRepo.IsUserInOrganization(ctx, requestingUserID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !memberRes.Success {
+		return nil, fmt.Errorf("%s", memberRes.Message)
+	}
+
+	isMember, ok := memberRes.Data.(bool)
+	if !ok || !isMember {
+		return nil, fmt.Errorf("usuário não é membro desta organização")
+	}
+
+	res, err := s.teamRepo.ListByOrganization(ctx, orgID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	teams, ok := res.Data.(domain.CursorPage[domain.Team])
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados das equipes")
+	}
+
+	return &teams, nil
+}
+
+func (s *TeamService) AddMember(ctx context.Context, requestingUserID, orgID, teamID uuid.UUID, am domain.AddTeamMember) error {
+	validate := validator.New()
+	err := validate.Struct(am)
+	if err != nil {
+		return err.(validator.ValidationErrors)
+	}
+
+	if err := s.requireAdmin(ctx, requestingUserID, orgID, "apenas administradores podem gerenciar equipes"); err != nil {
+		return err
+	}
+
+	userID, err := uuid.Parse(am.UserID)
+	if err != nil {
+		return fmt.Errorf("ID do usuário inválido")
+	}
+
+	res, err := s.teamRepo.AddMember(ctx, orgID, teamID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !res.Success {
+		return fmt.Errorf("%s", res.Message)
+	}
+
+	return nil
+}
+
+func (s *TeamService) RemoveMember(ctx context.Context, requestingUserID, orgID, teamID, userID uuid.UUID) error {
+	if err := s.requireAdmin(ctx, requestingUserID, orgID, "apenas administradores podem gerenciar equipes"); err != nil {
+		return err
+	}
+
+	res, err := s.teamRepo.RemoveMember(ctx, orgID, teamID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !res.Success {
+		return fmt.Errorf("%s", res.Message)
+	}
+
+	return nil
+}
+
+func (s *TeamService) requireAdmin(ctx context.Context, userID, orgID uuid.UUID, deniedMessage string) error {
+	return requireOrgAdmin(ctx, s.orgRepo, userID, orgID, deniedMessage)
+}
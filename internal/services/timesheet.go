@@ -4,54 +4,253 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
+	"github.com/go-playground/validator"
 	"github.com/google/uuid"
 	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	"github.com/marcelorc13/timesheet-pro/internal/events"
 	"github.com/marcelorc13/timesheet-pro/internal/repository"
 )
 
 type TimesheetService struct {
 	timesheetRepo *repository.TimesheetRepository
 	orgRepo       *repository.OrganizationRepository
+	teamRepo      *repository.TeamRepository
+	auditRepo     *repository.AuditRepository
+	receiptRepo   *repository.ReceiptRepository
+	webhookRepo   *repository.WebhookRepository
+	policyRepo    *repository.PunchPolicyRepository
+	txManager     *repository.TxManager
+	bus           *events.Bus
 }
 
-func NewTimesheetService(timesheetRepo *repository.TimesheetRepository, orgRepo *repository.OrganizationRepository) *TimesheetService {
+func NewTimesheetService(timesheetRepo *repository.TimesheetRepository, orgRepo *repository.OrganizationRepository, teamRepo *repository.TeamRepository, auditRepo *repository.AuditRepository, receiptRepo *repository.ReceiptRepository, webhookRepo *repository.WebhookRepository, policyRepo *repository.PunchPolicyRepository, txManager *repository.TxManager, bus *events.Bus) *TimesheetService {
 	return &TimesheetService{
 		timesheetRepo: timesheetRepo,
 		orgRepo:       orgRepo,
+		teamRepo:      teamRepo,
+		auditRepo:     auditRepo,
+		receiptRepo:   receiptRepo,
+		webhookRepo:   webhookRepo,
+		policyRepo:    policyRepo,
+		txManager:     txManager,
+		bus:           bus,
 	}
 }
 
-// ClockIn handles clock in/out for a user in an organization
-func (s *TimesheetService) ClockIn(ctx context.Context, userID, orgID uuid.UUID) error {
-	// Verify user is member of the organization
-	memberRes, err := s.orgRepo.IsUserInOrganization(ctx, userID, orgID)
+// ClockIn handles clock in/out for a user in an organization and issues the
+// punch receipt. The proof must satisfy the organization punch policy, which
+// may accept the punch with a warning. A working member may leave for a break
+// instead of clocking out.
+func (s *TimesheetService) ClockIn(ctx context.Context, userID, orgID uuid.UUID, req domain.ClockInRequest) (*domain.ClockInResult, error) {
+	if err := s.requireMember(ctx, userID, orgID); err != nil {
+		return nil, err
+	}
+
+	origin, warning, err := checkPunchPolicy(ctx, s.policyRepo, orgID, req.PunchProof, time.Now())
 	if err != nil {
-		return err
+		return nil, err
 	}
+	origin.Break = req.Break
 
-	if !memberRes.Success {
-		return fmt.Errorf("%s", memberRes.Message)
+	receipt, err := s.clockIn(ctx, userID, orgID, origin)
+	if err != nil {
+		return nil, err
 	}
 
-	isMember, ok := memberRes.Data.(bool)
-	if !ok || !isMember {
-		return fmt.Errorf("usuário não é membro desta organização")
+	return &domain.ClockInResult{PunchReceipt: receipt, Warning: warning}, nil
+}
+
+// ClockInAtKiosk records a punch made by the member at a kiosk device of the
+// organization. Kiosks are on-site, so the punch policy does not apply.
+func (s *TimesheetService) ClockInAtKiosk(ctx context.Context, userID, orgID, deviceID uuid.UUID) (*domain.PunchReceipt, error) {
+	if err := s.requireMember(ctx, userID, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.clockIn(ctx, userID, orgID, domain.PunchOrigin{KioskDeviceID: &deviceID})
+}
+
+// SyncOfflinePunches records the punches the member device queued while it
+// had no connection. Punches keep the device time and are recorded oldest
+// first, each placed against the punch policy as it was at its time and among
+// the day entries at that time. Punches already recorded by an earlier or
+// concurrent sync are reported as duplicates, so the device can resend the
+// whole queue after a failure.
+func (s *TimesheetService) SyncOfflinePunches(ctx context.Context, userID, orgID uuid.UUID, sync domain.SyncOfflinePunches) ([]domain.OfflinePunchResult, error) {
+	validate := validator.New()
+	if err := validate.Struct(sync); err != nil {
+		return nil, err.(validator.ValidationErrors)
 	}
 
-	// Call repository to clock in/out
-	res, err := s.timesheetRepo.ClockIn(ctx, orgID, userID)
+	if err := s.requireMember(ctx, userID, orgID); err != nil {
+		return nil, err
+	}
+
+	policy, err := punchPolicy(ctx, s.policyRepo, orgID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if !res.Success {
-		return fmt.Errorf("%s", res.Message)
+	punches := slices.Clone(sync.Punches)
+	slices.SortStableFunc(punches, func(a, b domain.OfflinePunch) int {
+		return a.CapturedAt.Compare(b.CapturedAt)
+	})
+
+	now := time.Now()
+	driftErr := sync.CheckDrift(now)
+
+	results := make([]domain.OfflinePunchResult, 0, len(punches))
+	for _, p := range punches {
+		result, err := s.syncOfflinePunch(ctx, userID, orgID, policy, p, sync.SentAt, driftErr)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
 	}
 
-	return nil
+	return results, nil
+}
+
+// syncOfflinePunch records a single queued punch. Punches that can never be
+// recorded are rejected with the reason; any other error aborts the sync so
+// the device keeps its queue and tries again later.
+func (s *TimesheetService) syncOfflinePunch(ctx context.Context, userID, orgID uuid.UUID, policy *domain.PunchPolicy, p domain.OfflinePunch, sentAt time.Time, driftErr error) (domain.OfflinePunchResult, error) {
+	result := domain.OfflinePunchResult{ClientID: p.ClientID, Status: domain.OfflinePunchRejected}
+
+	checkRes, err := s.timesheetRepo.CheckOfflinePunch(ctx, p)
+	if err != nil {
+		return result, err
+	}
+
+	if !checkRes.Success {
+		return result, fmt.Errorf("%s", checkRes.Message)
+	}
+
+	check, ok := checkRes.Data.(domain.OfflinePunchCheck)
+	if !ok {
+		return result, fmt.Errorf("erro ao converter verificação do registro offline")
+	}
+
+	if check.Synced {
+		result.Status = domain.OfflinePunchDuplicate
+		return result, nil
+	}
+
+	if driftErr != nil {
+		result.Message = driftErr.Error()
+		return result, nil
+	}
+
+	if err := p.Check(sentAt); err != nil {
+		result.Message = err.Error()
+		return result, nil
+	}
+
+	origin, warning, err := checkOfflinePunchPolicy(policy, p)
+	if err != nil {
+		result.Message = err.Error()
+		return result, nil
+	}
+	origin.Offline = &domain.OfflineCapture{ClientID: p.ClientID, CapturedAt: p.CapturedAt}
+	origin.Break = p.Break
+
+	receipt, err := s.clockIn(ctx, userID, orgID, origin)
+	if err != nil {
+		// A resend of the queue recorded it meanwhile
+		if errors.Is(err, domain.ErrOfflinePunchSynced) {
+			result.Status = domain.OfflinePunchDuplicate
+			return result, nil
+		}
+		// A break queued while the day had no entry, or a punch that would
+		// leave a later break without one or change the type of later
+		// punches, is refused on its own
+		if errors.Is(err, domain.ErrBreakWithoutEntry) || errors.Is(err, domain.ErrOfflinePunchSequence) || errors.Is(err, domain.ErrOfflinePunchRetypes) {
+			result.Message = err.Error()
+			return result, nil
+		}
+		return result, err
+	}
+
+	result.Status = domain.OfflinePunchRecorded
+	result.Message = warning
+	result.Receipt = receipt
+	return result, nil
+}
+
+// PunchPolicy retrieves the punch policy the member must satisfy to clock in
+func (s *TimesheetService) PunchPolicy(ctx context.Context, userID, orgID uuid.UUID) (*domain.PunchPolicy, error) {
+	if err := s.requireMember(ctx, userID, orgID); err != nil {
+		return nil, err
+	}
+
+	return punchPolicy(ctx, s.policyRepo, orgID)
+}
+
+// requireMember returns an error when the user is not a member of the organization
+func (s *TimesheetService) requireMember(ctx context.Context, userID, orgID uuid.UUID) error {
+	return requireOrgMember(ctx, s.orgRepo, userID, orgID)
+}
+
+func (s *TimesheetService) clockIn(ctx context.Context, userID, orgID uuid.UUID, origin domain.PunchOrigin) (*domain.PunchReceipt, error) {
+	// Every punch keeps the client IP, kiosk ones included
+	origin.ClientIP = domain.RequestMetadataFrom(ctx).IP
+
+	var receipt domain.PunchReceipt
+	var punch domain.PunchEvent
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		// Call repository to clock in/out
+		res, err := s.timesheetRepo.ClockIn(ctx, orgID, userID, origin)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		record, ok := res.Data.(domain.PunchRecord)
+		if !ok {
+			return fmt.Errorf("erro ao converter dados do registro")
+		}
+
+		receiptRes, err := s.receiptRepo.Create(ctx, record)
+		if err != nil {
+			return err
+		}
+
+		if !receiptRes.Success {
+			return fmt.Errorf("%s", receiptRes.Message)
+		}
+
+		receipt, ok = receiptRes.Data.(domain.PunchReceipt)
+		if !ok {
+			return fmt.Errorf("erro ao converter dados do comprovante")
+		}
+
+		punch = domain.PunchEvent{
+			OrganizationID:  orgID,
+			UserID:          userID,
+			EntryID:         receipt.EntryID,
+			TypeID:          receipt.TypeID,
+			Timestamp:       receipt.Timestamp,
+			KioskDeviceID:   origin.KioskDeviceID,
+			OfflineCaptured: origin.Offline != nil,
+		}
+		return enqueueWebhook(ctx, s.webhookRepo, orgID, domain.WebhookPunchCreated, punch)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Published after the commit so subscribers never see a rolled back punch
+	s.bus.Publish(punch)
+
+	return &receipt, nil
 }
 
 // GetUserTimesheet retrieves a user's timesheet for a specific date
@@ -89,7 +288,8 @@ func (s *TimesheetService) GetUserTimesheet(ctx context.Context, userID, orgID u
 	return &timesheet, nil
 }
 
-// GetCurrentStatus returns the current clock in/out status for a user
+// GetCurrentStatus returns the current status of a user: "in" while working,
+// "break" while on a break and "out" otherwise
 func (s *TimesheetService) GetCurrentStatus(ctx context.Context, userID, orgID uuid.UUID) (string, *time.Time, error) {
 	// Verify user is member of the organization
 	memberRes, err := s.orgRepo.IsUserInOrganization(ctx, userID, orgID)
@@ -131,48 +331,98 @@ func (s *TimesheetService) GetCurrentStatus(ctx context.Context, userID, orgID u
 	// Get last entry
 	lastEntry := timesheet.Entries[len(timesheet.Entries)-1]
 	
-	if lastEntry.TypeID == domain.EntryTypeIn {
+	if lastEntry.TypeID.StartsWork() {
 		return "in", &lastEntry.Timestamp, nil
 	}
 
+	if lastEntry.TypeID == domain.EntryTypeBreakStart {
+		return "break", &lastEntry.Timestamp, nil
+	}
+
 	return "out", &lastEntry.Timestamp, nil
 }
 
-// GetOrganizationTimesheets retrieves all timesheets for an organization on a specific date
-// Requesting user must be admin
-func (s *TimesheetService) GetOrganizationTimesheets(ctx context.Context, adminUserID, orgID uuid.UUID, date time.Time) ([]domain.UserTimesheet, error) {
-	// Verify user is member of the organization
-	memberRes, err := s.orgRepo.IsUserInOrganization(ctx, adminUserID, orgID)
+// GetOrganizationTimesheets retrieves a page of the organization timesheets matching q.
+// Admins see every member, optionally filtered by q.TeamID; managers only see the
+// members of their own teams.
+func (s *TimesheetService) GetOrganizationTimesheets(ctx context.Context, requestingUserID, orgID uuid.UUID, q domain.TimesheetQuery) (*domain.TimesheetPage, error) {
+	teamIDs, err := s.timesheetScope(ctx, requestingUserID, orgID, q.TeamID)
 	if err != nil {
 		return nil, err
 	}
 
-	if !memberRes.Success {
-		return nil, fmt.Errorf("%s", memberRes.Message)
+	// Managers without teams have nobody to supervise
+	if teamIDs != nil && len(teamIDs) == 0 {
+		return &domain.TimesheetPage{Timesheets: []domain.UserTimesheet{}, Page: q.Page, PageSize: q.PageSize}, nil
 	}
 
-	isMember, ok := memberRes.Data.(bool)
-	if !ok || !isMember {
-		return nil, fmt.Errorf("usuário não é membro desta organização")
+	// Get organization timesheets from repository
+	res, err := s.timesheetRepo.GetOrganizationTimesheets(ctx, orgID, q, teamIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	page, ok := res.Data.(domain.TimesheetPage)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados dos timesheets")
+	}
+
+	return &page, nil
+}
+
+// ListOrganizationTimesheets retrieves a keyset paginated page of the
+// organization timesheets matching q, with the same scope rules as
+// GetOrganizationTimesheets. The page fields of q are ignored.
+func (s *TimesheetService) ListOrganizationTimesheets(ctx context.Context, requestingUserID, orgID uuid.UUID, q domain.TimesheetQuery, page domain.PageRequest) (*domain.CursorPage[domain.UserTimesheet], error) {
+	teamIDs, err := s.timesheetScope(ctx, requestingUserID, orgID, q.TeamID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Check if user is admin
-	adminRes, err := s.orgRepo.IsUserAdmin(ctx, adminUserID, orgID)
+	// Managers without teams have nobody to supervise
+	if teamIDs != nil && len(teamIDs) == 0 {
+		return &domain.CursorPage[domain.UserTimesheet]{Items: []domain.UserTimesheet{}}, nil
+	}
+
+	res, err := s.timesheetRepo.ListOrganizationTimesheets(ctx, orgID, q, teamIDs, page)
 	if err != nil {
 		return nil, err
 	}
 
-	if !adminRes.Success {
-		return nil, fmt.Errorf("%s", adminRes.Message)
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
 	}
 
-	isAdmin, ok := adminRes.Data.(bool)
-	if !ok || !isAdmin {
-		return nil, fmt.Errorf("apenas administradores podem visualizar timesheets da organização")
+	timesheets, ok := res.Data.(domain.CursorPage[domain.UserTimesheet])
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados dos timesheets")
 	}
 
-	// Get organization timesheets from repository
-	res, err := s.timesheetRepo.GetOrganizationTimesheets(ctx, orgID, date)
+	return &timesheets, nil
+}
+
+// SummarizeTeams computes the totals of the given teams over every timesheet
+// matching q, not only the current page. The team filter of q is ignored so
+// each team is summarized on its own.
+func (s *TimesheetService) SummarizeTeams(ctx context.Context, requestingUserID, orgID uuid.UUID, q domain.TimesheetQuery, teams []domain.Team) ([]domain.TeamTotal, error) {
+	if len(teams) == 0 {
+		return []domain.TeamTotal{}, nil
+	}
+
+	if _, err := s.timesheetScope(ctx, requestingUserID, orgID, nil); err != nil {
+		return nil, err
+	}
+
+	teamIDs := make([]uuid.UUID, len(teams))
+	for i, team := range teams {
+		teamIDs[i] = team.ID
+	}
+
+	res, err := s.timesheetRepo.SummarizeTeams(ctx, orgID, q, teamIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -181,18 +431,32 @@ func (s *TimesheetService) GetOrganizationTimesheets(ctx context.Context, adminU
 		return nil, fmt.Errorf("%s", res.Message)
 	}
 
-	timesheets, ok := res.Data.([]domain.UserTimesheet)
+	sums, ok := res.Data.(map[uuid.UUID]domain.TeamTotal)
 	if !ok {
-		return nil, fmt.Errorf("erro ao converter dados dos timesheets")
+		return nil, fmt.Errorf("erro ao converter totais das equipes")
+	}
+
+	totals := make([]domain.TeamTotal, 0, len(teams))
+	for _, team := range teams {
+		total := sums[team.ID]
+		total.TeamID = team.ID
+		total.TeamName = team.Name
+		total.Members = len(team.Members)
+		totals = append(totals, total)
 	}
 
-	return timesheets, nil
+	return totals, nil
 }
 
-// GetTimesheetByID retrieves a single timesheet by its ID
-func (s *TimesheetService) GetTimesheetByID(ctx context.Context, requestingUserID, timesheetID uuid.UUID) (*domain.UserTimesheet, error) {
-	// Get timesheet from repository
-	res, err := s.timesheetRepo.GetTimesheetByID(ctx, timesheetID)
+// GetVisibleTeams returns the teams whose timesheets the user can supervise:
+// every team for admins and the user's own teams for managers
+func (s *TimesheetService) GetVisibleTeams(ctx context.Context, requestingUserID, orgID uuid.UUID) ([]domain.Team, error) {
+	teamIDs, err := s.timesheetScope(ctx, requestingUserID, orgID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.teamRepo.ListByOrganization(ctx, orgID, domain.PageRequest{})
 	if err != nil {
 		return nil, err
 	}
@@ -201,35 +465,317 @@ func (s *TimesheetService) GetTimesheetByID(ctx context.Context, requestingUserI
 		return nil, fmt.Errorf("%s", res.Message)
 	}
 
+	page, ok := res.Data.(domain.CursorPage[domain.Team])
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados das equipes")
+	}
+	teams := page.Items
+
+	if teamIDs == nil {
+		return teams, nil
+	}
+
+	visible := []domain.Team{}
+	for _, team := range teams {
+		for _, id := range teamIDs {
+			if team.ID == id {
+				visible = append(visible, team)
+				break
+			}
+		}
+	}
+
+	return visible, nil
+}
+
+// CanSuperviseTimesheets reports whether the user is an admin or a manager of the organization
+func (s *TimesheetService) CanSuperviseTimesheets(ctx context.Context, userID, orgID uuid.UUID) (bool, error) {
+	role, err := s.getUserRole(ctx, userID, orgID)
+	if err != nil {
+		return false, err
+	}
+
+	return role == domain.Admin || role == domain.Manager, nil
+}
+
+// ReviewTimesheet approves or reproves a daily timesheet.
+// Admins can review any timesheet; managers only those of their team members.
+func (s *TimesheetService) ReviewTimesheet(ctx context.Context, requestingUserID, timesheetID uuid.UUID, approve bool) error {
+	res, err := s.timesheetRepo.GetTimesheetByID(ctx, timesheetID)
+	if err != nil {
+		return err
+	}
+
+	if !res.Success {
+		return fmt.Errorf("%s", res.Message)
+	}
+
 	timesheet, ok := res.Data.(domain.UserTimesheet)
 	if !ok {
-		return nil, fmt.Errorf("erro ao converter dados do timesheet")
+		return fmt.Errorf("erro ao converter dados do timesheet")
 	}
 
-	// Verify user has permission to view this timesheet
-	// Either they own it, or they're an admin in the organization
-	if timesheet.UserID != requestingUserID {
-		// Check if requesting user is admin
-		adminRes, err := s.orgRepo.IsUserAdmin(ctx, requestingUserID, timesheet.OrganizationID)
+	allowed, err := s.CanReview(ctx, requestingUserID, timesheet.OrganizationID, timesheet.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !allowed {
+		if timesheet.UserID == requestingUserID {
+			return fmt.Errorf("você não pode aprovar o próprio timesheet")
+		}
+		return fmt.Errorf("você não tem permissão para aprovar este timesheet")
+	}
+
+	status := domain.StatusReproved
+	action := domain.AuditTimesheetReprove
+	event := domain.WebhookTimesheetReproved
+	if approve {
+		status = domain.StatusApproved
+		action = domain.AuditTimesheetApprove
+		event = domain.WebhookTimesheetApproved
+	}
+
+	return s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		updateRes, err := s.timesheetRepo.UpdateStatus(ctx, timesheetID, status)
+		if err != nil {
+			return err
+		}
+
+		if !updateRes.Success {
+			return fmt.Errorf("%s", updateRes.Message)
+		}
+
+		before := map[string]any{"user_id": timesheet.UserID, "date": timesheet.Date, "status_id": timesheet.StatusID}
+		after := map[string]any{"user_id": timesheet.UserID, "date": timesheet.Date, "status_id": status}
+		if err := recordAudit(ctx, s.auditRepo, timesheet.OrganizationID, requestingUserID, action, "timesheet", timesheetID.String(), before, after); err != nil {
+			return err
+		}
+
+		return enqueueWebhook(ctx, s.webhookRepo, timesheet.OrganizationID, event, domain.TimesheetReviewedEvent{
+			TimesheetID: timesheetID,
+			UserID:      timesheet.UserID,
+			Date:        timesheet.Date,
+			StatusID:    status,
+			ReviewerID:  requestingUserID,
+		})
+	})
+}
+
+// CanReview reports whether the user may approve the records of the member.
+// Admins review anyone; managers the members of their teams, but not themselves.
+func (s *TimesheetService) CanReview(ctx context.Context, userID, orgID, memberID uuid.UUID) (bool, error) {
+	role, err := s.getUserRole(ctx, userID, orgID)
+	if err != nil {
+		return false, err
+	}
+
+	switch role {
+	case domain.Admin:
+		return true, nil
+	case domain.Manager:
+		if memberID == userID {
+			return false, nil
+		}
+		return s.sharesTeam(ctx, orgID, userID, memberID)
+	default:
+		return false, nil
+	}
+}
+
+// SupervisedTeams resolves which teams the user supervises: nil for admins,
+// who supervise every member, and the teams of managers
+func (s *TimesheetService) SupervisedTeams(ctx context.Context, userID, orgID uuid.UUID) ([]uuid.UUID, error) {
+	return s.timesheetScope(ctx, userID, orgID, nil)
+}
+
+// timesheetScope resolves which teams the user can supervise. A nil slice means
+// no restriction (admin without a team filter).
+func (s *TimesheetService) timesheetScope(ctx context.Context, userID, orgID uuid.UUID, teamID *uuid.UUID) ([]uuid.UUID, error) {
+	role, err := s.getUserRole(ctx, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch role {
+	case domain.Admin:
+		if teamID != nil {
+			return []uuid.UUID{*teamID}, nil
+		}
+		return nil, nil
+	case domain.Manager:
+		res, err := s.teamRepo.GetUserTeamIDs(ctx, userID, orgID)
 		if err != nil {
 			return nil, err
 		}
 
-		if !adminRes.Success {
-			return nil, fmt.Errorf("%s", adminRes.Message)
+		if !res.Success {
+			return nil, fmt.Errorf("%s", res.Message)
 		}
 
-		isAdmin, ok := adminRes.Data.(bool)
-		if !ok || !isAdmin {
-			return nil, fmt.Errorf("você não tem permissão para visualizar este timesheet")
+		teamIDs, ok := res.Data.([]uuid.UUID)
+		if !ok {
+			return nil, fmt.Errorf("erro ao converter dados das equipes")
 		}
+
+		if teamID == nil {
+			return teamIDs, nil
+		}
+		for _, id := range teamIDs {
+			if id == *teamID {
+				return []uuid.UUID{id}, nil
+			}
+		}
+		return nil, fmt.Errorf("você não gerencia esta equipe")
+	default:
+		return nil, fmt.Errorf("apenas administradores e gestores podem visualizar timesheets da organização")
+	}
+}
+
+// Presence retrieves the current status of the members the requesting user
+// supervises: every member for admins, the members of their teams for managers
+func (s *TimesheetService) Presence(ctx context.Context, requestingUserID, orgID uuid.UUID) ([]domain.MemberPresence, error) {
+	teamIDs, err := s.timesheetScope(ctx, requestingUserID, orgID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Managers without teams have nobody to supervise
+	if teamIDs != nil && len(teamIDs) == 0 {
+		return []domain.MemberPresence{}, nil
+	}
+
+	res, err := s.timesheetRepo.GetPresence(ctx, orgID, teamIDs, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	presence, ok := res.Data.([]domain.MemberPresence)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados de presença")
+	}
+
+	return presence, nil
+}
+
+// SubscribePunches streams the punch events of the members the requesting user
+// supervises, with the same scope as Presence. Call cancel when done listening.
+func (s *TimesheetService) SubscribePunches(ctx context.Context, requestingUserID, orgID uuid.UUID) (<-chan domain.PunchEvent, func(), error) {
+	teamIDs, err := s.timesheetScope(ctx, requestingUserID, orgID, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var filter func(domain.PunchEvent) bool
+	if teamIDs != nil {
+		presence, err := s.Presence(ctx, requestingUserID, orgID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		supervised := make(map[uuid.UUID]bool, len(presence))
+		for _, p := range presence {
+			supervised[p.UserID] = true
+		}
+		filter = func(ev domain.PunchEvent) bool { return supervised[ev.UserID] }
+	}
+
+	ch, cancel := s.bus.Subscribe(orgID, filter)
+	return ch, cancel, nil
+}
+
+// canViewUserTimesheets checks if the requesting user can see the target user's timesheets:
+// the user themself, an admin, or a manager sharing a team with them
+func (s *TimesheetService) canViewUserTimesheets(ctx context.Context, requestingUserID, targetUserID, orgID uuid.UUID) (bool, error) {
+	if requestingUserID == targetUserID {
+		return true, nil
+	}
+
+	role, err := s.getUserRole(ctx, requestingUserID, orgID)
+	if err != nil {
+		return false, err
+	}
+
+	switch role {
+	case domain.Admin:
+		return true, nil
+	case domain.Manager:
+		return s.sharesTeam(ctx, orgID, requestingUserID, targetUserID)
+	default:
+		return false, nil
+	}
+}
+
+func (s *TimesheetService) getUserRole(ctx context.Context, userID, orgID uuid.UUID) (domain.Role, error) {
+	res, err := s.orgRepo.GetUserRole(ctx, userID, orgID)
+	if err != nil {
+		return "", err
+	}
+
+	if !res.Success {
+		return "", fmt.Errorf("%s", res.Message)
+	}
+
+	role, ok := res.Data.(domain.Role)
+	if !ok {
+		return "", fmt.Errorf("erro ao verificar permissões")
+	}
+
+	return role, nil
+}
+
+func (s *TimesheetService) sharesTeam(ctx context.Context, orgID, userID, otherUserID uuid.UUID) (bool, error) {
+	res, err := s.teamRepo.SharesTeam(ctx, orgID, userID, otherUserID)
+	if err != nil {
+		return false, err
+	}
+
+	if !res.Success {
+		return false, fmt.Errorf("%s", res.Message)
+	}
+
+	shares, ok := res.Data.(bool)
+	if !ok {
+		return false, fmt.Errorf("erro ao verificar equipes")
+	}
+
+	return shares, nil
+}
+
+// GetTimesheetByID retrieves a single timesheet by its ID
+func (s *TimesheetService) GetTimesheetByID(ctx context.Context, requestingUserID, timesheetID uuid.UUID) (*domain.UserTimesheet, error) {
+	// Get timesheet from repository
+	res, err := s.timesheetRepo.GetTimesheetByID(ctx, timesheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	timesheet, ok := res.Data.(domain.UserTimesheet)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados do timesheet")
+	}
+
+	// Verify user has permission to view this timesheet
+	// Either they own it, they're an admin in the organization or they manage the owner's team
+	canView, err := s.canViewUserTimesheets(ctx, requestingUserID, timesheet.UserID, timesheet.OrganizationID)
+	if err != nil || !canView {
+		return nil, fmt.Errorf("você não tem permissão para visualizar este timesheet")
 	}
 
 	return &timesheet, nil
 }
 
-// GetUserTimesheets retrieves all timesheets for a user within a date range
-func (s *TimesheetService) GetUserTimesheets(ctx context.Context, requestingUserID, targetUserID, orgID uuid.UUID, startDate, endDate time.Time) ([]domain.UserTimesheet, error) {
+// GetUserTimesheets retrieves a page of the timesheets of a user within a date range.
+// A zero page returns every timesheet of the range.
+func (s *TimesheetService) GetUserTimesheets(ctx context.Context, requestingUserID, targetUserID, orgID uuid.UUID, startDate, endDate time.Time, page domain.PageRequest) (*domain.CursorPage[domain.UserTimesheet], error) {
 	// Verify requesting user is member of the organization
 	memberRes, err := s.orgRepo.IsUserInOrganization(ctx, requestingUserID, orgID)
 	if err != nil {
@@ -245,25 +791,17 @@ func (s *TimesheetService) GetUserTimesheets(ctx context.Context, requestingUser
 		return nil, fmt.Errorf("usuário não é membro desta organização")
 	}
 
-	// If requesting user is not the target user, check if they're an admin
-	if requestingUserID != targetUserID {
-		adminRes, err := s.orgRepo.IsUserAdmin(ctx, requestingUserID, orgID)
-		if err != nil {
-			return nil, err
-		}
-
-		if !adminRes.Success {
-			return nil, fmt.Errorf("%s", adminRes.Message)
-		}
-
-		isAdmin, ok := adminRes.Data.(bool)
-		if !ok || !isAdmin {
-			return nil, fmt.Errorf("apenas administradores podem visualizar timesheets de outros usuários")
-		}
+	// If requesting user is not the target user, check if they're an admin or the target's manager
+	canView, err := s.canViewUserTimesheets(ctx, requestingUserID, targetUserID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, fmt.Errorf("apenas administradores e gestores podem visualizar timesheets de outros usuários")
 	}
 
 	// Get timesheets from repository
-	res, err := s.timesheetRepo.GetUserTimesheets(ctx, targetUserID, orgID, startDate, endDate)
+	res, err := s.timesheetRepo.GetUserTimesheets(ctx, targetUserID, orgID, startDate, endDate, page)
 	if err != nil {
 		return nil, err
 	}
@@ -272,10 +810,10 @@ func (s *TimesheetService) GetUserTimesheets(ctx context.Context, requestingUser
 		return nil, fmt.Errorf("%s", res.Message)
 	}
 
-	timesheets, ok := res.Data.([]domain.UserTimesheet)
+	timesheets, ok := res.Data.(domain.CursorPage[domain.UserTimesheet])
 	if !ok {
 		return nil, fmt.Errorf("erro ao converter dados dos timesheets")
 	}
 
-	return timesheets, nil
+	return &timesheets, nil
 }
@@ -0,0 +1,536 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	"github.com/marcelorc13/timesheet-pro/internal/repository"
+)
+
+const (
+	// weekReminderInterval is how often overdue weeks are looked for
+	weekReminderInterval = time.Hour
+	// recentWeeksLimit is how many past weeks are listed to the member
+	recentWeeksLimit = 12
+)
+
+type TimesheetWeekService struct {
+	weekRepo      *repository.TimesheetWeekRepository
+	timesheetRepo *repository.TimesheetRepository
+	orgRepo       *repository.OrganizationRepository
+	auditRepo     *repository.AuditRepository
+	webhookRepo   *repository.WebhookRepository
+	txManager     *repository.TxManager
+	timesheetServ *TimesheetService
+}
+
+func NewTimesheetWeekService(weekRepo *repository.TimesheetWeekRepository, timesheetRepo *repository.TimesheetRepository, orgRepo *repository.OrganizationRepository, auditRepo *repository.AuditRepository, webhookRepo *repository.WebhookRepository, txManager *repository.TxManager, timesheetServ *TimesheetService) *TimesheetWeekService {
+	return &TimesheetWeekService{
+		weekRepo:      weekRepo,
+		timesheetRepo: timesheetRepo,
+		orgRepo:       orgRepo,
+		auditRepo:     auditRepo,
+		webhookRepo:   webhookRepo,
+		txManager:     txManager,
+		timesheetServ: timesheetServ,
+	}
+}
+
+// Settings retrieves whether the organization asks for weekly submissions.
+// Requesting user must be a member.
+func (s *TimesheetWeekService) Settings(ctx context.Context, requestingUserID, orgID uuid.UUID) (*domain.WeekSettings, error) {
+	if err := requireOrgMember(ctx, s.orgRepo, requestingUserID, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.settings(ctx, orgID)
+}
+
+func (s *TimesheetWeekService) settings(ctx context.Context, orgID uuid.UUID) (*domain.WeekSettings, error) {
+	res, err := s.weekRepo.GetSettings(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	settings, ok := res.Data.(domain.WeekSettings)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter configuração do envio semanal")
+	}
+
+	return &settings, nil
+}
+
+// UpdateSettings turns the weekly submissions of the organization on or off.
+// Requesting user must be admin.
+func (s *TimesheetWeekService) UpdateSettings(ctx context.Context, requestingUserID, orgID uuid.UUID, u domain.UpdateWeekSettings) (*domain.WeekSettings, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem configurar o envio semanal"); err != nil {
+		return nil, err
+	}
+
+	var saved domain.WeekSettings
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		before, err := s.settings(ctx, orgID)
+		if err != nil {
+			return err
+		}
+
+		res, err := s.weekRepo.SaveSettings(ctx, domain.WeekSettings{OrganizationID: orgID, Enabled: u.Enabled})
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		var ok bool
+		saved, ok = res.Data.(domain.WeekSettings)
+		if !ok {
+			return fmt.Errorf("erro ao converter configuração do envio semanal")
+		}
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditWeekSettingsUpdate, "week_settings", orgID.String(), before, saved)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// Week summarizes the week of the requesting user starting at weekStart.
+// Weeks never submitted are returned as drafts.
+func (s *TimesheetWeekService) Week(ctx context.Context, requestingUserID, orgID uuid.UUID, weekStart time.Time) (*domain.WeekSummary, error) {
+	if err := requireOrgMember(ctx, s.orgRepo, requestingUserID, orgID); err != nil {
+		return nil, err
+	}
+
+	week, err := s.userWeek(ctx, orgID, requestingUserID, weekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.summarize(ctx, *week)
+}
+
+// userWeek retrieves the week of the member, or a draft when there is none
+func (s *TimesheetWeekService) userWeek(ctx context.Context, orgID, userID uuid.UUID, weekStart time.Time) (*domain.TimesheetWeek, error) {
+	res, err := s.weekRepo.GetWeek(ctx, orgID, userID, weekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	week, ok := res.Data.(*domain.TimesheetWeek)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados da semana")
+	}
+
+	if week == nil {
+		week = &domain.TimesheetWeek{
+			OrganizationID: orgID,
+			UserID:         userID,
+			WeekStart:      weekStart,
+			Status:         domain.WeekDraft,
+		}
+	}
+
+	return week, nil
+}
+
+// summarize loads the timesheets of the week
+func (s *TimesheetWeekService) summarize(ctx context.Context, week domain.TimesheetWeek) (*domain.WeekSummary, error) {
+	res, err := s.timesheetRepo.GetOrganizationTimesheetsForPeriod(ctx, week.OrganizationID, week.WeekStart, week.WeekEnd(), &week.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	timesheets, ok := res.Data.([]domain.UserTimesheet)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados dos timesheets")
+	}
+
+	loc, err := organizationLocation(ctx, s.orgRepo, week.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := domain.NewWeekSummary(week, timesheets, loc)
+	return &summary, nil
+}
+
+// RecentWeeks retrieves the latest submitted or reminded weeks of the
+// requesting user, newest first
+func (s *TimesheetWeekService) RecentWeeks(ctx context.Context, requestingUserID, orgID uuid.UUID) ([]domain.TimesheetWeek, error) {
+	if err := requireOrgMember(ctx, s.orgRepo, requestingUserID, orgID); err != nil {
+		return nil, err
+	}
+
+	res, err := s.weekRepo.ListUserWeeks(ctx, orgID, requestingUserID, recentWeeksLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	weeks, ok := res.Data.([]domain.TimesheetWeek)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados das semanas")
+	}
+
+	return weeks, nil
+}
+
+// PendingWeeks retrieves the Mondays of the past weeks the requesting user
+// worked in but did not submit, or had rejected, since the organization
+// enabled weekly submissions. It is empty when they are disabled.
+func (s *TimesheetWeekService) PendingWeeks(ctx context.Context, requestingUserID, orgID uuid.UUID) ([]time.Time, error) {
+	settings, err := s.Settings(ctx, requestingUserID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !settings.Enabled {
+		return []time.Time{}, nil
+	}
+
+	res, err := s.weekRepo.UnsubmittedWeeks(ctx, orgID, requestingUserID, domain.WeekStart(settings.UpdatedAt), domain.WeekStart(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	weeks, ok := res.Data.([]time.Time)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados das semanas")
+	}
+
+	return weeks, nil
+}
+
+// SubmitWeek sends the week of the requesting user for review with the
+// worked time it has now. Drafts and rejected weeks that already started can
+// be submitted.
+func (s *TimesheetWeekService) SubmitWeek(ctx context.Context, requestingUserID, orgID uuid.UUID, sw domain.SubmitWeek) (*domain.TimesheetWeek, error) {
+	validate := validator.New()
+	if err := validate.Struct(sw); err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	weekStart, err := domain.ParseWeek(sw.Week)
+	if err != nil {
+		return nil, err
+	}
+
+	if weekStart.After(domain.WeekStart(time.Now())) {
+		return nil, fmt.Errorf("não é possível enviar uma semana futura")
+	}
+
+	settings, err := s.Settings(ctx, requestingUserID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !settings.Enabled {
+		return nil, fmt.Errorf("a organização não utiliza o envio semanal")
+	}
+
+	week, err := s.userWeek(ctx, orgID, requestingUserID, weekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	if !week.Status.CanSubmit() {
+		return nil, fmt.Errorf("esta semana já foi enviada")
+	}
+
+	summary, err := s.summarize(ctx, *week)
+	if err != nil {
+		return nil, err
+	}
+
+	week.WorkedMinutes = summary.WorkedMinutes
+	week.Note = strings.TrimSpace(sw.Note)
+
+	var saved domain.TimesheetWeek
+	err = s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.weekRepo.SubmitWeek(ctx, *week)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		var ok bool
+		saved, ok = res.Data.(domain.TimesheetWeek)
+		if !ok {
+			return fmt.Errorf("erro ao converter dados da semana")
+		}
+
+		return enqueueWebhook(ctx, s.webhookRepo, orgID, domain.WebhookWeekSubmitted, weekEvent(saved))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// ReviewQueue retrieves the submitted weeks the requesting user can review,
+// oldest first. Admins see every member; managers the members of their teams
+// but not their own weeks.
+func (s *TimesheetWeekService) ReviewQueue(ctx context.Context, requestingUserID, orgID uuid.UUID) ([]domain.WeekSummary, error) {
+	teamIDs, err := s.timesheetServ.SupervisedTeams(ctx, requestingUserID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var exclude *uuid.UUID
+	if teamIDs != nil {
+		// Managers without teams have nobody to supervise
+		if len(teamIDs) == 0 {
+			return []domain.WeekSummary{}, nil
+		}
+		exclude = &requestingUserID
+	}
+
+	res, err := s.weekRepo.ReviewQueue(ctx, orgID, teamIDs, exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	weeks, ok := res.Data.([]domain.TimesheetWeek)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados das semanas")
+	}
+
+	queue := []domain.WeekSummary{}
+	for _, week := range weeks {
+		summary, err := s.summarize(ctx, week)
+		if err != nil {
+			return nil, err
+		}
+		queue = append(queue, *summary)
+	}
+
+	return queue, nil
+}
+
+// WeekByID summarizes a week of the organization. The member it belongs to
+// and whoever can review it may see it.
+func (s *TimesheetWeekService) WeekByID(ctx context.Context, requestingUserID, orgID, weekID uuid.UUID) (*domain.WeekSummary, error) {
+	week, err := s.week(ctx, orgID, weekID)
+	if err != nil {
+		return nil, err
+	}
+
+	if week.UserID != requestingUserID {
+		allowed, err := s.timesheetServ.CanReview(ctx, requestingUserID, orgID, week.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, fmt.Errorf("você não tem permissão para revisar esta semana")
+		}
+	}
+
+	return s.summarize(ctx, *week)
+}
+
+func (s *TimesheetWeekService) week(ctx context.Context, orgID, weekID uuid.UUID) (*domain.TimesheetWeek, error) {
+	res, err := s.weekRepo.GetWeekByID(ctx, orgID, weekID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	week, ok := res.Data.(domain.TimesheetWeek)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados da semana")
+	}
+
+	return &week, nil
+}
+
+// ApproveWeek approves a submitted week and every worked day in it.
+// Admins can review any week; managers only those of their team members.
+func (s *TimesheetWeekService) ApproveWeek(ctx context.Context, requestingUserID, orgID, weekID uuid.UUID, rw domain.ReviewWeek) (*domain.TimesheetWeek, error) {
+	return s.reviewWeek(ctx, requestingUserID, orgID, weekID, rw, true)
+}
+
+// RejectWeek sends a submitted week back to the member, who may fix it and
+// submit it again. The note saying why is required.
+func (s *TimesheetWeekService) RejectWeek(ctx context.Context, requestingUserID, orgID, weekID uuid.UUID, rw domain.ReviewWeek) (*domain.TimesheetWeek, error) {
+	return s.reviewWeek(ctx, requestingUserID, orgID, weekID, rw, false)
+}
+
+func (s *TimesheetWeekService) reviewWeek(ctx context.Context, requestingUserID, orgID, weekID uuid.UUID, rw domain.ReviewWeek, approve bool) (*domain.TimesheetWeek, error) {
+	validate := validator.New()
+	if err := validate.Struct(rw); err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	note := strings.TrimSpace(rw.Note)
+	if !approve && note == "" {
+		return nil, fmt.Errorf("informe o motivo da rejeição")
+	}
+
+	week, err := s.week(ctx, orgID, weekID)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := s.timesheetServ.CanReview(ctx, requestingUserID, orgID, week.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !allowed {
+		if week.UserID == requestingUserID {
+			return nil, fmt.Errorf("você não pode revisar a própria semana")
+		}
+		return nil, fmt.Errorf("você não tem permissão para revisar esta semana")
+	}
+
+	status := domain.WeekRejected
+	action := domain.AuditWeekReject
+	event := domain.WebhookWeekRejected
+	if approve {
+		status = domain.WeekApproved
+		action = domain.AuditWeekApprove
+		event = domain.WebhookWeekApproved
+	}
+
+	var saved domain.TimesheetWeek
+	err = s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.weekRepo.ReviewWeek(ctx, orgID, weekID, requestingUserID, status, note)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		var ok bool
+		saved, ok = res.Data.(domain.TimesheetWeek)
+		if !ok {
+			return fmt.Errorf("erro ao converter dados da semana")
+		}
+
+		if approve {
+			res, err := s.weekRepo.ApproveDays(ctx, orgID, saved.UserID, saved.WeekStart, saved.WeekEnd())
+			if err != nil {
+				return err
+			}
+
+			if !res.Success {
+				return fmt.Errorf("%s", res.Message)
+			}
+		}
+
+		before := map[string]any{"user_id": week.UserID, "week_start": week.WeekStart, "status": week.Status}
+		after := map[string]any{"user_id": saved.UserID, "week_start": saved.WeekStart, "status": saved.Status, "review_note": saved.ReviewNote}
+		if err := recordAudit(ctx, s.auditRepo, orgID, requestingUserID, action, "timesheet_week", weekID.String(), before, after); err != nil {
+			return err
+		}
+
+		return enqueueWebhook(ctx, s.webhookRepo, orgID, event, weekEvent(saved))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// RunReminders reminds the members of the last week they did not submit until
+// ctx is cancelled, sending a timesheet_week.overdue event for each. Each week
+// is reminded once, so several instances can run it at the same time.
+func (s *TimesheetWeekService) RunReminders(ctx context.Context) {
+	ticker := time.NewTicker(weekReminderInterval)
+	defer ticker.Stop()
+
+	for {
+		s.remindOverdue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// remindOverdue marks the unsubmitted weeks that ended before the current one
+// as reminded and queues their events
+func (s *TimesheetWeekService) remindOverdue(ctx context.Context) {
+	lastWeek := domain.WeekStart(time.Now()).AddDate(0, 0, -7)
+
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.weekRepo.RemindOverdue(ctx, lastWeek)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		weeks, ok := res.Data.([]domain.TimesheetWeek)
+		if !ok {
+			return fmt.Errorf("erro ao converter dados das semanas")
+		}
+
+		for _, week := range weeks {
+			if err := enqueueWebhook(ctx, s.webhookRepo, week.OrganizationID, domain.WebhookWeekOverdue, weekEvent(week)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("weeks: erro ao lembrar semanas pendentes: %v", err)
+	}
+}
+
+func weekEvent(w domain.TimesheetWeek) domain.TimesheetWeekEvent {
+	return domain.TimesheetWeekEvent{
+		WeekID:        w.ID,
+		UserID:        w.UserID,
+		WeekStart:     w.WeekStart,
+		Status:        w.Status,
+		WorkedMinutes: w.WorkedMinutes,
+	}
+}
@@ -18,8 +18,8 @@ func NewUserService(userRepository repository.UserRepository) *UserService {
 	return &UserService{repository: userRepository}
 }
 
-func (us UserService) List(ctx context.Context) (*[]domain.User, error) {
-	res, err := us.repository.List(ctx)
+func (us UserService) List(ctx context.Context, page domain.PageRequest) (*domain.CursorPage[domain.User], error) {
+	res, err := us.repository.List(ctx, page)
 
 	if err != nil {
 		return nil, err
@@ -29,7 +29,7 @@ func (us UserService) List(ctx context.Context) (*[]domain.User, error) {
 		return nil, nil
 	}
 
-	usuarios, ok := res.Data.([]domain.User)
+	usuarios, ok := res.Data.(domain.CursorPage[domain.User])
 
 	if !ok {
 		return nil, fmt.Errorf("erro ao converter dados")
@@ -120,11 +120,16 @@ func (us UserService) Login(ctx context.Context, u domain.LoginUser) (*domain.Us
 }
 
 // UpdateProfile updates a user's profile information
-func (us UserService) UpdateProfile(ctx context.Context, userID uuid.UUID, name, email string) (*domain.User, error) {
+func (us UserService) UpdateProfile(ctx context.Context, userID uuid.UUID, name, email, cpf string) (*domain.User, error) {
 	// Validate name is not empty
 	if name == "" {
 		return nil, fmt.Errorf("nome não pode ser vazio")
 	}
+
+	cpf, err := domain.NormalizeCPF(cpf)
+	if err != nil {
+		return nil, err
+	}
 	
 	// Validate email format
 	validate := validator.New()
@@ -146,7 +151,7 @@ func (us UserService) UpdateProfile(ctx context.Context, userID uuid.UUID, name,
 	}
 	
 	// Update user
-	res, err := us.repository.UpdateUser(ctx, userID.String(), name, email)
+	res, err := us.repository.UpdateUser(ctx, userID.String(), name, email, cpf)
 	if err != nil {
 		return nil, err
 	}
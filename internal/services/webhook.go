@@ -0,0 +1,434 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+	"github.com/marcelorc13/timesheet-pro/internal/domain"
+	"github.com/marcelorc13/timesheet-pro/internal/repository"
+)
+
+const (
+	// webhookPollInterval is how often the dispatcher looks for due deliveries
+	webhookPollInterval = 5 * time.Second
+	// webhookBatchSize is how many deliveries are sent concurrently per poll
+	webhookBatchSize = 10
+	// webhookLease postpones claimed deliveries so they are not sent twice;
+	// it must be longer than webhookTimeout
+	webhookLease   = 2 * time.Minute
+	webhookTimeout = 10 * time.Second
+	// webhookMaxResponseBody is how much of the receiver response is kept in the log
+	webhookMaxResponseBody = 1024
+)
+
+type WebhookService struct {
+	webhookRepo *repository.WebhookRepository
+	orgRepo     *repository.OrganizationRepository
+	auditRepo   *repository.AuditRepository
+	txManager   *repository.TxManager
+	client      *http.Client
+}
+
+func NewWebhookService(webhookRepo *repository.WebhookRepository, orgRepo *repository.OrganizationRepository, auditRepo *repository.AuditRepository, txManager *repository.TxManager) *WebhookService {
+	return &WebhookService{
+		webhookRepo: webhookRepo,
+		orgRepo:     orgRepo,
+		auditRepo:   auditRepo,
+		txManager:   txManager,
+		client:      newWebhookClient(),
+	}
+}
+
+// newWebhookClient returns the client used for deliveries. Every address it
+// dials is checked, so hostnames resolving (or rebinding) to the server own
+// network are refused, and redirects are not followed: the 3xx response is
+// recorded as a failed attempt.
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhookTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addr, err := netip.ParseAddrPort(address)
+			if err != nil || domain.WebhookAddressBlocked(addr.Addr()) {
+				return domain.ErrWebhookAddressBlocked
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   webhookTimeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Create registers a webhook with a generated signing secret. Requesting user must be admin.
+func (s *WebhookService) Create(ctx context.Context, requestingUserID, orgID uuid.UUID, cw domain.CreateWebhook) (*domain.Webhook, error) {
+	validate := validator.New()
+	err := validate.Struct(cw)
+	if err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	if err := cw.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem gerenciar webhooks"); err != nil {
+		return nil, err
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar segredo do webhook")
+	}
+
+	var webhook domain.Webhook
+	err = s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.webhookRepo.Create(ctx, domain.Webhook{
+			OrganizationID: orgID,
+			URL:            cw.URL,
+			Secret:         secret,
+			Events:         cw.Events,
+			Active:         true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		var ok bool
+		webhook, ok = res.Data.(domain.Webhook)
+		if !ok {
+			return fmt.Errorf("erro ao converter dados do webhook")
+		}
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditWebhookCreate, "webhook", webhook.ID.String(), nil, withoutSecret(webhook))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// List retrieves a page of the organization webhooks. Requesting user must be admin.
+func (s *WebhookService) List(ctx context.Context, requestingUserID, orgID uuid.UUID, page domain.PageRequest) (*domain.CursorPage[domain.Webhook], error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem gerenciar webhooks"); err != nil {
+		return nil, err
+	}
+
+	res, err := s.webhookRepo.ListByOrganization(ctx, orgID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	webhooks, ok := res.Data.(domain.CursorPage[domain.Webhook])
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados dos webhooks")
+	}
+
+	return &webhooks, nil
+}
+
+// Update changes the webhook URL, events or active flag. Requesting user must be admin.
+func (s *WebhookService) Update(ctx context.Context, requestingUserID, orgID, webhookID uuid.UUID, uw domain.UpdateWebhook) (*domain.Webhook, error) {
+	validate := validator.New()
+	err := validate.Struct(uw)
+	if err != nil {
+		return nil, err.(validator.ValidationErrors)
+	}
+
+	if err := uw.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem gerenciar webhooks"); err != nil {
+		return nil, err
+	}
+
+	before, err := s.getWebhook(ctx, orgID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhook domain.Webhook
+	err = s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.webhookRepo.Update(ctx, uw.Apply(*before))
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		var ok bool
+		webhook, ok = res.Data.(domain.Webhook)
+		if !ok {
+			return fmt.Errorf("erro ao converter dados do webhook")
+		}
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditWebhookUpdate, "webhook", webhookID.String(), withoutSecret(*before), withoutSecret(webhook))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// Delete removes a webhook and its delivery log. Requesting user must be admin.
+func (s *WebhookService) Delete(ctx context.Context, requestingUserID, orgID, webhookID uuid.UUID) error {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem gerenciar webhooks"); err != nil {
+		return err
+	}
+
+	before, err := s.getWebhook(ctx, orgID, webhookID)
+	if err != nil {
+		return err
+	}
+
+	return s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		res, err := s.webhookRepo.Delete(ctx, orgID, webhookID)
+		if err != nil {
+			return err
+		}
+
+		if !res.Success {
+			return fmt.Errorf("%s", res.Message)
+		}
+
+		return recordAudit(ctx, s.auditRepo, orgID, requestingUserID, domain.AuditWebhookDelete, "webhook", webhookID.String(), withoutSecret(*before), nil)
+	})
+}
+
+// Deliveries retrieves a page of the delivery log, newest first. A nil
+// webhookID lists every webhook of the organization. Requesting user must be admin.
+func (s *WebhookService) Deliveries(ctx context.Context, requestingUserID, orgID uuid.UUID, webhookID *uuid.UUID, page domain.PageRequest) (*domain.CursorPage[domain.WebhookDelivery], error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem gerenciar webhooks"); err != nil {
+		return nil, err
+	}
+
+	res, err := s.webhookRepo.ListDeliveries(ctx, orgID, webhookID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	deliveries, ok := res.Data.(domain.CursorPage[domain.WebhookDelivery])
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados das entregas")
+	}
+
+	return &deliveries, nil
+}
+
+// Redeliver queues a new delivery with the same event and payload of an
+// earlier one. Requesting user must be admin.
+func (s *WebhookService) Redeliver(ctx context.Context, requestingUserID, orgID, deliveryID uuid.UUID) (*domain.WebhookDelivery, error) {
+	if err := requireOrgAdmin(ctx, s.orgRepo, requestingUserID, orgID, "apenas administradores podem gerenciar webhooks"); err != nil {
+		return nil, err
+	}
+
+	res, err := s.webhookRepo.Redeliver(ctx, orgID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	delivery, ok := res.Data.(domain.WebhookDelivery)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados da entrega")
+	}
+
+	return &delivery, nil
+}
+
+// Run sends the queued deliveries until ctx is cancelled. Deliveries are
+// claimed with row locks, so several instances can run it at the same time.
+func (s *WebhookService) Run(ctx context.Context) {
+	ticker := time.NewTicker(webhookPollInterval)
+	defer ticker.Stop()
+
+	for {
+		// A full batch means more deliveries may already be due
+		if s.dispatchDue(ctx) == webhookBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatchDue sends one batch of due deliveries and returns how many were claimed
+func (s *WebhookService) dispatchDue(ctx context.Context) int {
+	res, err := s.webhookRepo.ClaimDue(ctx, webhookBatchSize, webhookLease)
+	if err != nil || !res.Success {
+		log.Printf("webhooks: %s: %v", res.Message, err)
+		return 0
+	}
+
+	due, ok := res.Data.([]domain.PendingWebhookDelivery)
+	if !ok {
+		return 0
+	}
+
+	var wg sync.WaitGroup
+	for _, d := range due {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.deliver(ctx, d)
+		}()
+	}
+	wg.Wait()
+
+	return len(due)
+}
+
+// deliver posts the delivery and records the attempt, scheduling a retry with
+// exponential backoff when the receiver does not answer with a 2xx status
+func (s *WebhookService) deliver(ctx context.Context, d domain.PendingWebhookDelivery) {
+	attempt := s.post(ctx, d)
+
+	var nextAttempt *time.Time
+	attempts := d.Attempts + 1
+	if !attempt.Succeeded() && attempts < domain.WebhookMaxAttempts {
+		next := time.Now().Add(domain.WebhookBackoff(attempts))
+		nextAttempt = &next
+	}
+
+	res, err := s.webhookRepo.RecordAttempt(ctx, d.ID, attempt, nextAttempt)
+	if err != nil {
+		log.Printf("webhooks: %s %s: %v", res.Message, d.ID, err)
+	}
+}
+
+func (s *WebhookService) post(ctx context.Context, d domain.PendingWebhookDelivery) domain.WebhookAttempt {
+	body, err := json.Marshal(d.Envelope())
+	if err != nil {
+		return domain.WebhookAttempt{Error: "erro ao montar o corpo da entrega"}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(body))
+	if err != nil {
+		return domain.WebhookAttempt{Error: err.Error()}
+	}
+
+	now := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "TimesheetPro-Webhooks/1.0")
+	req.Header.Set(domain.WebhookEventHeader, string(d.Event))
+	req.Header.Set(domain.WebhookDeliveryHeader, d.ID.String())
+	req.Header.Set(domain.WebhookTimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(domain.WebhookSignatureHeader, domain.SignWebhook(d.Secret, now, body))
+
+	resp, err := s.client.Do(req)
+	if errors.Is(err, domain.ErrWebhookAddressBlocked) {
+		return domain.WebhookAttempt{Error: domain.ErrWebhookAddressBlocked.Error()}
+	}
+	if err != nil {
+		return domain.WebhookAttempt{Error: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, webhookMaxResponseBody))
+	attempt := domain.WebhookAttempt{StatusCode: &resp.StatusCode, ResponseBody: string(respBody)}
+	if !attempt.Succeeded() {
+		attempt.Error = fmt.Sprintf("resposta %d", resp.StatusCode)
+	}
+	return attempt
+}
+
+func (s *WebhookService) getWebhook(ctx context.Context, orgID, webhookID uuid.UUID) (*domain.Webhook, error) {
+	res, err := s.webhookRepo.GetByID(ctx, orgID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !res.Success {
+		return nil, fmt.Errorf("%s", res.Message)
+	}
+
+	webhook, ok := res.Data.(domain.Webhook)
+	if !ok {
+		return nil, fmt.Errorf("erro ao converter dados do webhook")
+	}
+
+	return &webhook, nil
+}
+
+// enqueueWebhook queues data as the payload of event for the organization
+// webhooks subscribed to it. Call it with the context of a TxManager.WithTx so
+// the deliveries are only queued if the change they describe commits.
+func enqueueWebhook(ctx context.Context, webhookRepo *repository.WebhookRepository, orgID uuid.UUID, event domain.WebhookEvent, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	res, err := webhookRepo.Enqueue(ctx, orgID, event, payload)
+	if err != nil {
+		return err
+	}
+
+	if !res.Success {
+		return fmt.Errorf("%s", res.Message)
+	}
+
+	return nil
+}
+
+// withoutSecret hides the signing secret from audit snapshots
+func withoutSecret(w domain.Webhook) domain.Webhook {
+	w.Secret = ""
+	return w
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
@@ -85,7 +85,7 @@ func OrganizationAddUserPage(org domain.Organization, userName string) templ.Com
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "\" hx-target=\"#add-user-message\" hx-swap=\"innerHTML\" hx-ext=\"json-enc\"><div><label class=\"block text-sm font-medium text-gray-700\" for=\"email\">Email do Usuário</label><div class=\"mt-1\"><input autocomplete=\"email\" class=\"block w-full appearance-none rounded-md border border-gray-300 px-3 py-3 \r\n\t\t\t\t\t\t\t\t\tplaceholder-gray-400 shadow-sm focus:border-[var(--primary-color)] focus:outline-none \r\n\t\t\t\t\t\t\t\t\tfocus:ring-[var(--primary-color)] sm:text-sm\" id=\"email\" name=\"email\" placeholder=\"usuario@exemplo.com\" required=\"\" type=\"email\"></div><p class=\"mt-1 text-xs text-gray-500\">O usuário deve estar cadastrado no sistema</p></div><div><label class=\"block text-sm font-medium text-gray-700\" for=\"role\">Função</label><div class=\"mt-1\"><select class=\"block w-full appearance-none rounded-md border border-gray-300 px-3 py-3 \r\n\t\t\t\t\t\t\t\t\tshadow-sm focus:border-[var(--primary-color)] focus:outline-none \r\n\t\t\t\t\t\t\t\t\tfocus:ring-[var(--primary-color)] sm:text-sm\" id=\"role\" name=\"role\" required=\"\"><option value=\"\">Selecione uma função</option> <option value=\"member\">Membro</option> <option value=\"admin\">Administrador</option></select></div><p class=\"mt-1 text-xs text-gray-500\">Administradores podem editar a organização e adicionar usuários</p></div><div><button class=\"flex w-full justify-center rounded-md border border-transparent bg-[var(--primary-color)]\r\n\t\t\t\t\t\t\t\tpy-3 px-4 text-sm font-semibold text-white shadow-sm hover:bg-blue-700 focus:outline-none focus:ring-2\r\n\t\t\t\t\t\t\t\tfocus:ring-blue-500 focus:ring-offset-2\" type=\"submit\">Adicionar Usuário</button></div></form><div class=\"mt-6 rounded-md bg-blue-50 p-4\"><div class=\"flex\"><div class=\"flex-shrink-0\"><span class=\"material-symbols-outlined text-blue-400\">info</span></div><div class=\"ml-3\"><p class=\"text-sm text-blue-700\">Apenas administradores podem adicionar usuários à organização. O usuário receberá acesso assim que for adicionado.</p></div></div></div></div></div></div>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "\" hx-target=\"#add-user-message\" hx-swap=\"innerHTML\" hx-ext=\"json-enc\"><div><label class=\"block text-sm font-medium text-gray-700\" for=\"email\">Email do Usuário</label><div class=\"mt-1\"><input autocomplete=\"email\" class=\"block w-full appearance-none rounded-md border border-gray-300 px-3 py-3 \r\n\t\t\t\t\t\t\t\t\tplaceholder-gray-400 shadow-sm focus:border-[var(--primary-color)] focus:outline-none \r\n\t\t\t\t\t\t\t\t\tfocus:ring-[var(--primary-color)] sm:text-sm\" id=\"email\" name=\"email\" placeholder=\"usuario@exemplo.com\" required=\"\" type=\"email\"></div><p class=\"mt-1 text-xs text-gray-500\">O usuário deve estar cadastrado no sistema</p></div><div><label class=\"block text-sm font-medium text-gray-700\" for=\"role\">Função</label><div class=\"mt-1\"><select class=\"block w-full appearance-none rounded-md border border-gray-300 px-3 py-3 \r\n\t\t\t\t\t\t\t\t\tshadow-sm focus:border-[var(--primary-color)] focus:outline-none \r\n\t\t\t\t\t\t\t\t\tfocus:ring-[var(--primary-color)] sm:text-sm\" id=\"role\" name=\"role\" required=\"\"><option value=\"\">Selecione uma função</option> <option value=\"member\">Membro</option> <option value=\"manager\">Gestor</option> <option value=\"admin\">Administrador</option></select></div><p class=\"mt-1 text-xs text-gray-500\">Administradores podem editar a organização e adicionar usuários. Gestores acompanham e aprovam os pontos das suas equipes</p></div><div><button class=\"flex w-full justify-center rounded-md border border-transparent bg-[var(--primary-color)]\r\n\t\t\t\t\t\t\t\tpy-3 px-4 text-sm font-semibold text-white shadow-sm hover:bg-blue-700 focus:outline-none focus:ring-2\r\n\t\t\t\t\t\t\t\tfocus:ring-blue-500 focus:ring-offset-2\" type=\"submit\">Adicionar Usuário</button></div></form><div class=\"mt-6 rounded-md bg-blue-50 p-4\"><div class=\"flex\"><div class=\"flex-shrink-0\"><span class=\"material-symbols-outlined text-blue-400\">info</span></div><div class=\"ml-3\"><p class=\"text-sm text-blue-700\">Apenas administradores podem adicionar usuários à organização. O usuário receberá acesso assim que for adicionado.</p></div></div></div></div></div></div>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -73,72 +73,111 @@ func OrganizationEditPage(org domain.Organization, userName string) templ.Compon
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "\" required type=\"text\" minlength=\"3\" maxlength=\"100\"></div></div><div class=\"space-y-4 border-t border-gray-200 pt-4\"><h3 class=\"text-lg font-medium text-gray-900\">Endereço</h3><div class=\"grid grid-cols-1 gap-4 sm:grid-cols-2\"><div><label class=\"block text-sm font-medium text-gray-700\" for=\"zip_code\">CEP</label><div class=\"mt-1\"><input class=\"block w-full appearance-none rounded-md border border-gray-300 px-3 py-2 placeholder-gray-400 shadow-sm focus:border-[var(--primary-color)] focus:outline-none focus:ring-[var(--primary-color)] sm:text-sm\" id=\"zip_code\" name=\"zip_code\" value=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "\" required type=\"text\" minlength=\"3\" maxlength=\"100\"></div></div><div><label class=\"block text-sm font-medium text-gray-700\" for=\"cnpj\">CNPJ</label><div class=\"mt-1\"><input class=\"block w-full appearance-none rounded-md border border-gray-300 px-3 py-3 placeholder-gray-400 shadow-sm focus:border-[var(--primary-color)] focus:outline-none focus:ring-[var(--primary-color)] sm:text-sm\" id=\"cnpj\" name=\"cnpj\" value=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var5 string
-			templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(org.Address.ZipCode)
+			templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(org.CNPJ)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/templates/pages/organization_edit.templ`, Line: 66, Col: 38}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/templates/pages/organization_edit.templ`, Line: 61, Col: 25}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "\" placeholder=\"00000-000\" required type=\"text\" onblur=\"checkCEP(this.value)\"></div></div><div><label class=\"block text-sm font-medium text-gray-700\" for=\"city\">Cidade</label><div class=\"mt-1\"><input class=\"block w-full appearance-none rounded-md border border-gray-300 px-3 py-2 bg-gray-50 text-gray-600 sm:text-sm\" id=\"city\" name=\"city\" value=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "\" type=\"text\" inputmode=\"numeric\" placeholder=\"00.000.000/0000-00\"></div></div><div class=\"space-y-4 border-t border-gray-200 pt-4\"><h3 class=\"text-lg font-medium text-gray-900\">Endereço</h3><div class=\"grid grid-cols-1 gap-4 sm:grid-cols-2\"><div><label class=\"block text-sm font-medium text-gray-700\" for=\"zip_code\">CEP</label><div class=\"mt-1\"><input class=\"block w-full appearance-none rounded-md border border-gray-300 px-3 py-2 placeholder-gray-400 shadow-sm focus:border-[var(--primary-color)] focus:outline-none focus:ring-[var(--primary-color)] sm:text-sm\" id=\"zip_code\" name=\"zip_code\" value=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var6 string
-			templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(org.Address.City)
+			templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(org.Address.ZipCode)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/templates/pages/organization_edit.templ`, Line: 83, Col: 35}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/templates/pages/organization_edit.templ`, Line: 82, Col: 38}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "\" required type=\"text\" readonly></div></div><div><label class=\"block text-sm font-medium text-gray-700\" for=\"state\">Estado</label><div class=\"mt-1\"><input class=\"block w-full appearance-none rounded-md border border-gray-300 px-3 py-2 bg-gray-50 text-gray-600 sm:text-sm\" id=\"state\" name=\"state\" value=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "\" placeholder=\"00000-000\" required type=\"text\" onblur=\"checkCEP(this.value)\"></div></div><div><label class=\"block text-sm font-medium text-gray-700\" for=\"city\">Cidade</label><div class=\"mt-1\"><input class=\"block w-full appearance-none rounded-md border border-gray-300 px-3 py-2 bg-gray-50 text-gray-600 sm:text-sm\" id=\"city\" name=\"city\" value=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var7 string
-			templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(org.Address.State)
+			templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(org.Address.City)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/templates/pages/organization_edit.templ`, Line: 99, Col: 36}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/templates/pages/organization_edit.templ`, Line: 99, Col: 35}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "\" required type=\"text\" readonly></div></div><div class=\"sm:col-span-2\"><label class=\"block text-sm font-medium text-gray-700\" for=\"public_place\">Logradouro</label><div class=\"mt-1\"><input class=\"block w-full appearance-none rounded-md border border-gray-300 px-3 py-2 bg-gray-50 text-gray-600 sm:text-sm\" id=\"public_place\" name=\"public_place\" value=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "\" required type=\"text\" readonly></div></div><div><label class=\"block text-sm font-medium text-gray-700\" for=\"state\">Estado</label><div class=\"mt-1\"><input class=\"block w-full appearance-none rounded-md border border-gray-300 px-3 py-2 bg-gray-50 text-gray-600 sm:text-sm\" id=\"state\" name=\"state\" value=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var8 string
-			templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(org.Address.PublicPlace)
+			templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(org.Address.State)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/templates/pages/organization_edit.templ`, Line: 115, Col: 42}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/templates/pages/organization_edit.templ`, Line: 115, Col: 36}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "\" required type=\"text\" readonly></div></div><div class=\"sm:col-span-2\"><label class=\"block text-sm font-medium text-gray-700\" for=\"complement\">Complemento</label><div class=\"mt-1\"><input class=\"block w-full appearance-none rounded-md border border-gray-300 px-3 py-2 placeholder-gray-400 shadow-sm focus:border-[var(--primary-color)] focus:outline-none focus:ring-[var(--primary-color)] sm:text-sm\" id=\"complement\" name=\"complement\" value=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "\" required type=\"text\" readonly></div></div><div class=\"sm:col-span-2\"><label class=\"block text-sm font-medium text-gray-700\" for=\"public_place\">Logradouro</label><div class=\"mt-1\"><input class=\"block w-full appearance-none rounded-md border border-gray-300 px-3 py-2 bg-gray-50 text-gray-600 sm:text-sm\" id=\"public_place\" name=\"public_place\" value=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var9 string
-			templ_7745c5c3_Var9, templ_7745c5c3_Err = templ.JoinStringErrs(org.Address.Complement)
+			templ_7745c5c3_Var9, templ_7745c5c3_Err = templ.JoinStringErrs(org.Address.PublicPlace)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/templates/pages/organization_edit.templ`, Line: 131, Col: 41}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/templates/pages/organization_edit.templ`, Line: 131, Col: 42}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var9))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "\" placeholder=\"Apto, Sala, Bloco, etc.\" type=\"text\"></div></div></div></div><div><button class=\"flex w-full justify-center rounded-md border border-transparent bg-[var(--primary-color)] py-3 px-4 text-sm font-semibold text-white shadow-sm hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-blue-500 focus:ring-offset-2\" type=\"submit\">Salvar Alterações</button></div></form></div><script>\r\n\t\t\t\t\tfunction checkCEP(cep) {\r\n\t\t\t\t\t\tvar cleanCep = cep.replace(/\\D/g, '');\r\n\t\t\t\t\t\tif (cleanCep.length === 8) {\r\n\t\t\t\t\t\t\tdocument.getElementById('public_place').value = \"...\";\r\n\t\t\t\t\t\t\tfetch(`https://viacep.com.br/ws/${cleanCep}/json/`)\r\n\t\t\t\t\t\t\t\t.then(response => response.json())\r\n\t\t\t\t\t\t\t\t.then(data => {\r\n\t\t\t\t\t\t\t\t\tif (!data.erro) {\r\n\t\t\t\t\t\t\t\t\t\tdocument.getElementById('public_place').value = data.logradouro;\r\n\t\t\t\t\t\t\t\t\t\tdocument.getElementById('city').value = data.localidade;\r\n\t\t\t\t\t\t\t\t\t\tdocument.getElementById('state').value = data.uf;\r\n\t\t\t\t\t\t\t\t\t\tdocument.getElementById('complement').focus();\r\n\t\t\t\t\t\t\t\t\t} else {\r\n\t\t\t\t\t\t\t\t\t\talert(\"CEP não encontrado.\");\r\n\t\t\t\t\t\t\t\t\t\tdocument.getElementById('public_place').value = \"\";\r\n\t\t\t\t\t\t\t\t\t}\r\n\t\t\t\t\t\t\t\t})\r\n\t\t\t\t\t\t\t\t.catch(err => console.error(err));\r\n\t\t\t\t\t\t}\r\n\t\t\t\t\t}\r\n\t\t\t\t</script></div></div>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "\" required type=\"text\" readonly></div></div><div class=\"sm:col-span-2\"><label class=\"block text-sm font-medium text-gray-700\" for=\"complement\">Complemento</label><div class=\"mt-1\"><input class=\"block w-full appearance-none rounded-md border border-gray-300 px-3 py-2 placeholder-gray-400 shadow-sm focus:border-[var(--primary-color)] focus:outline-none focus:ring-[var(--primary-color)] sm:text-sm\" id=\"complement\" name=\"complement\" value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var10 string
+			templ_7745c5c3_Var10, templ_7745c5c3_Err = templ.JoinStringErrs(org.Address.Complement)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/templates/pages/organization_edit.templ`, Line: 147, Col: 41}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var10))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, "\" placeholder=\"Apto, Sala, Bloco, etc.\" type=\"text\"></div></div><div><label class=\"block text-sm font-medium text-gray-700\" for=\"latitude\">Latitude</label><div class=\"mt-1\"><input class=\"block w-full appearance-none rounded-md border border-gray-300 px-3 py-2 placeholder-gray-400 shadow-sm focus:border-[var(--primary-color)] focus:outline-none focus:ring-[var(--primary-color)] sm:text-sm\" id=\"latitude\" name=\"latitude\" value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var11 string
+			templ_7745c5c3_Var11, templ_7745c5c3_Err = templ.JoinStringErrs(coordinateValue(org.Address.Latitude))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/templates/pages/organization_edit.templ`, Line: 162, Col: 56}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var11))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "\" placeholder=\"-23.550520\" type=\"text\" inputmode=\"decimal\"></div></div><div><label class=\"block text-sm font-medium text-gray-700\" for=\"longitude\">Longitude</label><div class=\"mt-1\"><input class=\"block w-full appearance-none rounded-md border border-gray-300 px-3 py-2 placeholder-gray-400 shadow-sm focus:border-[var(--primary-color)] focus:outline-none focus:ring-[var(--primary-color)] sm:text-sm\" id=\"longitude\" name=\"longitude\" value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var12 string
+			templ_7745c5c3_Var12, templ_7745c5c3_Err = templ.JoinStringErrs(coordinateValue(org.Address.Longitude))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/templates/pages/organization_edit.templ`, Line: 176, Col: 57}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var12))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 11, "\" placeholder=\"-46.633308\" type=\"text\" inputmode=\"decimal\"></div></div></div></div><div><button class=\"flex w-full justify-center rounded-md border border-transparent bg-[var(--primary-color)] py-3 px-4 text-sm font-semibold text-white shadow-sm hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-blue-500 focus:ring-offset-2\" type=\"submit\">Salvar Alterações</button></div></form></div><script>\r\n\t\t\t\t\tfunction checkCEP(cep) {\r\n\t\t\t\t\t\tvar cleanCep = cep.replace(/\\D/g, '');\r\n\t\t\t\t\t\tif (cleanCep.length === 8) {\r\n\t\t\t\t\t\t\tdocument.getElementById('public_place').value = \"...\";\r\n\t\t\t\t\t\t\tfetch(`https://viacep.com.br/ws/${cleanCep}/json/`)\r\n\t\t\t\t\t\t\t\t.then(response => response.json())\r\n\t\t\t\t\t\t\t\t.then(data => {\r\n\t\t\t\t\t\t\t\t\tif (!data.erro) {\r\n\t\t\t\t\t\t\t\t\t\tdocument.getElementById('public_place').value = data.logradouro;\r\n\t\t\t\t\t\t\t\t\t\tdocument.getElementById('city').value = data.localidade;\r\n\t\t\t\t\t\t\t\t\t\tdocument.getElementById('state').value = data.uf;\r\n\t\t\t\t\t\t\t\t\t\tdocument.getElementById('complement').focus();\r\n\t\t\t\t\t\t\t\t\t} else {\r\n\t\t\t\t\t\t\t\t\t\talert(\"CEP não encontrado.\");\r\n\t\t\t\t\t\t\t\t\t\tdocument.getElementById('public_place').value = \"\";\r\n\t\t\t\t\t\t\t\t\t}\r\n\t\t\t\t\t\t\t\t})\r\n\t\t\t\t\t\t\t\t.catch(err => console.error(err));\r\n\t\t\t\t\t\t}\r\n\t\t\t\t\t}\r\n\t\t\t\t</script></div></div>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -152,4 +191,12 @@ func OrganizationEditPage(org domain.Organization, userName string) templ.Compon
 	})
 }
 
+// coordinateValue formats an optional address coordinate for the form
+func coordinateValue(c *float64) string {
+	if c == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.6f", *c)
+}
+
 var _ = templruntime.GeneratedTemplate
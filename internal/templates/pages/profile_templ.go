@@ -85,7 +85,20 @@ func ProfilePage(user domain.User, userName string) templ.Component {
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "\" required class=\"mt-1 block w-full rounded-md border border-gray-300 px-3 py-2 shadow-sm focus:border-blue-500 focus:outline-none focus:ring-1 focus:ring-blue-500 sm:text-sm\"></div><!-- Success/Error Messages --><div id=\"message\" class=\"hidden\"></div><!-- Buttons --><div class=\"flex justify-end gap-3\"><a href=\"/\" class=\"inline-flex items-center gap-2 rounded-md bg-white px-4 py-2 text-sm font-semibold text-gray-700 shadow-sm ring-1 ring-inset ring-gray-300 hover:bg-gray-50\">Cancelar</a> <button type=\"submit\" class=\"inline-flex items-center gap-2 rounded-md bg-[var(--primary-color)] px-4 py-2 text-sm font-semibold text-white shadow-sm hover:bg-blue-700 focus-visible:outline focus-visible:outline-2 focus-visible:outline-offset-2 focus-visible:outline-blue-600\"><span class=\"material-symbols-outlined text-lg\">save</span> Salvar Alterações</button></div></form></div></main></div><script>\r\n\t\tfunction showSuccess() {\r\n\t\t\tconst msg = document.getElementById('message');\r\n\t\t\tmsg.className = 'rounded-md bg-green-50 p-4';\r\n\t\t\tmsg.innerHTML = '<div class=\"flex\"><span class=\"material-symbols-outlined text-green-400 mr-3\">check_circle</span><p class=\"text-sm text-green-800\">Perfil atualizado com sucesso!</p></div>';\r\n\t\t\tsetTimeout(() => {\r\n\t\t\t\tmsg.className = 'hidden';\r\n\t\t\t}, 3000);\r\n\t\t}\r\n\r\n\t\tfunction showError(event) {\r\n\t\t\tconst msg = document.getElementById('message');\r\n\t\t\tconst response = JSON.parse(event.detail.xhr.response);\r\n\t\t\tmsg.className = 'rounded-md bg-red-50 p-4';\r\n\t\t\tmsg.innerHTML = '<div class=\"flex\"><span class=\"material-symbols-outlined text-red-400 mr-3\">error</span><p class=\"text-sm text-red-800\">' + (response.message || 'Erro ao atualizar perfil') + '</p></div>';\r\n\t\t\tsetTimeout(() => {\r\n\t\t\t\tmsg.className = 'hidden';\r\n\t\t\t}, 5000);\r\n\t\t}\r\n\t\t</script>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "\" required class=\"mt-1 block w-full rounded-md border border-gray-300 px-3 py-2 shadow-sm focus:border-blue-500 focus:outline-none focus:ring-1 focus:ring-blue-500 sm:text-sm\"></div><!-- CPF Field --><div><label for=\"cpf\" class=\"block text-sm font-medium text-gray-700\">CPF</label> <input type=\"text\" id=\"cpf\" name=\"cpf\" value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var6 string
+			templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(user.CPF)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/templates/pages/profile.templ`, Line: 71, Col: 24}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "\" inputmode=\"numeric\" placeholder=\"000.000.000-00\" class=\"mt-1 block w-full rounded-md border border-gray-300 px-3 py-2 shadow-sm focus:border-blue-500 focus:outline-none focus:ring-1 focus:ring-blue-500 sm:text-sm\"><p class=\"mt-1 text-xs text-gray-500\">Exigido nos arquivos fiscais de ponto (AFD/AEJ).</p></div><!-- Success/Error Messages --><div id=\"message\" class=\"hidden\"></div><!-- Buttons --><div class=\"flex justify-end gap-3\"><a href=\"/\" class=\"inline-flex items-center gap-2 rounded-md bg-white px-4 py-2 text-sm font-semibold text-gray-700 shadow-sm ring-1 ring-inset ring-gray-300 hover:bg-gray-50\">Cancelar</a> <button type=\"submit\" class=\"inline-flex items-center gap-2 rounded-md bg-[var(--primary-color)] px-4 py-2 text-sm font-semibold text-white shadow-sm hover:bg-blue-700 focus-visible:outline focus-visible:outline-2 focus-visible:outline-offset-2 focus-visible:outline-blue-600\"><span class=\"material-symbols-outlined text-lg\">save</span> Salvar Alterações</button></div></form></div></main></div><script>\r\n\t\tfunction showSuccess() {\r\n\t\t\tconst msg = document.getElementById('message');\r\n\t\t\tmsg.className = 'rounded-md bg-green-50 p-4';\r\n\t\t\tmsg.innerHTML = '<div class=\"flex\"><span class=\"material-symbols-outlined text-green-400 mr-3\">check_circle</span><p class=\"text-sm text-green-800\">Perfil atualizado com sucesso!</p></div>';\r\n\t\t\tsetTimeout(() => {\r\n\t\t\t\tmsg.className = 'hidden';\r\n\t\t\t}, 3000);\r\n\t\t}\r\n\r\n\t\tfunction showError(event) {\r\n\t\t\tconst msg = document.getElementById('message');\r\n\t\t\tconst response = JSON.parse(event.detail.xhr.response);\r\n\t\t\tmsg.className = 'rounded-md bg-red-50 p-4';\r\n\t\t\tmsg.innerHTML = '<div class=\"flex\"><span class=\"material-symbols-outlined text-red-400 mr-3\">error</span><p class=\"text-sm text-red-800\">' + (response.message || 'Erro ao atualizar perfil') + '</p></div>';\r\n\t\t\tsetTimeout(() => {\r\n\t\t\t\tmsg.className = 'hidden';\r\n\t\t\t}, 5000);\r\n\t\t}\r\n\t\t</script>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}